@@ -1,6 +1,8 @@
 package app
 
 import (
+	"os"
+	"path/filepath"
 	"runtime"
 	"unicode"
 
@@ -20,7 +22,8 @@ type windowInfo struct {
 
 // App is a singleton with the context of the entire Application
 type App struct {
-	windows []*windowInfo // List of opened native windows
+	windows      []*windowInfo // List of opened native windows
+	fontCacheDir string        // Directory used to cache the font atlases (disabled if empty)
 }
 
 // Single Application instance
@@ -70,14 +73,35 @@ func (a *App) Render() bool {
 		if wi.view != nil {
 			// Dispatch events to the top view
 			view.DispatchEvents(wi.w, wi.view)
-			// Render top view and its children
+			// Layout and render top view and its children
+			view.Layout(wi.w, wi.view)
 			wi.view.Render(wi.w)
+			view.RenderOverlays(wi.w)
 		}
 		wi.w.RenderFrame()
 	}
 	return false
 }
 
+// SetFontCacheDir sets the directory used to cache the font atlases built for the
+// windows created after this call. The cache is disabled by default.
+// An empty directory name disables the cache.
+func (a *App) SetFontCacheDir(dir string) {
+
+	a.fontCacheDir = dir
+}
+
+// UserFontCacheDir returns the default directory to cache font atlases
+// inside the user cache directory.
+func UserFontCacheDir() (string, error) {
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "gux", "fonts"), nil
+}
+
 // NewWindow creates and returns a new application window
 func (a *App) NewWindow(title string, width, height int) (*window.Window, error) {
 
@@ -146,6 +170,7 @@ func (a *App) createFontManager(w *window.Window) error {
 		return err
 	}
 
+	fm.SetCacheDir(a.fontCacheDir)
 	err = fm.AddStyle(window.FontRegular, goregular.TTF)
 	if err != nil {
 		return err
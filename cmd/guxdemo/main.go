@@ -1,18 +1,28 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"math"
+	"sort"
+	"strings"
 
 	"github.com/leonsal/gux/app"
 	"github.com/leonsal/gux/color"
 	"github.com/leonsal/gux/gb"
 	"github.com/leonsal/gux/view"
+	"github.com/leonsal/gux/window"
 )
 
 func main() {
 
 	a := app.Get()
 
+	// Caches the built font atlases in the user cache directory
+	if dir, err := app.UserFontCacheDir(); err == nil {
+		a.SetFontCacheDir(dir)
+	}
+
 	// First Window
 	cfg := gb.Config{}
 	cfg.DebugPrintCmds = false
@@ -23,16 +33,132 @@ func main() {
 	}
 	w1.SetClearColor(gb.Vec4{0.6, 0.6, 0.6, 1})
 
-	group := view.NewGroup()
-	l1 := view.NewLabel("This is a label 1")
-	l1.SetPos(100, 100)
-	l1.SetStyleColor(view.StyleColorText, color.Darkred)
+	radio1 := view.NewRadioButton("Radio 1")
+	radio2 := view.NewRadioButton("Radio 2")
+	view.NewRadioGroup(radio1, radio2).Select(0)
+
+	fruits := view.StringList{"Apple", "Apricot", "Banana", "Blackberry", "Blueberry", "Cherry", "Grape", "Kiwi",
+		"Lemon", "Lime", "Mango", "Melon", "Orange", "Peach", "Pear", "Plum", "Raspberry", "Strawberry"}
+
+	group := view.With(view.NewGroup(), view.Margin(view.Insets{Top: 200, Left: 200})).Add(
+		view.With(view.NewLabel("This is a label 1"), view.Pos(100, 100), view.Color(view.StyleColorText, color.Darkred)),
+		view.With(view.NewLabel("This is a label 2"), view.Pos(100, 200)),
+		view.With(view.NewButton("Button 1"), view.Pos(100, 300), view.Tooltip("Logs a message when clicked"),
+			view.Do(func(b *view.Button) {
+				b.OnClick(func(b *view.Button) { log.Printf("%s clicked", b.Text()) })
+			})),
+		view.With(view.NewVBox(), view.Pos(100, 400)).Add(
+			view.With(view.NewCheckBox("Tri-state check box"), view.Do(func(c *view.CheckBox) { c.SetTriState(true) })),
+			radio1,
+			radio2,
+			view.NewSwitch("Switch"),
+			view.With(view.NewTextEdit(""), view.Do(func(t *view.TextEdit) { t.SetPlaceholder("Type here") })),
+			view.With(view.NewTextEdit("secret"), view.Do(func(t *view.TextEdit) { t.SetPassword(true) })),
+		),
+		view.With(view.NewTextArea("Multi-line text\nwith undo and redo"), view.Pos(400, 400), view.PrefSize(300, 160),
+			view.Do(func(t *view.TextArea) { t.SetWrap(true) })),
+		view.With(view.NewVBox(), view.Pos(750, 400)).Add(
+			view.With(view.NewSlider(view.Horizontal, 0, 100), view.Do(func(s *view.Slider) { s.SetStep(1) })),
+			view.With(view.NewSlider(view.Horizontal, 0.01, 100), view.Do(func(s *view.Slider) { s.SetLogarithmic(true) })),
+			view.NewDragNumber(math.Inf(-1), math.Inf(1)),
+			view.With(view.NewSpinBox(0, 10), view.Do(func(s *view.SpinBox) { s.SetStep(0.5); s.SetFormat("%.1f mm") })),
+			view.With(view.NewComboBox(fruits), view.Do(func(c *view.ComboBox) {
+				c.SetSelected(0)
+				c.OnChange(func(c *view.ComboBox) { log.Printf("combo selected %d: %s", c.Selected(), c.Text()) })
+			})),
+			view.With(view.NewComboBox(fruits), view.Do(func(c *view.ComboBox) { c.SetEditable(true); c.SetPlaceholder("Fruit") })),
+			view.With(view.NewProgressBar(view.Horizontal), view.Do(func(p *view.ProgressBar) { p.SetValue(0.42); p.SetShowText(true) })),
+			view.With(view.NewHBox(), view.Do(func(b *view.HBox) { b.SetAlign(view.AlignCenter); b.SetSpacing(6) })).Add(
+				view.NewSpinner(),
+				view.NewLabel("Working..."),
+			),
+		),
+	)
+
+	items := view.NewVBox()
+	for i := 0; i < 50; i++ {
+		items.Add(view.NewLabel(fmt.Sprintf("Scrolled label %d", i)))
+	}
+	group.Add(view.With(view.NewScrollView(items), view.Pos(750, 100), view.PrefSize(200, 250),
+		view.Do(func(s *view.ScrollView) { s.SetKinetic(true) })))
 
-	l2 := view.NewLabel("This is a label 2")
-	l2.SetPos(100, 200)
-	group.Add(l1)
-	group.Add(l2)
-	group.SetPos(200, 200)
+	rows := make(view.StringList, 100000)
+	for i := range rows {
+		rows[i] = fmt.Sprintf("List item %d", i)
+	}
+	group.Add(view.With(view.NewListView(rows), view.Pos(1000, 100), view.PrefSize(180, 250),
+		view.Do(func(lv *view.ListView) { lv.SetSelectionMode(view.SelectMulti) })))
+
+	group.Add(view.With(view.NewTreeView(demoTree{}), view.Pos(1000, 400), view.PrefSize(180, 250)))
+
+	table := view.NewTable(newDemoTable(10000))
+	table.AddColumn("Row", 60).Sortable = true
+	table.AddColumn("Square", 100).Align = view.AlignEnd
+	table.AddColumn("Hex", 80).Sortable = true
+	table.SetSelectionMode(view.SelectMulti)
+	group.Add(view.With(table, view.Pos(100, 700), view.PrefSize(400, 200)))
+
+	tabs := view.NewTabView()
+	for i := 0; i < 8; i++ {
+		tabs.SetTabCloseable(tabs.AddTab(fmt.Sprintf("Tab %d", i), view.NewLabel(fmt.Sprintf("Contents of tab %d", i))), i > 0)
+	}
+	group.Add(view.With(tabs, view.Pos(550, 700), view.PrefSize(300, 150)))
+
+	left := view.NewPanel().Add(view.NewLabel("Left"))
+	bottom := view.NewPanel().Add(view.NewLabel("Bottom"))
+	right := view.NewSplitter(view.Vertical).Add(view.NewPanel().Add(view.NewLabel("Top")), bottom)
+	split := view.NewSplitter(view.Horizontal).Add(left, right)
+	split.SetPaneLimits(left, 60, 200)
+	right.SetPaneLimits(bottom, 40, 0)
+	group.Add(view.With(split, view.Pos(900, 700), view.PrefSize(280, 150)))
+
+	fileMenu := view.NewMenu()
+	fileMenu.AddItem("&New", func(mi *view.MenuItem) { log.Printf("%s clicked", mi.Text()) }).SetShortcut(gb.KeyN, gb.ModControl)
+	fileMenu.AddItem("&Open...", func(mi *view.MenuItem) { log.Printf("%s clicked", mi.Text()) }).SetShortcut(gb.KeyO, gb.ModControl)
+	recent := view.NewMenu()
+	for i := 1; i <= 3; i++ {
+		recent.AddItem(fmt.Sprintf("&%d file%d.txt", i, i), nil)
+	}
+	fileMenu.AddSubmenu("&Recent", recent)
+	fileMenu.AddSeparator()
+	fileMenu.AddItem("&Quit", nil).SetShortcut(gb.KeyQ, gb.ModControl)
+	viewMenu := view.NewMenu()
+	viewMenu.AddItem("Show &grid", nil).SetCheckable(true)
+	viewMenu.AddItem("&Disabled", nil).SetEnabled(false)
+	group.Add(view.With(view.NewMenuBar(), view.Pos(0, 0)).Add("&File", fileMenu).Add("&View", viewMenu))
+
+	contextMenu := view.NewMenu()
+	contextMenu.AddItem("Cu&t", nil).SetShortcut(gb.KeyX, gb.ModControl)
+	contextMenu.AddItem("&Copy", nil).SetShortcut(gb.KeyC, gb.ModControl)
+	contextMenu.AddItem("&Paste", nil).SetShortcut(gb.KeyV, gb.ModControl)
+	group.SetContextMenu(contextMenu)
+
+	dialog := view.NewPanel()
+	dialog.Add(view.NewVBox().Add(
+		view.NewLabel("This is a modal dialog"),
+		view.With(view.NewButton("Close"), view.Do(func(b *view.Button) {
+			b.OnClick(func(*view.Button) { view.CloseOverlay(w1, dialog) })
+		})),
+	))
+	popup := view.NewPanel().Add(view.NewLabel("Click outside to close"))
+	picker := view.NewColorPicker(color.Steelblue)
+	picker.OnChange(func(cp *view.ColorPicker) { log.Printf("color %s", cp.Color().Hex()) })
+	pickerPopup := view.NewPanel().Add(picker)
+	group.Add(view.With(view.NewHBox(), view.Pos(100, 950)).Add(
+		view.With(view.NewButton("Dialog"), view.Do(func(b *view.Button) {
+			b.OnClick(func(*view.Button) { view.ShowModal(w1, dialog) })
+		})),
+		view.With(view.NewButton("Popup"), view.Do(func(b *view.Button) {
+			b.OnClick(func(b *view.Button) {
+				view.ShowPopup(w1, popup, b.WindowRect(gb.Rect{Max: b.Size()}), view.PlaceAbove)
+			})
+		})),
+		view.With(view.NewButton("Color"), view.Do(func(b *view.Button) {
+			b.OnClick(func(b *view.Button) {
+				view.ShowPopup(w1, pickerPopup, b.WindowRect(gb.Rect{Max: b.Size()}), view.PlaceAbove)
+			})
+		})),
+	))
 
 	a.SetView(w1, group)
 
@@ -48,3 +174,66 @@ func main() {
 	}
 	a.Close()
 }
+
+// demoTree is a tree data source with nodes identified by their paths
+type demoTree struct{}
+
+func (demoTree) Children(node any) []any {
+
+	path, _ := node.(string)
+	children := make([]any, 5)
+	for i := range children {
+		children[i] = fmt.Sprintf("%s/%d", path, i)
+	}
+	return children
+}
+
+func (demoTree) HasChildren(node any) bool {
+
+	return strings.Count(node.(string), "/") < 4
+}
+
+func (demoTree) RenderNode(w *window.Window, item *view.TreeItem) {
+
+	item.DrawText(w, item.Node.(string))
+}
+
+// demoTable is a table data source with a row for each integer
+type demoTable []int
+
+func newDemoTable(rows int) demoTable {
+
+	t := make(demoTable, rows)
+	for i := range t {
+		t[i] = i
+	}
+	return t
+}
+
+func (t demoTable) Rows() int {
+
+	return len(t)
+}
+
+func (t demoTable) Sort(column int, ascending bool) {
+
+	sort.Slice(t, func(i, j int) bool {
+		if ascending {
+			return t[i] < t[j]
+		}
+		return t[i] > t[j]
+	})
+}
+
+func (t demoTable) RenderCell(w *window.Window, cell *view.TableCell) {
+
+	v := t[cell.Row]
+	switch cell.Column {
+	case 0:
+		cell.DrawText(w, fmt.Sprint(v))
+	case 1:
+		cell.DrawText(w, fmt.Sprint(v*v))
+	case 2:
+		cell.DrawText(w, fmt.Sprintf("%#x", v))
+	}
+}
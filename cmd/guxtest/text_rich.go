@@ -0,0 +1,88 @@
+package main
+
+import (
+	"log"
+	"unicode"
+
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func init() {
+
+	registerTest("text_rich", 13, newTestTextRich)
+}
+
+type testTextRich struct {
+	fm     *window.FontManager
+	layout *window.RichTextLayout
+}
+
+func newTestTextRich(win *window.Window) ITest {
+
+	t := new(testTextRich)
+
+	// Creates FontManager with the styles used by the text runs
+	fm, err := window.NewFontManager(32, 2, 4, util.AsciiSet(), util.RangeTableSet(unicode.Latin))
+	if err != nil {
+		log.Fatal(err)
+	}
+	styles := map[window.FontStyleType][]byte{
+		window.FontRegular: goregular.TTF,
+		window.FontBold:    gobold.TTF,
+		window.FontItalic:  goitalic.TTF,
+		window.FontMono:    gomono.TTF,
+	}
+	for ff, data := range styles {
+		err = fm.AddStyle(ff, data)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	err = fm.BuildFonts(win)
+	if err != nil {
+		log.Fatal(err)
+	}
+	t.fm = fm
+
+	black := gb.MakeColor(0, 0, 0, 255)
+	runs := []window.TextRun{
+		{Text: "Rich text ", Style: window.FontRegular, Color: black},
+		{Text: "with bold, ", Style: window.FontBold, Color: gb.MakeColor(200, 0, 0, 255)},
+		{Text: "italic, ", Style: window.FontItalic, Color: gb.MakeColor(0, 0, 200, 255)},
+		{Text: "LARGER ", Style: window.FontBold, RelSize: 4, Color: black},
+		{Text: "and smaller ", Style: window.FontRegular, RelSize: -2, Color: black},
+		{Text: "sizes sharing the same baseline.\n", Style: window.FontRegular, Color: black},
+		{Text: "func", Style: window.FontMono, Color: gb.MakeColor(0, 0, 180, 255)},
+		{Text: " main() { ", Style: window.FontMono, Color: black},
+		{Text: "// comment", Style: window.FontMono, Color: gb.MakeColor(0, 128, 0, 255)},
+		{Text: " }\n", Style: window.FontMono, Color: black},
+		{Text: "underlined", Style: window.FontRegular, Color: black, Underline: true},
+		{Text: " ", Style: window.FontRegular, Color: black},
+		{Text: "struck", Style: window.FontRegular, Color: black, Strike: true},
+		{Text: " ", Style: window.FontRegular, Color: black},
+		{Text: "highlighted", Style: window.FontRegular, Color: black, Background: gb.MakeColor(255, 255, 0, 255)},
+		{Text: " and a long sentence which should be wrapped at the layout maximum width.", Style: window.FontRegular, Color: black},
+	}
+	t.layout = window.NewRichTextLayout(fm, runs, 900)
+	return t
+}
+
+func (t *testTextRich) draw(win *window.Window) {
+
+	dl := win.DrawList()
+	pos := gb.Vec2{50, 50}
+	win.AddRect(dl, pos, gb.Vec2Add(pos, t.layout.Size), gb.MakeColor(0, 0, 0, 60), 0, 0, 1)
+	win.AddRichText(dl, pos, t.layout)
+}
+
+func (t *testTextRich) destroy(win *window.Window) {
+
+	t.fm.DestroyFonts(win)
+	log.Println("Destroy font manager fonts")
+}
@@ -1,6 +1,13 @@
 package color
 
-import "github.com/leonsal/gux/gb"
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/leonsal/gux/gb"
+)
 
 type Color struct {
 	R float32
@@ -24,6 +31,285 @@ func ColorFromRGBA(rgba gb.RGBA) Color {
 	}
 }
 
+// Lerp returns the linear interpolation between this color and the other
+// for the specified factor between 0 and 1.
+func (c Color) Lerp(other Color, t float32) Color {
+
+	return Color{
+		R: c.R + (other.R-c.R)*t,
+		G: c.G + (other.G-c.G)*t,
+		B: c.B + (other.B-c.B)*t,
+		A: c.A + (other.A-c.A)*t,
+	}
+}
+
+// FromHSV returns the color with the specified hue in degrees, saturation, value and alpha between 0 and 1
+func FromHSV(h, s, v, a float32) Color {
+
+	h = float32(math.Mod(float64(h), 360))
+	if h < 0 {
+		h += 360
+	}
+	sector := h / 60
+	i := int(sector)
+	f := sector - float32(i)
+	p := v * (1 - s)
+	q := v * (1 - s*f)
+	t := v * (1 - s*(1-f))
+	switch i {
+	case 0:
+		return Color{v, t, p, a}
+	case 1:
+		return Color{q, v, p, a}
+	case 2:
+		return Color{p, v, t, a}
+	case 3:
+		return Color{p, q, v, a}
+	case 4:
+		return Color{t, p, v, a}
+	default:
+		return Color{v, p, q, a}
+	}
+}
+
+// HSV returns the hue in degrees between 0 and 360 and the saturation and value between 0 and 1 of this color.
+// The hue of grays is 0.
+func (c Color) HSV() (h, s, v float32) {
+
+	max := float32(math.Max(float64(c.R), math.Max(float64(c.G), float64(c.B))))
+	min := float32(math.Min(float64(c.R), math.Min(float64(c.G), float64(c.B))))
+	v = max
+	delta := max - min
+	if max > 0 {
+		s = delta / max
+	}
+	if delta == 0 {
+		return 0, s, v
+	}
+	switch max {
+	case c.R:
+		h = (c.G - c.B) / delta
+		if h < 0 {
+			h += 6
+		}
+	case c.G:
+		h = (c.B-c.R)/delta + 2
+	default:
+		h = (c.R-c.G)/delta + 4
+	}
+	return h * 60, s, v
+}
+
+// Hex returns this color as "#RRGGBB" or as "#RRGGBBAA" if it is not opaque
+func (c Color) Hex() string {
+
+	hex := fmt.Sprintf("#%02X%02X%02X", toByte(c.R), toByte(c.G), toByte(c.B))
+	if toByte(c.A) != 255 {
+		hex += fmt.Sprintf("%02X", toByte(c.A))
+	}
+	return hex
+}
+
+// ParseHex returns the color from a "#RGB", "#RRGGBB" or "#RRGGBBAA" hexadecimal string.
+// The '#' prefix is optional.
+func ParseHex(hex string) (Color, error) {
+
+	digits := strings.TrimPrefix(strings.TrimSpace(hex), "#")
+	if len(digits) == 3 {
+		digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+	}
+	if len(digits) == 6 {
+		digits += "FF"
+	}
+	if len(digits) != 8 {
+		return Color{}, fmt.Errorf("invalid hex color: %q", hex)
+	}
+	v, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("invalid hex color: %q", hex)
+	}
+	return Color{
+		R: float32(v>>24&0xFF) / 255,
+		G: float32(v>>16&0xFF) / 255,
+		B: float32(v>>8&0xFF) / 255,
+		A: float32(v&0xFF) / 255,
+	}, nil
+}
+
+// toByte converts a color component between 0 and 1 to the nearest byte value
+func toByte(f float32) byte {
+
+	return byte(math.Round(math.Max(0, math.Min(float64(f), 1)) * 255))
+}
+
+// NamedColor is a color with its name
+type NamedColor struct {
+	Name  string
+	Color Color
+}
+
+// Named contains all the named colors of this package in alphabetical order
+var Named = []NamedColor{
+	{"Aliceblue", Aliceblue},
+	{"Antiquewhite", Antiquewhite},
+	{"Aqua", Aqua},
+	{"Aquamarine", Aquamarine},
+	{"Azure", Azure},
+	{"Beige", Beige},
+	{"Bisque", Bisque},
+	{"Black", Black},
+	{"Blanchedalmond", Blanchedalmond},
+	{"Blue", Blue},
+	{"Blueviolet", Blueviolet},
+	{"Brown", Brown},
+	{"Burlywood", Burlywood},
+	{"Cadetblue", Cadetblue},
+	{"Chartreuse", Chartreuse},
+	{"Chocolate", Chocolate},
+	{"Coral", Coral},
+	{"Cornflowerblue", Cornflowerblue},
+	{"Cornsilk", Cornsilk},
+	{"Crimson", Crimson},
+	{"Cyan", Cyan},
+	{"Darkblue", Darkblue},
+	{"Darkcyan", Darkcyan},
+	{"Darkgoldenrod", Darkgoldenrod},
+	{"Darkgray", Darkgray},
+	{"Darkgreen", Darkgreen},
+	{"Darkgrey", Darkgrey},
+	{"Darkkhaki", Darkkhaki},
+	{"Darkmagenta", Darkmagenta},
+	{"Darkolivegreen", Darkolivegreen},
+	{"Darkorange", Darkorange},
+	{"Darkorchid", Darkorchid},
+	{"Darkred", Darkred},
+	{"Darksalmon", Darksalmon},
+	{"Darkseagreen", Darkseagreen},
+	{"Darkslateblue", Darkslateblue},
+	{"Darkslategray", Darkslategray},
+	{"Darkslategrey", Darkslategrey},
+	{"Darkturquoise", Darkturquoise},
+	{"Darkviolet", Darkviolet},
+	{"Deeppink", Deeppink},
+	{"Deepskyblue", Deepskyblue},
+	{"Dimgray", Dimgray},
+	{"Dimgrey", Dimgrey},
+	{"Dodgerblue", Dodgerblue},
+	{"Firebrick", Firebrick},
+	{"Floralwhite", Floralwhite},
+	{"Forestgreen", Forestgreen},
+	{"Fuchsia", Fuchsia},
+	{"Gainsboro", Gainsboro},
+	{"Ghostwhite", Ghostwhite},
+	{"Gold", Gold},
+	{"Goldenrod", Goldenrod},
+	{"Gray", Gray},
+	{"Green", Green},
+	{"Greenyellow", Greenyellow},
+	{"Grey", Grey},
+	{"Honeydew", Honeydew},
+	{"Hotpink", Hotpink},
+	{"Indianred", Indianred},
+	{"Indigo", Indigo},
+	{"Ivory", Ivory},
+	{"Khaki", Khaki},
+	{"Lavender", Lavender},
+	{"Lavenderblush", Lavenderblush},
+	{"Lawngreen", Lawngreen},
+	{"Lemonchiffon", Lemonchiffon},
+	{"Lightblue", Lightblue},
+	{"Lightcoral", Lightcoral},
+	{"Lightcyan", Lightcyan},
+	{"Lightgoldenrodyellow", Lightgoldenrodyellow},
+	{"Lightgray", Lightgray},
+	{"Lightgreen", Lightgreen},
+	{"Lightgrey", Lightgrey},
+	{"Lightpink", Lightpink},
+	{"Lightsalmon", Lightsalmon},
+	{"Lightseagreen", Lightseagreen},
+	{"Lightskyblue", Lightskyblue},
+	{"Lightslategray", Lightslategray},
+	{"Lightslategrey", Lightslategrey},
+	{"Lightsteelblue", Lightsteelblue},
+	{"Lightyellow", Lightyellow},
+	{"Lime", Lime},
+	{"Limegreen", Limegreen},
+	{"Linen", Linen},
+	{"Magenta", Magenta},
+	{"Maroon", Maroon},
+	{"Mediumaquamarine", Mediumaquamarine},
+	{"Mediumblue", Mediumblue},
+	{"Mediumorchid", Mediumorchid},
+	{"Mediumpurple", Mediumpurple},
+	{"Mediumseagreen", Mediumseagreen},
+	{"Mediumslateblue", Mediumslateblue},
+	{"Mediumspringgreen", Mediumspringgreen},
+	{"Mediumturquoise", Mediumturquoise},
+	{"Mediumvioletred", Mediumvioletred},
+	{"Midnightblue", Midnightblue},
+	{"Mintcream", Mintcream},
+	{"Mistyrose", Mistyrose},
+	{"Moccasin", Moccasin},
+	{"Navajowhite", Navajowhite},
+	{"Navy", Navy},
+	{"Oldlace", Oldlace},
+	{"Olive", Olive},
+	{"Olivedrab", Olivedrab},
+	{"Orange", Orange},
+	{"Orangered", Orangered},
+	{"Orchid", Orchid},
+	{"Palegoldenrod", Palegoldenrod},
+	{"Palegreen", Palegreen},
+	{"Paleturquoise", Paleturquoise},
+	{"Palevioletred", Palevioletred},
+	{"Papayawhip", Papayawhip},
+	{"Peachpuff", Peachpuff},
+	{"Peru", Peru},
+	{"Pink", Pink},
+	{"Plum", Plum},
+	{"Powderblue", Powderblue},
+	{"Purple", Purple},
+	{"Red", Red},
+	{"Rosybrown", Rosybrown},
+	{"Royalblue", Royalblue},
+	{"Saddlebrown", Saddlebrown},
+	{"Salmon", Salmon},
+	{"Sandybrown", Sandybrown},
+	{"Seagreen", Seagreen},
+	{"Seashell", Seashell},
+	{"Sienna", Sienna},
+	{"Silver", Silver},
+	{"Skyblue", Skyblue},
+	{"Slateblue", Slateblue},
+	{"Slategray", Slategray},
+	{"Slategrey", Slategrey},
+	{"Snow", Snow},
+	{"Springgreen", Springgreen},
+	{"Steelblue", Steelblue},
+	{"Tan", Tan},
+	{"Teal", Teal},
+	{"Thistle", Thistle},
+	{"Tomato", Tomato},
+	{"Turquoise", Turquoise},
+	{"Violet", Violet},
+	{"Wheat", Wheat},
+	{"White", White},
+	{"Whitesmoke", Whitesmoke},
+	{"Yellow", Yellow},
+	{"Yellowgreen", Yellowgreen},
+}
+
+// FromName returns the named color with the specified case insensitive name
+func FromName(name string) (Color, bool) {
+
+	for _, nc := range Named {
+		if strings.EqualFold(nc.Name, name) {
+			return nc.Color, true
+		}
+	}
+	return Color{}, false
+}
+
 var (
 	Aliceblue            = Color{0.941, 0.973, 1.000, 1.0}
 	Antiquewhite         = Color{0.980, 0.922, 0.843, 1.0}
@@ -0,0 +1,129 @@
+package color
+
+import (
+	"math"
+	"testing"
+)
+
+// near returns if the specified values differ at most by the specified tolerance
+func near(a, b, tol float32) bool {
+
+	return math.Abs(float64(a-b)) <= float64(tol)
+}
+
+// nearColor returns if all the components of the specified colors differ at most by the specified tolerance
+func nearColor(a, b Color, tol float32) bool {
+
+	return near(a.R, b.R, tol) && near(a.G, b.G, tol) && near(a.B, b.B, tol) && near(a.A, b.A, tol)
+}
+
+func TestParseHex(t *testing.T) {
+
+	cases := []struct {
+		hex  string
+		want Color
+		ok   bool
+	}{
+		{"#FF0000", Color{1, 0, 0, 1}, true},
+		{"00ff00", Color{0, 1, 0, 1}, true},
+		{"#00F", Color{0, 0, 1, 1}, true},
+		{" #fff ", Color{1, 1, 1, 1}, true},
+		{"#33669980", Color{0.2, 0.4, 0.6, 128.0 / 255}, true},
+		{"", Color{}, false},
+		{"#", Color{}, false},
+		{"#12345", Color{}, false},
+		{"#1234567", Color{}, false},
+		{"#GG0000", Color{}, false},
+		{"#+12345", Color{}, false},
+		{"##FF0000", Color{}, false},
+	}
+	for _, c := range cases {
+		got, err := ParseHex(c.hex)
+		if (err == nil) != c.ok {
+			t.Errorf("ParseHex(%q): error %v", c.hex, err)
+			continue
+		}
+		if c.ok && !nearColor(got, c.want, 1e-6) {
+			t.Errorf("ParseHex(%q): got %v, want %v", c.hex, got, c.want)
+		}
+	}
+}
+
+func TestHex(t *testing.T) {
+
+	cases := []struct {
+		c    Color
+		want string
+	}{
+		{Color{1, 0, 0, 1}, "#FF0000"},
+		{Color{0.2, 0.4, 0.6, 1}, "#336699"},
+		{Color{0, 0, 0, 0.5}, "#00000080"},
+		{Color{1.5, -1, 0.999, 1}, "#FF00FF"},
+		{Color{0, 0, 0, 0.999}, "#000000"},
+	}
+	for _, c := range cases {
+		if got := c.c.Hex(); got != c.want {
+			t.Errorf("%v.Hex(): got %q, want %q", c.c, got, c.want)
+		}
+	}
+
+	// All byte values survive a round trip
+	for i := 0; i < 256; i++ {
+		b := float32(i) / 255
+		src := Color{b, 1 - b, b / 2, b}
+		back, err := ParseHex(src.Hex())
+		if err != nil || back.Hex() != src.Hex() || !nearColor(back, src, 0.51/255) {
+			t.Errorf("round trip of %v: got %v (%v)", src, back, err)
+		}
+	}
+}
+
+func TestHSV(t *testing.T) {
+
+	cases := []struct {
+		c       Color
+		h, s, v float32
+	}{
+		{Color{0, 0, 0, 1}, 0, 0, 0},
+		{Color{1, 1, 1, 1}, 0, 0, 1},
+		{Color{0.5, 0.5, 0.5, 1}, 0, 0, 0.5},
+		{Color{1, 0, 0, 1}, 0, 1, 1},
+		{Color{1, 1, 0, 1}, 60, 1, 1},
+		{Color{0, 1, 0, 1}, 120, 1, 1},
+		{Color{0, 1, 1, 1}, 180, 1, 1},
+		{Color{0, 0, 1, 1}, 240, 1, 1},
+		{Color{1, 0, 1, 1}, 300, 1, 1},
+		{Color{1, 0, 0.5, 1}, 330, 1, 1},
+		{Color{0.5, 0.25, 0.25, 1}, 0, 0.5, 0.5},
+	}
+	for _, c := range cases {
+		h, s, v := c.c.HSV()
+		if !near(h, c.h, 1e-3) || !near(s, c.s, 1e-6) || !near(v, c.v, 1e-6) {
+			t.Errorf("%v.HSV(): got %v %v %v, want %v %v %v", c.c, h, s, v, c.h, c.s, c.v)
+		}
+		if got := FromHSV(c.h, c.s, c.v, c.c.A); !nearColor(got, c.c, 1e-6) {
+			t.Errorf("FromHSV(%v, %v, %v): got %v, want %v", c.h, c.s, c.v, got, c.c)
+		}
+	}
+
+	// Hues out of range wrap around
+	for _, h := range []float32{-120, 600, 360} {
+		want := FromHSV(float32(math.Mod(float64(h)+720, 360)), 1, 1, 1)
+		if got := FromHSV(h, 1, 1, 1); !nearColor(got, want, 1e-6) {
+			t.Errorf("FromHSV(%v): got %v, want %v", h, got, want)
+		}
+	}
+
+	// Colors survive a round trip through HSV
+	for r := 0; r <= 10; r++ {
+		for g := 0; g <= 10; g++ {
+			for b := 0; b <= 10; b++ {
+				src := Color{float32(r) / 10, float32(g) / 10, float32(b) / 10, 0.5}
+				h, s, v := src.HSV()
+				if got := FromHSV(h, s, v, src.A); !nearColor(got, src, 1e-5) {
+					t.Errorf("HSV round trip of %v: got %v", src, got)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package gb
+
+// Key is the code of a keyboard key. The values are the same used by GLFW.
+type Key int32
+
+const (
+	KeyUnknown      Key = -1
+	KeySpace        Key = 32
+	KeyApostrophe   Key = 39
+	KeyComma        Key = 44
+	KeyMinus        Key = 45
+	KeyPeriod       Key = 46
+	KeySlash        Key = 47
+	Key0            Key = 48
+	Key1            Key = 49
+	Key2            Key = 50
+	Key3            Key = 51
+	Key4            Key = 52
+	Key5            Key = 53
+	Key6            Key = 54
+	Key7            Key = 55
+	Key8            Key = 56
+	Key9            Key = 57
+	KeySemicolon    Key = 59
+	KeyEqual        Key = 61
+	KeyA            Key = 65
+	KeyB            Key = 66
+	KeyC            Key = 67
+	KeyD            Key = 68
+	KeyE            Key = 69
+	KeyF            Key = 70
+	KeyG            Key = 71
+	KeyH            Key = 72
+	KeyI            Key = 73
+	KeyJ            Key = 74
+	KeyK            Key = 75
+	KeyL            Key = 76
+	KeyM            Key = 77
+	KeyN            Key = 78
+	KeyO            Key = 79
+	KeyP            Key = 80
+	KeyQ            Key = 81
+	KeyR            Key = 82
+	KeyS            Key = 83
+	KeyT            Key = 84
+	KeyU            Key = 85
+	KeyV            Key = 86
+	KeyW            Key = 87
+	KeyX            Key = 88
+	KeyY            Key = 89
+	KeyZ            Key = 90
+	KeyLeftBracket  Key = 91
+	KeyBackslash    Key = 92
+	KeyRightBracket Key = 93
+	KeyGraveAccent  Key = 96
+	KeyEscape       Key = 256
+	KeyEnter        Key = 257
+	KeyTab          Key = 258
+	KeyBackspace    Key = 259
+	KeyInsert       Key = 260
+	KeyDelete       Key = 261
+	KeyRight        Key = 262
+	KeyLeft         Key = 263
+	KeyDown         Key = 264
+	KeyUp           Key = 265
+	KeyPageUp       Key = 266
+	KeyPageDown     Key = 267
+	KeyHome         Key = 268
+	KeyEnd          Key = 269
+	KeyCapsLock     Key = 280
+	KeyScrollLock   Key = 281
+	KeyNumLock      Key = 282
+	KeyPrintScreen  Key = 283
+	KeyPause        Key = 284
+	KeyF1           Key = 290
+	KeyF2           Key = 291
+	KeyF3           Key = 292
+	KeyF4           Key = 293
+	KeyF5           Key = 294
+	KeyF6           Key = 295
+	KeyF7           Key = 296
+	KeyF8           Key = 297
+	KeyF9           Key = 298
+	KeyF10          Key = 299
+	KeyF11          Key = 300
+	KeyF12          Key = 301
+	KeyKP0          Key = 320
+	KeyKP1          Key = 321
+	KeyKP2          Key = 322
+	KeyKP3          Key = 323
+	KeyKP4          Key = 324
+	KeyKP5          Key = 325
+	KeyKP6          Key = 326
+	KeyKP7          Key = 327
+	KeyKP8          Key = 328
+	KeyKP9          Key = 329
+	KeyKPDecimal    Key = 330
+	KeyKPDivide     Key = 331
+	KeyKPMultiply   Key = 332
+	KeyKPSubtract   Key = 333
+	KeyKPAdd        Key = 334
+	KeyKPEnter      Key = 335
+	KeyKPEqual      Key = 336
+	KeyLeftShift    Key = 340
+	KeyLeftControl  Key = 341
+	KeyLeftAlt      Key = 342
+	KeyLeftSuper    Key = 343
+	KeyRightShift   Key = 344
+	KeyRightControl Key = 345
+	KeyRightAlt     Key = 346
+	KeyRightSuper   Key = 347
+	KeyMenu         Key = 348
+)
+
+// Action is the action of key and mouse button events
+type Action int32
+
+const (
+	ActionRelease Action = 0 // Key or button was released
+	ActionPress   Action = 1 // Key or button was pressed
+	ActionRepeat  Action = 2 // Key was held down until it repeated
+)
+
+// ModKey is a bitmask of the modifier keys held down in key and mouse button events
+type ModKey int32
+
+const (
+	ModShift    ModKey = 0x01
+	ModControl  ModKey = 0x02
+	ModAlt      ModKey = 0x04
+	ModSuper    ModKey = 0x08
+	ModCapsLock ModKey = 0x10
+	ModNumLock  ModKey = 0x20
+)
+
+// MouseButton is the code of a mouse button
+type MouseButton int32
+
+const (
+	MouseButtonLeft   MouseButton = 0
+	MouseButtonRight  MouseButton = 1
+	MouseButtonMiddle MouseButton = 2
+)
+
+// Key returns the key of an EventKey event
+func (ev *Event) Key() Key {
+
+	return Key(ev.ArgInt[0])
+}
+
+// Action returns the action of an EventKey or EventMouseButton event
+func (ev *Event) Action() Action {
+
+	if ev.Type == EventKey {
+		return Action(ev.ArgInt[2])
+	}
+	return Action(ev.ArgInt[1])
+}
+
+// Mods returns the modifier keys of an EventKey or EventMouseButton event
+func (ev *Event) Mods() ModKey {
+
+	if ev.Type == EventKey {
+		return ModKey(ev.ArgInt[3])
+	}
+	return ModKey(ev.ArgInt[2])
+}
+
+// Char returns the unicode code point of an EventChar event
+func (ev *Event) Char() rune {
+
+	return rune(ev.ArgInt[0])
+}
+
+// Button returns the mouse button of an EventMouseButton event
+func (ev *Event) Button() MouseButton {
+
+	return MouseButton(ev.ArgInt[0])
+}
+
+// Entered returns if the cursor entered the window for an EventCursorEnter event
+func (ev *Event) Entered() bool {
+
+	return ev.ArgInt[0] != 0
+}
+
+// Vec2 returns the float arguments of the event as a vector:
+// the cursor position for EventCursorPos and the scroll offsets for EventScroll.
+func (ev *Event) Vec2() Vec2 {
+
+	return Vec2{ev.ArgFloat[0], ev.ArgFloat[1]}
+}
@@ -126,6 +126,16 @@ const (
 	CursorVResize   Cursor = C.CURSOR_VRESIZE
 )
 
+// Event types
+const (
+	EventKey         = C.EVENT_KEY          // Key input event
+	EventChar        = C.EVENT_CHAR         // Character input event
+	EventCursorPos   = C.EVENT_CURSOR_POS   // Cursor position change event
+	EventCursorEnter = C.EVENT_CURSOR_ENTER // Cursor enter/exit event
+	EventMouseButton = C.EVENT_MOUSE_BUTTON // Mouse button event
+	EventScroll      = C.EVENT_SCROLL       // Scroll event (mouse wheel)
+)
+
 // MakeColor makes and returns an RGBA packed color from the specified components
 func MakeColor(r, g, b, a byte) RGBA {
 
@@ -396,6 +406,32 @@ func (w *Window) SetCursor(cursor Cursor) {
 	C.gb_set_cursor(w.c, C.int(cursor))
 }
 
+// FbScale returns the current ratio between the window framebuffer size and the window size.
+// It is greater than 1 for HiDPI displays.
+func (w *Window) FbScale() Vec2 {
+
+	cscale := C.gb_get_fb_scale(w.c)
+	return Vec2{float32(cscale.x), float32(cscale.y)}
+}
+
+// Clipboard returns the current clipboard text or an empty string if not available
+func (w *Window) Clipboard() string {
+
+	ctext := C.gb_get_clipboard(w.c)
+	if ctext == nil {
+		return ""
+	}
+	return C.GoString(ctext)
+}
+
+// SetClipboard sets the clipboard text
+func (w *Window) SetClipboard(text string) {
+
+	ctext := C.CString(text)
+	defer C.free(unsafe.Pointer(ctext))
+	C.gb_set_clipboard(w.c, ctext)
+}
+
 // CreateTexture creates texture with the specified image data and returns the texture id.
 func (w *Window) CreateTexture(width, height int, data *RGBA) TextureID {
 
@@ -7,6 +7,9 @@ static void _gb_create_cursors();
 static void _gb_set_cursor(gb_state_t* s, int cursor);
 static void _gb_destroy_cursors();
 static void _gb_update_frame_info(gb_state_t* s, double timeout);
+static gb_vec2_t _gb_get_fb_scale(gb_state_t* s);
+static const char* _gb_get_clipboard(gb_state_t* s);
+static void _gb_set_clipboard(gb_state_t* s, const char* text);
 static void _gb_print_draw_list(gb_draw_list_t dl);
 static void _gb_glfw_error_callback(int error, const char* description);
 static void _gb_set_ev_handlers(gb_state_t* s);
@@ -98,8 +101,7 @@ static void _gb_update_frame_info(gb_state_t* s, double timeout) {
     s->frame.fb_size.x = (float)width;
     s->frame.fb_size.y = (float)height;
     if (s->frame.win_size.x > 0 && s->frame.win_size.y > 0) {
-        s->frame.fb_scale.x = s->frame.fb_size.x / s->frame.win_size.x;
-        s->frame.fb_scale.y = s->frame.fb_size.y / s->frame.win_size.y;
+        s->frame.fb_scale = _gb_get_fb_scale(s);
     }
 
     // Poll and handle events, blocking if no events for the specified timeout
@@ -107,6 +109,33 @@ static void _gb_update_frame_info(gb_state_t* s, double timeout) {
     glfwWaitEventsTimeout(timeout);
 }
 
+// Returns the current ratio between the window framebuffer size and its size
+static gb_vec2_t _gb_get_fb_scale(gb_state_t* s) {
+
+    gb_vec2_t scale = {1.0f, 1.0f};
+    int win_width, win_height;
+    int fb_width, fb_height;
+    glfwGetWindowSize(s->w, &win_width, &win_height);
+    glfwGetFramebufferSize(s->w, &fb_width, &fb_height);
+    if (win_width > 0 && win_height > 0) {
+        scale.x = (float)fb_width / (float)win_width;
+        scale.y = (float)fb_height / (float)win_height;
+    }
+    return scale;
+}
+
+// Returns the current clipboard UTF-8 text or NULL if not available.
+// The returned string is owned by GLFW and valid until the next clipboard call.
+static const char* _gb_get_clipboard(gb_state_t* s) {
+
+    return glfwGetClipboardString(s->w);
+}
+
+// Sets the clipboard UTF-8 text
+static void _gb_set_clipboard(gb_state_t* s, const char* text) {
+
+    glfwSetClipboardString(s->w, text);
+}
 
 // Prints the specifid draw list for debugging
 static void _gb_print_draw_list(gb_draw_list_t dl) {
@@ -257,6 +257,27 @@ void gb_set_cursor(gb_window_t win, int cursor) {
     _gb_set_cursor(s, cursor);
 }
 
+// Returns the current window framebuffer scale
+gb_vec2_t gb_get_fb_scale(gb_window_t win) {
+
+    gb_state_t* s = (gb_state_t*)(win);
+    return _gb_get_fb_scale(s);
+}
+
+// Returns the current clipboard text or NULL
+const char* gb_get_clipboard(gb_window_t win) {
+
+    gb_state_t* s = (gb_state_t*)(win);
+    return _gb_get_clipboard(s);
+}
+
+// Sets the clipboard text
+void gb_set_clipboard(gb_window_t win, const char* text) {
+
+    gb_state_t* s = (gb_state_t*)(win);
+    _gb_set_clipboard(s, text);
+}
+
 // Creates and returns texture
 gb_texid_t gb_create_texture(gb_window_t win, int width, int height, const gb_rgba_t* data) {
 
@@ -129,6 +129,9 @@ void gb_window_destroy(gb_window_t win);
 gb_frame_info_t* gb_window_start_frame(gb_window_t bw, gb_frame_params_t* params);
 void gb_window_render_frame(gb_window_t win, gb_draw_list_t dl);
 void gb_set_cursor(gb_window_t win, int cursor);
+gb_vec2_t gb_get_fb_scale(gb_window_t win);
+const char* gb_get_clipboard(gb_window_t win);
+void gb_set_clipboard(gb_window_t win, const char* text);
 gb_texid_t gb_create_texture(gb_window_t win, int width, int height, const gb_rgba_t* data);
 void gb_delete_texture(gb_window_t win, gb_texid_t texid);
 
@@ -0,0 +1,53 @@
+package gb
+
+// Contains returns if the specified point is inside the rectangle.
+// Points on the minimum edges are inside and on the maximum edges are outside.
+func (r Rect) Contains(p Vec2) bool {
+
+	return p.X >= r.Min.X && p.X < r.Max.X && p.Y >= r.Min.Y && p.Y < r.Max.Y
+}
+
+// Size returns the width and height of the rectangle
+func (r Rect) Size() Vec2 {
+
+	return Vec2{r.Max.X - r.Min.X, r.Max.Y - r.Min.Y}
+}
+
+// Empty returns if the rectangle has no area
+func (r Rect) Empty() bool {
+
+	return r.Min.X >= r.Max.X || r.Min.Y >= r.Max.Y
+}
+
+// Intersect returns the intersection of this rectangle with the other.
+// The result is empty if the rectangles do not overlap.
+func (r Rect) Intersect(other Rect) Rect {
+
+	res := r
+	if other.Min.X > res.Min.X {
+		res.Min.X = other.Min.X
+	}
+	if other.Min.Y > res.Min.Y {
+		res.Min.Y = other.Min.Y
+	}
+	if other.Max.X < res.Max.X {
+		res.Max.X = other.Max.X
+	}
+	if other.Max.Y < res.Max.Y {
+		res.Max.Y = other.Max.Y
+	}
+	// Rectangles without intersection give an empty rectangle
+	if res.Max.X < res.Min.X {
+		res.Max.X = res.Min.X
+	}
+	if res.Max.Y < res.Min.Y {
+		res.Max.Y = res.Min.Y
+	}
+	return res
+}
+
+// Translate returns the rectangle translated by the specified offset
+func (r Rect) Translate(offset Vec2) Rect {
+
+	return Rect{Vec2Add(r.Min, offset), Vec2Add(r.Max, offset)}
+}
@@ -25,6 +25,14 @@ func Max[T Number](lhs, rhs T) T {
 	return rhs
 }
 
+func Abs[T Number](v T) T {
+
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
 func Clamp[T Number](v, min, max T) T {
 
 	if v < min {
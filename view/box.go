@@ -0,0 +1,165 @@
+package view
+
+import (
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// Box is the base container for HBox and VBox which arranges its
+// visible children sequentially along its main axis.
+type Box struct {
+	Group
+	vertical bool    // Main axis is vertical
+	spacing  float32 // Space between children
+	align    Align   // Alignment of children in the cross axis
+}
+
+// HBox is a container which arranges its children from left to right
+type HBox struct {
+	Box
+}
+
+// VBox is a container which arranges its children from top to bottom
+type VBox struct {
+	Box
+}
+
+// NewHBox creates and returns a new empty HBox
+func NewHBox() *HBox {
+
+	b := new(HBox)
+	b.Init(b)
+	b.align = AlignStretch
+	return b
+}
+
+// NewVBox creates and returns a new empty VBox
+func NewVBox() *VBox {
+
+	b := new(VBox)
+	b.Init(b)
+	b.vertical = true
+	b.align = AlignStretch
+	return b
+}
+
+// Add appends the specified child views and returns this HBox
+func (b *HBox) Add(children ...IView) *HBox {
+
+	b.View.Add(children...)
+	return b
+}
+
+// Add appends the specified child views and returns this VBox
+func (b *VBox) Add(children ...IView) *VBox {
+
+	b.View.Add(children...)
+	return b
+}
+
+// SetSpacing sets the space between the box children
+func (b *Box) SetSpacing(spacing float32) {
+
+	b.spacing = spacing
+}
+
+// Spacing returns the current space between the box children
+func (b *Box) Spacing() float32 {
+
+	return b.spacing
+}
+
+// SetAlign sets the alignment of the box children in the cross axis.
+// The default is AlignStretch.
+func (b *Box) SetAlign(align Align) {
+
+	b.align = align
+}
+
+// Align returns the current alignment of the box children in the cross axis
+func (b *Box) Align() Align {
+
+	return b.align
+}
+
+// Measure satisfies the IView interface
+func (b *Box) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	content := gb.Vec2{
+		util.Max(avail.X-b.padding.Horizontal(), 0),
+		util.Max(avail.Y-b.padding.Vertical(), 0),
+	}
+	var main, cross float32
+	count := 0
+	for _, c := range b.children {
+		cv := c.GetView()
+		if !cv.visible {
+			continue
+		}
+		if count > 0 {
+			main += b.spacing
+		}
+		cavail := axisVec2(util.Max(axisMain(content, b.vertical)-main, 0), axisCross(content, b.vertical), b.vertical)
+		d := MeasureChild(w, c, cavail)
+		main += axisMain(d, b.vertical) + axisMain(cv.margin.Size(), b.vertical)
+		cross = util.Max(cross, axisCross(d, b.vertical)+axisCross(cv.margin.Size(), b.vertical))
+		count++
+	}
+	size := axisVec2(main, cross, b.vertical)
+	size.Add(b.padding.Size())
+	return b.ConstrainSize(size)
+}
+
+// Arrange satisfies the IView interface
+func (b *Box) Arrange(w *window.Window, pos gb.Vec2, size gb.Vec2) {
+
+	b.View.Arrange(w, pos, size)
+	content := b.ContentRect()
+	contentCross := axisCross(gb.Vec2Sub(content.Max, content.Min), b.vertical)
+	cursor := axisMain(content.Min, b.vertical)
+	for _, c := range b.children {
+		cv := c.GetView()
+		if !cv.visible {
+			continue
+		}
+		mstart := axisMain(gb.Vec2{cv.margin.Left, cv.margin.Top}, b.vertical)
+		mcross := axisCross(gb.Vec2{cv.margin.Left, cv.margin.Top}, b.vertical)
+		space := contentCross - axisCross(cv.margin.Size(), b.vertical)
+		childMain := axisMain(cv.desired, b.vertical)
+		childCross := axisCross(cv.desired, b.vertical)
+		if b.align == AlignStretch {
+			childCross = space
+		}
+		crossPos := axisCross(content.Min, b.vertical) + mcross + alignOffset(b.align, space, childCross)
+		ArrangeChild(w, c, axisVec2(cursor+mstart, crossPos, b.vertical), axisVec2(childMain, childCross, b.vertical))
+		cursor += childMain + axisMain(cv.margin.Size(), b.vertical) + b.spacing
+	}
+}
+
+// axisMain returns the component of the vector in the main axis
+func axisMain(v gb.Vec2, vertical bool) float32 {
+
+	if vertical {
+		return v.Y
+	}
+	return v.X
+}
+
+// axisCross returns the component of the vector in the cross axis
+func axisCross(v gb.Vec2, vertical bool) float32 {
+
+	if vertical {
+		return v.X
+	}
+	return v.Y
+}
+
+// axisVec2 returns a vector from its main and cross axis components
+func axisVec2(main, cross float32, vertical bool) gb.Vec2 {
+
+	if vertical {
+		return gb.Vec2{cross, main}
+	}
+	return gb.Vec2{main, cross}
+}
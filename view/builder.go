@@ -0,0 +1,194 @@
+package view
+
+import (
+	"github.com/leonsal/gux/color"
+)
+
+// Views can be built declaratively as a single nested expression using the container
+// Add() methods, which return the container, and With() to apply options to any view.
+// The options are the chainable form of the view setters: the base View properties have
+// their own options and Do() applies the setters of a specific view type:
+//
+//	root := view.NewVBox().Add(
+//		view.With(view.NewLabel("Title"), view.Margin(view.InsetsAll(4))),
+//		view.NewHBox().Add(
+//			view.NewLabel("Name:"),
+//			view.With(view.NewTextEdit(""), view.Tooltip("Your name"),
+//				view.Do(func(t *view.TextEdit) { t.SetPlaceholder("Type here") })),
+//		),
+//	)
+
+// Option is a function which changes a view property when building views with With()
+type Option func(iv IView)
+
+// With applies the specified options to the view and returns it
+func With[T IView](iv T, opts ...Option) T {
+
+	for _, opt := range opts {
+		opt(iv)
+	}
+	return iv
+}
+
+// Do returns an Option which calls the specified function with the view
+// converted to its concrete type, allowing any view specific setter to be used with With().
+// The option panics if applied to a view of another type.
+func Do[T IView](f func(T)) Option {
+
+	return func(iv IView) {
+		f(iv.(T))
+	}
+}
+
+// Pos returns an Option which sets the view position
+func Pos(x, y float32) Option {
+
+	return func(iv IView) {
+		iv.SetPos(x, y)
+	}
+}
+
+// Margin returns an Option which sets the view margin
+func Margin(m Insets) Option {
+
+	return func(iv IView) {
+		iv.GetView().SetMargin(m)
+	}
+}
+
+// Padding returns an Option which sets the view padding
+func Padding(p Insets) Option {
+
+	return func(iv IView) {
+		iv.GetView().SetPadding(p)
+	}
+}
+
+// PrefSize returns an Option which sets the view preferred size
+func PrefSize(width, height float32) Option {
+
+	return func(iv IView) {
+		iv.GetView().SetPrefSize(width, height)
+	}
+}
+
+// MinSize returns an Option which sets the view minimum size
+func MinSize(width, height float32) Option {
+
+	return func(iv IView) {
+		iv.GetView().SetMinSize(width, height)
+	}
+}
+
+// MaxSize returns an Option which sets the view maximum size
+func MaxSize(width, height float32) Option {
+
+	return func(iv IView) {
+		iv.GetView().SetMaxSize(width, height)
+	}
+}
+
+// Visible returns an Option which sets the view visibility
+func Visible(visible bool) Option {
+
+	return func(iv IView) {
+		iv.GetView().SetVisible(visible)
+	}
+}
+
+// Scale returns an Option which sets the view scale
+func Scale(x, y float32) Option {
+
+	return func(iv IView) {
+		iv.GetView().SetScale(x, y)
+	}
+}
+
+// Rotation returns an Option which sets the view rotation in radians
+func Rotation(r float32) Option {
+
+	return func(iv IView) {
+		iv.GetView().SetRotation(r)
+	}
+}
+
+// Enabled returns an Option which sets if the view is enabled
+func Enabled(enabled bool) Option {
+
+	return func(iv IView) {
+		iv.GetView().SetEnabled(enabled)
+	}
+}
+
+// Focusable returns an Option which sets if the view can receive the keyboard focus
+func Focusable(focusable bool) Option {
+
+	return func(iv IView) {
+		iv.GetView().SetFocusable(focusable)
+	}
+}
+
+// Tooltip returns an Option which sets the text of the view tooltip
+func Tooltip(text string) Option {
+
+	return func(iv IView) {
+		iv.GetView().SetTooltip(text)
+	}
+}
+
+// ContextMenu returns an Option which sets the menu opened by the right mouse button over the view
+func ContextMenu(m *Menu) Option {
+
+	return func(iv IView) {
+		iv.GetView().SetContextMenu(m)
+	}
+}
+
+// Color returns an Option which sets a specific style color of the view
+func Color(scolor StyleColorType, c color.Color) Option {
+
+	return func(iv IView) {
+		iv.GetView().SetStyleColor(scolor, c)
+	}
+}
+
+// Alpha returns an Option which sets the specific style alpha of the view
+func Alpha(alpha float32) Option {
+
+	return func(iv IView) {
+		iv.GetView().SetStyleAlpha(alpha)
+	}
+}
+
+// textSetter is the interface of the views whose text is set by the Text option
+type textSetter interface {
+	SetText(text string)
+}
+
+// Text returns an Option which sets the text of views which implement textSetter,
+// such as Label, Button, CheckBox and TextEdit. It is ignored by other views.
+func Text(text string) Option {
+
+	return func(iv IView) {
+		if tv, ok := iv.(textSetter); ok {
+			tv.SetText(text)
+		}
+	}
+}
+
+// Children returns an Option which appends the specified child views to the view
+func Children(children ...IView) Option {
+
+	return func(iv IView) {
+		iv.GetView().Add(children...)
+	}
+}
+
+// Ref returns an Option which stores the view in the specified variable,
+// allowing views built inside nested expressions to be referenced later.
+func Ref[T IView](ref *T) Option {
+
+	return func(iv IView) {
+		*ref = iv.(T)
+	}
+}
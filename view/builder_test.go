@@ -0,0 +1,50 @@
+package view
+
+import (
+	"testing"
+)
+
+func TestTextOption(t *testing.T) {
+
+	label := With(NewLabel("a"), Text("label"))
+	button := With(NewButton("a"), Text("button"))
+	check := With(NewCheckBox("a"), Text("check"))
+	edit := With(NewTextEdit("a"), Text("edit"))
+	area := With(NewTextArea("a"), Text("area"))
+	cases := []struct {
+		got, want string
+	}{
+		{label.Text(), "label"},
+		{button.Text(), "button"},
+		{check.Text(), "check"},
+		{edit.Text(), "edit"},
+		{area.Text(), "area"},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("got text %q, want %q", c.got, c.want)
+		}
+	}
+
+	// Views without SetText ignore the option
+	With(newTestView(10, 10), Text("ignored"))
+}
+
+func TestBuilderOptions(t *testing.T) {
+
+	var edit *TextEdit
+	menu := NewMenu()
+	box := NewVBox().Add(
+		With(NewTextEdit(""), Ref(&edit), Margin(InsetsAll(2)), Enabled(false), Focusable(false),
+			Tooltip("tip"), ContextMenu(menu), Do(func(t *TextEdit) { t.SetPlaceholder("name") })),
+	)
+	if len(box.Children()) != 1 || box.Children()[0] != IView(edit) {
+		t.Fatalf("text edit not added or referenced")
+	}
+	if edit.Margin() != InsetsAll(2) || edit.Enabled() || edit.Focusable() {
+		t.Errorf("base properties not set: margin %v enabled %v focusable %v", edit.Margin(), edit.Enabled(), edit.Focusable())
+	}
+	if edit.Tooltip() == nil || edit.ContextMenu() != menu || edit.Placeholder() != "name" {
+		t.Errorf("tooltip, context menu or placeholder not set")
+	}
+}
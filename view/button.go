@@ -0,0 +1,217 @@
+package view
+
+import (
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// buttonIconSpacing is the space between the button icon and its text
+const buttonIconSpacing = 4
+
+// Button is a clickable view with a text and an optional icon.
+// It is activated by a left mouse click or, when focused, by the Space or Enter keys.
+type Button struct {
+	View
+	text     string               // Button text
+	ff       window.FontStyleType // Text font style
+	hasIcon  bool                 // Button has an icon
+	icon     gb.TextureID         // Icon texture
+	iconSize gb.Vec2              // Icon size (zero uses the font height)
+	hovered  bool                 // Cursor is over the button
+	pressed  bool                 // Left mouse button was pressed over the button
+	keyDown  bool                 // Space key was pressed while focused
+	onClick  func(b *Button)      // Click callback
+}
+
+// NewButton creates and returns a new Button with the specified text
+func NewButton(text string) *Button {
+
+	b := new(Button)
+	b.Init(b)
+	b.ff = window.FontRegular
+	b.focusable = true
+	b.padding = InsetsXY(8, 4)
+	b.text = text
+	return b
+}
+
+// SetText sets the button text
+func (b *Button) SetText(text string) {
+
+	b.text = text
+}
+
+// Text returns the button text
+func (b *Button) Text() string {
+
+	return b.text
+}
+
+// SetIcon sets the texture of the icon drawn before the text and its size.
+// If the size is zero the icon is drawn as a square with the height of the font.
+func (b *Button) SetIcon(texID gb.TextureID, size gb.Vec2) {
+
+	b.hasIcon = true
+	b.icon = texID
+	b.iconSize = size
+}
+
+// ClearIcon removes the button icon
+func (b *Button) ClearIcon() {
+
+	b.hasIcon = false
+}
+
+// OnClick sets the function called when the button is clicked
+func (b *Button) OnClick(cb func(b *Button)) {
+
+	b.onClick = cb
+}
+
+// Click calls the button click callback, if set
+func (b *Button) Click() {
+
+	if b.onClick != nil {
+		b.onClick(b)
+	}
+}
+
+// Hovered returns if the cursor is over the button
+func (b *Button) Hovered() bool {
+
+	return b.hovered
+}
+
+// Pressed returns if the button is being pressed by the mouse or keyboard
+func (b *Button) Pressed() bool {
+
+	return (b.pressed && b.hovered) || b.keyDown
+}
+
+// Measure satisfies the IView interface.
+// The desired size of the Button is the size of its icon and text plus its padding.
+func (b *Button) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	fa := w.Font(b.ff, 0)
+	size := gb.Vec2{fa.MeasureString(b.text), fa.Height()}
+	if b.hasIcon {
+		isize := b.iconDrawSize(fa)
+		if b.text != "" {
+			size.X += buttonIconSpacing
+		}
+		size.X += isize.X
+		size.Y = util.Max(size.Y, isize.Y)
+	}
+	size.Add(b.padding.Size())
+	return b.ConstrainSize(size)
+}
+
+// OnEvent satisfies the IView interface
+func (b *Button) OnEvent(w *window.Window, ev *Event) bool {
+
+	switch ev.Type {
+	case EventMouseEnter:
+		b.hovered = true
+	case EventMouseLeave:
+		b.hovered = false
+	case EventFocusOut:
+		b.keyDown = false
+	case EventMouseDown:
+		if b.disabled || ev.Button != gb.MouseButtonLeft {
+			return false
+		}
+		b.pressed = true
+		return true
+	case EventMouseUp:
+		if ev.Button != gb.MouseButtonLeft || !b.pressed {
+			return false
+		}
+		b.pressed = false
+		if b.hovered && !b.disabled {
+			b.Click()
+		}
+		return true
+	case EventKeyDown:
+		if b.disabled {
+			return false
+		}
+		switch ev.Key {
+		case gb.KeySpace:
+			b.keyDown = true
+			return true
+		case gb.KeyEnter, gb.KeyKPEnter:
+			if !ev.Repeat {
+				b.Click()
+			}
+			return true
+		}
+	case EventKeyUp:
+		if ev.Key == gb.KeySpace && b.keyDown {
+			b.keyDown = false
+			if !b.disabled {
+				b.Click()
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Render satisfies the IView interface
+func (b *Button) Render(w *window.Window) {
+
+	if !b.visible {
+		return
+	}
+	dl := b.BeginRender()
+
+	// Draws the background for the current state
+	bgColor := StyleColorButton
+	textColor := StyleColorText
+	if b.disabled {
+		bgColor = StyleColorButtonDisabled
+		textColor = StyleColorTextDisabled
+	} else if b.Pressed() {
+		bgColor = StyleColorButtonPressed
+	} else if b.hovered {
+		bgColor = StyleColorButtonHovered
+	}
+	rounding := b.StyleFrameRounding(w)
+	w.AddRectFilled(dl, gb.Vec2{}, b.size, b.StyleColor(w, bgColor).RGBA(), rounding, window.DrawFlags_RoundCornersAll)
+	if b.HasFocus(w) && !b.disabled {
+		w.AddRect(dl, gb.Vec2{}, b.size, b.StyleColor(w, StyleColorFocus).RGBA(), rounding, window.DrawFlags_RoundCornersAll, 2)
+	}
+
+	// Draws the icon and text centered in the content area
+	fa := w.Font(b.ff, 0)
+	content := b.ContentRect()
+	csize := content.Size()
+	width := fa.MeasureString(b.text)
+	var isize gb.Vec2
+	if b.hasIcon {
+		isize = b.iconDrawSize(fa)
+		width += isize.X
+		if b.text != "" {
+			width += buttonIconSpacing
+		}
+	}
+	x := content.Min.X + (csize.X-width)/2
+	if b.hasIcon {
+		pmin := gb.Vec2{x, content.Min.Y + (csize.Y-isize.Y)/2}
+		w.AddImage(dl, b.icon, pmin, gb.Vec2Add(pmin, isize))
+		x += isize.X + buttonIconSpacing
+	}
+	pos := gb.Vec2{x, content.Min.Y + (csize.Y-fa.Height())/2}
+	w.AddText(dl, fa, &pos, b.StyleColor(w, textColor).RGBA(), window.TextVAlignTop, b.text)
+	b.EndRender(w)
+}
+
+// iconDrawSize returns the size used to draw the icon
+func (b *Button) iconDrawSize(fa *window.FontAtlas) gb.Vec2 {
+
+	if b.iconSize.X > 0 && b.iconSize.Y > 0 {
+		return b.iconSize
+	}
+	return gb.Vec2{fa.Height(), fa.Height()}
+}
@@ -0,0 +1,139 @@
+package view
+
+import (
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/window"
+)
+
+// CheckState is the state of a CheckBox
+type CheckState int
+
+const (
+	CheckUnchecked     CheckState = iota // Not checked
+	CheckChecked                         // Checked
+	CheckIndeterminate                   // Neither checked nor unchecked (tri-state check boxes only)
+)
+
+// CheckBox is a boolean input view with a check mark and a label.
+// Tri-state check boxes also have an indeterminate state.
+type CheckBox struct {
+	toggleView
+	state    CheckState      // Current state
+	triState bool            // Cycles through the indeterminate state when activated
+	onChange func(*CheckBox) // Change callback
+}
+
+// NewCheckBox creates and returns a new unchecked CheckBox with the specified label
+func NewCheckBox(text string) *CheckBox {
+
+	c := new(CheckBox)
+	c.initToggle(c, text, c.toggle)
+	return c
+}
+
+// SetChecked sets the check box state to checked or unchecked
+func (c *CheckBox) SetChecked(checked bool) {
+
+	if checked {
+		c.SetState(CheckChecked)
+	} else {
+		c.SetState(CheckUnchecked)
+	}
+}
+
+// Checked returns if the check box state is checked
+func (c *CheckBox) Checked() bool {
+
+	return c.state == CheckChecked
+}
+
+// SetState sets the check box state, calling the change callback if it changed
+func (c *CheckBox) SetState(state CheckState) {
+
+	if state == c.state {
+		return
+	}
+	c.state = state
+	if c.onChange != nil {
+		c.onChange(c)
+	}
+}
+
+// State returns the current check box state
+func (c *CheckBox) State() CheckState {
+
+	return c.state
+}
+
+// SetTriState sets if the check box cycles through the indeterminate state when activated
+func (c *CheckBox) SetTriState(triState bool) {
+
+	c.triState = triState
+}
+
+// TriState returns if the check box cycles through the indeterminate state
+func (c *CheckBox) TriState() bool {
+
+	return c.triState
+}
+
+// OnChange sets the function called when the check box state changes
+func (c *CheckBox) OnChange(cb func(c *CheckBox)) {
+
+	c.onChange = cb
+}
+
+// Measure satisfies the IView interface
+func (c *CheckBox) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	side := indicatorSide(w.Font(c.ff, 0))
+	return c.measureToggle(w, gb.Vec2{side, side})
+}
+
+// Render satisfies the IView interface
+func (c *CheckBox) Render(w *window.Window) {
+
+	if !c.visible {
+		return
+	}
+	dl := c.BeginRender()
+	side := indicatorSide(w.Font(c.ff, 0))
+	r := c.indicatorRect(gb.Vec2{side, side})
+	rounding := c.StyleFrameRounding(w)
+
+	// Draws the box
+	w.AddRectFilled(dl, r.Min, r.Max, c.frameColor(w), rounding, window.DrawFlags_RoundCornersAll)
+	w.AddRect(dl, r.Min, r.Max, c.StyleColor(w, StyleColorBorder).RGBA(), rounding, window.DrawFlags_RoundCornersAll, 1)
+
+	// Draws the check mark or the indeterminate bar
+	mark := c.markColor(w)
+	switch c.state {
+	case CheckChecked:
+		drawCheckMark(w, dl, r.Min, side, mark)
+	case CheckIndeterminate:
+		pad := side * 0.22
+		w.AddRectFilled(dl, gb.Vec2{r.Min.X + pad, r.Min.Y + side*0.42}, gb.Vec2{r.Max.X - pad, r.Min.Y + side*0.58}, mark, 0, 0)
+	}
+	if c.HasFocus(w) && !c.disabled {
+		w.AddRect(dl, r.Min, r.Max, c.StyleColor(w, StyleColorFocus).RGBA(), rounding, window.DrawFlags_RoundCornersAll, 2)
+	}
+	c.renderLabel(w, dl, side)
+	c.EndRender(w)
+}
+
+// toggle changes the check box state when it is activated
+func (c *CheckBox) toggle() {
+
+	switch c.state {
+	case CheckUnchecked:
+		c.SetState(CheckChecked)
+	case CheckChecked:
+		if c.triState {
+			c.SetState(CheckIndeterminate)
+		} else {
+			c.SetState(CheckUnchecked)
+		}
+	default:
+		c.SetState(CheckUnchecked)
+	}
+}
@@ -0,0 +1,481 @@
+package view
+
+import (
+	"math"
+	"sort"
+
+	"github.com/leonsal/gux/color"
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// Side of the saturation/value square and height of the hue and alpha bars of a ColorPicker
+const colorAreaSize = 160
+
+// Width of the hue and alpha bars of a ColorPicker
+const colorBarWidth = 20
+
+// Height of the ColorPicker preview of the current color
+const colorPreviewHeight = 32
+
+// Width of the ColorPicker hexadecimal entry field
+const colorHexWidth = 90
+
+// Side of the checkerboard squares drawn behind transparent colors
+const colorCheckerSize = 6
+
+// Side of the ColorPicker palette swatches, the space between them and the default number of columns
+const (
+	swatchSize    = 16
+	swatchSpacing = 2
+	swatchColumns = 20
+)
+
+// Spacing between the parts of a ColorPicker
+const colorPickerSpacing = 6
+
+// colorAreaKind is the part of a ColorPicker drawn by a colorArea
+type colorAreaKind int
+
+const (
+	colorAreaSV      colorAreaKind = iota // Saturation (horizontal) and value (vertical) square
+	colorAreaHue                          // Vertical hue bar
+	colorAreaAlpha                        // Vertical alpha bar
+	colorAreaPreview                      // Current color opaque and with its alpha
+)
+
+// ColorPicker is a view for choosing a color, which is kept as hue, saturation, value and alpha
+// so the hue is not lost for grays. It has a saturation/value square with hue and alpha bars
+// which are dragged with the mouse, RGBA (0-255) and HSV (degrees and percents) numeric fields,
+// a hexadecimal "#RRGGBB[AA]" field and a palette with the named colors of the color package.
+type ColorPicker struct {
+	VBox
+	h, s, v, a float32            // Current hue in degrees and saturation, value and alpha between 0 and 1
+	rgba       [4]*SpinBox        // Red, green, blue and alpha fields
+	hsv        [3]*SpinBox        // Hue, saturation and value fields
+	hex        *TextEdit          // Hexadecimal field
+	syncing    bool               // Fields are being updated from the current color
+	onChange   func(*ColorPicker) // Color change callback
+}
+
+// colorArea is one of the color areas of a ColorPicker dragged with the mouse
+type colorArea struct {
+	View
+	cp       *ColorPicker
+	kind     colorAreaKind
+	dragging bool // Mouse button pressed over the area
+}
+
+// colorSwatches is the palette of named colors of a ColorPicker
+type colorSwatches struct {
+	View
+	cp      *ColorPicker
+	hovered int // Swatch under the cursor (-1 if none)
+}
+
+// colorPalette contains the distinct named colors with the grays first,
+// from light to dark, followed by the other colors sorted by hue
+var colorPalette = newColorPalette()
+
+// NewColorPicker creates and returns a new ColorPicker with the specified initial color
+func NewColorPicker(c color.Color) *ColorPicker {
+
+	cp := new(ColorPicker)
+	cp.Init(cp)
+	cp.vertical = true
+	cp.align = AlignStretch
+	cp.spacing = colorPickerSpacing
+
+	newSpin := func(max float64, cb func()) *SpinBox {
+		s := NewSpinBox(0, max)
+		s.SetStep(1)
+		s.SetFormat("%.0f")
+		s.OnChange(func(*SpinBox) {
+			if !cp.syncing {
+				cb()
+			}
+		})
+		return s
+	}
+	for i := range cp.rgba {
+		cp.rgba[i] = newSpin(255, cp.rgbaEdited)
+	}
+	cp.hsv[0] = newSpin(360, cp.hsvEdited)
+	cp.hsv[1] = newSpin(100, cp.hsvEdited)
+	cp.hsv[2] = newSpin(100, cp.hsvEdited)
+	cp.hex = NewTextEdit("")
+	cp.hex.SetMaxLength(9)
+	cp.hex.SetPrefSize(colorHexWidth, 0)
+	cp.hex.OnChange(func(t *TextEdit) {
+		if c, err := color.ParseHex(t.Text()); err == nil && !cp.syncing {
+			cp.setColor(c, t)
+		}
+	})
+	cp.hex.OnSubmit(func(t *TextEdit) { t.SetText(cp.Color().Hex()) })
+
+	fields := NewGrid(4)
+	fields.SetSpacing(colorPickerSpacing, colorPickerSpacing)
+	fields.SetAlign(AlignStretch, AlignCenter)
+	fields.Add(
+		NewLabel("R"), cp.rgba[0], NewLabel("H"), cp.hsv[0],
+		NewLabel("G"), cp.rgba[1], NewLabel("S"), cp.hsv[1],
+		NewLabel("B"), cp.rgba[2], NewLabel("V"), cp.hsv[2],
+		NewLabel("A"), cp.rgba[3], NewLabel("Hex"), cp.hex,
+	)
+	side := NewVBox().Add(cp.newArea(colorAreaPreview), fields)
+	side.SetSpacing(colorPickerSpacing)
+	top := NewHBox().Add(cp.newArea(colorAreaSV), cp.newArea(colorAreaHue), cp.newArea(colorAreaAlpha), side)
+	top.SetSpacing(colorPickerSpacing)
+	top.SetAlign(AlignStart)
+	swatches := &colorSwatches{cp: cp, hovered: -1}
+	swatches.Init(swatches)
+	cp.View.Add(top, swatches)
+
+	cp.h, cp.s, cp.v = c.HSV()
+	cp.a = c.A
+	cp.updateFields(nil)
+	return cp
+}
+
+// SetColor sets the current color, calling the change callback if it changed
+func (cp *ColorPicker) SetColor(c color.Color) {
+
+	cp.setColor(c, nil)
+}
+
+// Color returns the current color
+func (cp *ColorPicker) Color() color.Color {
+
+	return color.FromHSV(cp.h, cp.s, cp.v, cp.a)
+}
+
+// SetHSV sets the current color from its hue in degrees and its saturation, value and alpha between 0 and 1
+func (cp *ColorPicker) SetHSV(h, s, v, a float32) {
+
+	cp.setHSV(h, s, v, a, nil)
+}
+
+// HSV returns the hue in degrees and the saturation, value and alpha between 0 and 1 of the current color
+func (cp *ColorPicker) HSV() (h, s, v, a float32) {
+
+	return cp.h, cp.s, cp.v, cp.a
+}
+
+// OnChange sets the function called when the color changes
+func (cp *ColorPicker) OnChange(cb func(cp *ColorPicker)) {
+
+	cp.onChange = cb
+}
+
+// setColor sets the current color, keeping the current hue for grays,
+// and updates the fields except the specified one which was edited
+func (cp *ColorPicker) setColor(c color.Color, from IView) {
+
+	h, s, v := c.HSV()
+	if s == 0 || v == 0 {
+		h = cp.h
+	}
+	if s == 0 && v == 0 {
+		s = cp.s
+	}
+	cp.setHSV(h, s, v, c.A, from)
+}
+
+// setHSV sets the current color components and updates the fields except the specified one which was edited
+func (cp *ColorPicker) setHSV(h, s, v, a float32, from IView) {
+
+	h = util.Clamp(h, 0, 360)
+	s = util.Clamp(s, 0, 1)
+	v = util.Clamp(v, 0, 1)
+	a = util.Clamp(a, 0, 1)
+	if h == cp.h && s == cp.s && v == cp.v && a == cp.a {
+		return
+	}
+	cp.h, cp.s, cp.v, cp.a = h, s, v, a
+	cp.updateFields(from)
+	if cp.onChange != nil {
+		cp.onChange(cp)
+	}
+}
+
+// updateFields sets the fields from the current color except the specified one which was edited
+func (cp *ColorPicker) updateFields(from IView) {
+
+	cp.syncing = true
+	defer func() { cp.syncing = false }()
+	c := cp.Color()
+	for i, f := range []float32{c.R, c.G, c.B, c.A} {
+		if cp.rgba[i] != from {
+			cp.rgba[i].SetValue(math.Round(float64(f) * 255))
+		}
+	}
+	for i, f := range []float32{cp.h, cp.s * 100, cp.v * 100} {
+		if cp.hsv[i] != from {
+			cp.hsv[i].SetValue(math.Round(float64(f)))
+		}
+	}
+	if cp.hex != from {
+		cp.hex.SetText(c.Hex())
+	}
+}
+
+// rgbaEdited sets the current color from the RGBA fields
+func (cp *ColorPicker) rgbaEdited() {
+
+	c := color.Color{
+		R: float32(cp.rgba[0].Value() / 255),
+		G: float32(cp.rgba[1].Value() / 255),
+		B: float32(cp.rgba[2].Value() / 255),
+		A: float32(cp.rgba[3].Value() / 255),
+	}
+	cp.setColor(c, nil)
+}
+
+// hsvEdited sets the current color from the HSV fields
+func (cp *ColorPicker) hsvEdited() {
+
+	cp.setHSV(float32(cp.hsv[0].Value()), float32(cp.hsv[1].Value()/100), float32(cp.hsv[2].Value()/100), cp.a, nil)
+}
+
+// newArea creates and returns a new color area of the specified kind
+func (cp *ColorPicker) newArea(kind colorAreaKind) *colorArea {
+
+	a := &colorArea{cp: cp, kind: kind}
+	a.Init(a)
+	return a
+}
+
+// Measure satisfies the IView interface
+func (a *colorArea) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	switch a.kind {
+	case colorAreaSV:
+		return a.ConstrainSize(gb.Vec2{colorAreaSize, colorAreaSize})
+	case colorAreaPreview:
+		return a.ConstrainSize(gb.Vec2{0, colorPreviewHeight})
+	default:
+		return a.ConstrainSize(gb.Vec2{colorBarWidth, colorAreaSize})
+	}
+}
+
+// OnEvent satisfies the IView interface
+func (a *colorArea) OnEvent(w *window.Window, ev *Event) bool {
+
+	if a.kind == colorAreaPreview {
+		return false
+	}
+	switch ev.Type {
+	case EventMouseDown:
+		if a.cp.disabled || ev.Button != gb.MouseButtonLeft {
+			return false
+		}
+		a.dragging = true
+		a.pick(ev.Pos)
+		return true
+	case EventMouseMove:
+		if a.dragging {
+			a.pick(ev.Pos)
+			return true
+		}
+	case EventMouseUp:
+		if a.dragging && ev.Button == gb.MouseButtonLeft {
+			a.dragging = false
+			return true
+		}
+	}
+	return false
+}
+
+// pick sets the color components of the area from the specified local point
+func (a *colorArea) pick(p gb.Vec2) {
+
+	fx := util.Clamp(p.X/util.Max(a.size.X, 1), 0, 1)
+	fy := util.Clamp(p.Y/util.Max(a.size.Y, 1), 0, 1)
+	cp := a.cp
+	switch a.kind {
+	case colorAreaSV:
+		cp.setHSV(cp.h, fx, 1-fy, cp.a, nil)
+	case colorAreaHue:
+		cp.setHSV(fy*360, cp.s, cp.v, cp.a, nil)
+	case colorAreaAlpha:
+		cp.setHSV(cp.h, cp.s, cp.v, 1-fy, nil)
+	}
+}
+
+// Render satisfies the IView interface
+func (a *colorArea) Render(w *window.Window) {
+
+	if !a.visible {
+		return
+	}
+	dl := a.BeginRender()
+	cp := a.cp
+	c := cp.Color()
+	opaque := color.Color{R: c.R, G: c.G, B: c.B, A: 1}
+	white := color.White.RGBA()
+	black := color.Black.RGBA()
+	switch a.kind {
+	case colorAreaSV:
+		// Blends the pure hue horizontally from white and then vertically to black
+		hue := color.FromHSV(cp.h, 1, 1, 1).RGBA()
+		w.AddRectFilledMultiColor(dl, gb.Vec2{}, a.size, white, hue, hue, white)
+		w.AddRectFilledMultiColor(dl, gb.Vec2{}, a.size, black&^gb.RGBAMaskA, black&^gb.RGBAMaskA, black, black)
+		center := gb.Vec2{cp.s * a.size.X, (1 - cp.v) * a.size.Y}
+		w.PushClipRect(a.WindowRect(gb.Rect{Max: a.size}))
+		w.AddCircle(dl, center, 6, black, 24, 1)
+		w.AddCircle(dl, center, 5, white, 24, 2)
+		w.PopClipRect()
+	case colorAreaHue:
+		for i := 0; i < 6; i++ {
+			top := color.FromHSV(float32(i)*60, 1, 1, 1).RGBA()
+			bottom := color.FromHSV(float32(i+1)*60, 1, 1, 1).RGBA()
+			y0 := float32(i) * a.size.Y / 6
+			y1 := float32(i+1) * a.size.Y / 6
+			w.AddRectFilledMultiColor(dl, gb.Vec2{0, y0}, gb.Vec2{a.size.X, y1}, top, top, bottom, bottom)
+		}
+		a.drawMarker(w, dl, cp.h/360*a.size.Y)
+	case colorAreaAlpha:
+		drawChecker(w, dl, gb.Vec2{}, a.size, colorCheckerSize)
+		top := opaque.RGBA()
+		bottom := top &^ gb.RGBAMaskA
+		w.AddRectFilledMultiColor(dl, gb.Vec2{}, a.size, top, top, bottom, bottom)
+		a.drawMarker(w, dl, (1-cp.a)*a.size.Y)
+	case colorAreaPreview:
+		// Draws the opaque color at the left and the color with its alpha at the right
+		mid := a.size.X / 2
+		w.AddRectFilled(dl, gb.Vec2{}, gb.Vec2{mid, a.size.Y}, opaque.RGBA(), 0, 0)
+		drawChecker(w, dl, gb.Vec2{mid, 0}, a.size, colorCheckerSize)
+		w.AddRectFilled(dl, gb.Vec2{mid, 0}, a.size, c.RGBA(), 0, 0)
+	}
+	w.AddRect(dl, gb.Vec2{}, a.size, a.StyleColor(w, StyleColorBorder).RGBA(), 0, 0, 1)
+	a.EndRender(w)
+}
+
+// drawMarker draws the marker of the current value of a bar at the specified vertical position
+func (a *colorArea) drawMarker(w *window.Window, dl *gb.DrawList, y float32) {
+
+	min := gb.Vec2{0, y - 2}
+	max := gb.Vec2{a.size.X, y + 2}
+	w.AddRect(dl, min, max, color.Black.RGBA(), 0, 0, 3)
+	w.AddRect(dl, min, max, color.White.RGBA(), 0, 0, 1)
+}
+
+// Measure satisfies the IView interface
+func (sw *colorSwatches) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	columns := swatchColumns
+	if avail.X != Unlimited {
+		columns = util.Max(sw.columnsFor(avail.X-sw.padding.Horizontal()), 1)
+	}
+	rows := (len(colorPalette) + columns - 1) / columns
+	size := gb.Vec2{float32(columns)*(swatchSize+swatchSpacing) - swatchSpacing, float32(rows)*(swatchSize+swatchSpacing) - swatchSpacing}
+	size.Add(sw.padding.Size())
+	return sw.ConstrainSize(size)
+}
+
+// OnEvent satisfies the IView interface
+func (sw *colorSwatches) OnEvent(w *window.Window, ev *Event) bool {
+
+	switch ev.Type {
+	case EventMouseMove:
+		sw.hovered = sw.swatchAt(ev.Pos)
+	case EventMouseLeave:
+		sw.hovered = -1
+	case EventMouseDown:
+		index := sw.swatchAt(ev.Pos)
+		if sw.cp.disabled || ev.Button != gb.MouseButtonLeft || index < 0 {
+			return false
+		}
+		// Picking a swatch keeps the current alpha
+		c := colorPalette[index].Color
+		c.A = sw.cp.a
+		sw.cp.setColor(c, nil)
+		return true
+	}
+	return false
+}
+
+// Render satisfies the IView interface
+func (sw *colorSwatches) Render(w *window.Window) {
+
+	if !sw.visible {
+		return
+	}
+	dl := sw.BeginRender()
+	current := sw.cp.Color().Hex()
+	border := sw.StyleColor(w, StyleColorBorder).RGBA()
+	for i, nc := range colorPalette {
+		r := sw.swatchRect(i)
+		w.AddRectFilled(dl, r.Min, r.Max, nc.Color.RGBA(), 0, 0)
+		switch {
+		case nc.Color.Hex() == current[:util.Min(len(current), 7)]:
+			w.AddRect(dl, r.Min, r.Max, sw.StyleColor(w, StyleColorFocus).RGBA(), 0, 0, 2)
+		case i == sw.hovered:
+			w.AddRect(dl, r.Min, r.Max, sw.StyleColor(w, StyleColorText).RGBA(), 0, 0, 1)
+		default:
+			w.AddRect(dl, r.Min, r.Max, border, 0, 0, 1)
+		}
+	}
+	sw.EndRender(w)
+}
+
+// columnsFor returns the number of swatch columns which fit in the specified width
+func (sw *colorSwatches) columnsFor(width float32) int {
+
+	return int((width + swatchSpacing) / (swatchSize + swatchSpacing))
+}
+
+// swatchRect returns the rectangle of the swatch with the specified palette index in local coordinates
+func (sw *colorSwatches) swatchRect(index int) gb.Rect {
+
+	content := sw.ContentRect()
+	columns := util.Max(sw.columnsFor(content.Size().X), 1)
+	min := gb.Vec2{
+		content.Min.X + float32(index%columns)*(swatchSize+swatchSpacing),
+		content.Min.Y + float32(index/columns)*(swatchSize+swatchSpacing),
+	}
+	return gb.Rect{Min: min, Max: gb.Vec2{min.X + swatchSize, min.Y + swatchSize}}
+}
+
+// swatchAt returns the palette index of the swatch at the specified local point or -1 if none
+func (sw *colorSwatches) swatchAt(p gb.Vec2) int {
+
+	for i := range colorPalette {
+		if sw.swatchRect(i).Contains(p) {
+			return i
+		}
+	}
+	return -1
+}
+
+// newColorPalette returns the distinct named colors with the grays first,
+// from light to dark, followed by the other colors sorted by hue and then by value
+func newColorPalette() []color.NamedColor {
+
+	const graySaturation = 0.1
+	var palette []color.NamedColor
+	seen := make(map[color.Color]bool)
+	for _, nc := range color.Named {
+		if !seen[nc.Color] {
+			seen[nc.Color] = true
+			palette = append(palette, nc)
+		}
+	}
+	sort.SliceStable(palette, func(i, j int) bool {
+		hi, si, vi := palette[i].Color.HSV()
+		hj, sj, vj := palette[j].Color.HSV()
+		grayi, grayj := si < graySaturation, sj < graySaturation
+		switch {
+		case grayi != grayj:
+			return grayi
+		case grayi:
+			return vi > vj
+		case hi != hj:
+			return hi < hj
+		default:
+			return vi > vj
+		}
+	})
+	return palette
+}
@@ -0,0 +1,693 @@
+package view
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// Default maximum number of items shown in the ComboBox popup list before it scrolls
+const comboDefaultVisible = 10
+
+// Number of average characters used for the minimum desired width of a ComboBox
+const comboDefaultChars = 12
+
+// Maximum number of items measured for the desired width of a ComboBox
+const comboMeasureItems = 100
+
+// Time in seconds after the last typed character when a new type-ahead search starts
+const comboSearchTimeout = 1.0
+
+// ComboDataSource supplies the items of a ComboBox
+type ComboDataSource interface {
+	Len() int              // Returns the number of items
+	Text(index int) string // Returns the text of the item, shown in the box and used for searching
+}
+
+// ComboItemRenderer can be implemented by a ComboDataSource to draw its items in the popup list.
+// The Index of the item is the index in the data source. Otherwise the item text is drawn.
+type ComboItemRenderer interface {
+	RenderItem(w *window.Window, item *ListItem) // Draws the item in its rectangle
+}
+
+// ComboBox shows the selected item of a data source and opens a popup list with all items,
+// which scrolls when there are more items than its maximum number of visible items.
+// The popup is opened by clicking the box or its arrow, Alt+Down, F4 or Space, an item is
+// selected by clicking it or by Enter and Escape closes the popup.
+// Up/Down, PageUp/PageDown and Home/End move the selection, or the highlighted item of the
+// open popup, and typed characters select the next item starting with them (type-ahead).
+// In editable mode the text can be typed, and the popup lists the items containing it.
+type ComboBox struct {
+	TextEdit
+	src        ComboDataSource // Data source
+	selected   int             // Selected item index (-1 if none)
+	editable   bool            // Text can be edited
+	maxVisible int             // Maximum number of visible items in the popup
+	popup      *comboPopup     // Popup list
+	open       bool            // Popup is open
+	filter     []int           // Item indices shown in the popup while filtering (nil shows all)
+	closedAt   time.Time       // Frame time when the popup was last closed
+	search     string          // Lowercase type-ahead search text
+	searchAt   time.Time       // Time of the last type-ahead character
+	arrowHover bool            // Cursor is over the arrow button
+	onChange   func(*ComboBox) // Selection change callback
+}
+
+// comboPopup is the popup list of a ComboBox
+type comboPopup struct {
+	ListView
+	cb      *ComboBox
+	pressed bool // Mouse button pressed over an item
+}
+
+// comboItems is the ListDataSource of the popup list of a ComboBox
+type comboItems struct {
+	cb *ComboBox
+}
+
+// NewComboBox creates and returns a new ComboBox with the specified data source, which can be nil
+func NewComboBox(src ComboDataSource) *ComboBox {
+
+	c := new(ComboBox)
+	c.initTextEdit(c, "")
+	c.src = src
+	c.selected = -1
+	c.maxVisible = comboDefaultVisible
+	c.popup = &comboPopup{cb: c}
+	c.popup.initListView(c.popup, comboItems{c})
+	c.popup.padding = Insets{1, 1, 1, 1}
+	return c
+}
+
+// SetDataSource sets the data source, clearing the selection
+func (c *ComboBox) SetDataSource(src ComboDataSource) {
+
+	c.src = src
+	c.filter = nil
+	c.selected = -1
+	c.TextEdit.SetText("")
+	c.popup.DataChanged()
+}
+
+// DataSource returns the current data source
+func (c *ComboBox) DataSource() ComboDataSource {
+
+	return c.src
+}
+
+// DataChanged must be called when the items of the data source changed.
+// The selection is cleared if the selected index is no longer valid.
+func (c *ComboBox) DataChanged() {
+
+	c.filter = nil
+	if c.selected >= c.count() {
+		c.SetSelected(-1)
+	} else if c.selected >= 0 {
+		c.TextEdit.SetText(c.src.Text(c.selected))
+	}
+	c.popup.DataChanged()
+}
+
+// SetSelected selects the item with the specified index, setting the text to the item text.
+// An invalid index clears the selection.
+func (c *ComboBox) SetSelected(index int) {
+
+	if index < 0 || index >= c.count() {
+		index = -1
+	}
+	if index >= 0 {
+		c.TextEdit.SetText(c.src.Text(index))
+	} else if !c.editable {
+		c.TextEdit.SetText("")
+	}
+	if index == c.selected {
+		return
+	}
+	c.selected = index
+	if c.onChange != nil {
+		c.onChange(c)
+	}
+}
+
+// Selected returns the index of the selected item or -1 if none
+func (c *ComboBox) Selected() int {
+
+	return c.selected
+}
+
+// SetEditable sets if the text can be typed, filtering the items of the popup list
+func (c *ComboBox) SetEditable(editable bool) {
+
+	c.editable = editable
+	if !editable {
+		c.SetSelected(c.selected)
+	}
+}
+
+// Editable returns if the text can be typed
+func (c *ComboBox) Editable() bool {
+
+	return c.editable
+}
+
+// SetMaxVisibleItems sets the maximum number of items shown in the popup list before it scrolls.
+// The default is 10.
+func (c *ComboBox) SetMaxVisibleItems(count int) {
+
+	c.maxVisible = util.Max(count, 1)
+}
+
+// MaxVisibleItems returns the maximum number of items shown in the popup list
+func (c *ComboBox) MaxVisibleItems() int {
+
+	return c.maxVisible
+}
+
+// OnChange sets the function called when the selected item changes
+func (c *ComboBox) OnChange(cb func(c *ComboBox)) {
+
+	c.onChange = cb
+}
+
+// IsOpen returns if the popup list is open
+func (c *ComboBox) IsOpen() bool {
+
+	return c.open
+}
+
+// Open opens the popup list below the box with all items, highlighting the selected item
+func (c *ComboBox) Open(w *window.Window) {
+
+	if c.disabled {
+		return
+	}
+	c.filter = nil
+	c.showPopup(w)
+	if c.open {
+		c.highlight(c.selected)
+	}
+}
+
+// Close closes the popup list if open
+func (c *ComboBox) Close(w *window.Window) {
+
+	if c.open {
+		CloseOverlay(w, c.popup)
+	}
+}
+
+// Measure satisfies the IView interface
+func (c *ComboBox) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	// The right padding reserves the space of the arrow button, which depends on the font
+	c.padding.Right = spinBoxTextPadding + c.arrowWidth(w)
+	fa := w.Font(c.ff, 0)
+	width := fa.MeasureString("0") * comboDefaultChars
+	for i := 0; i < util.Min(c.count(), comboMeasureItems); i++ {
+		width = util.Max(width, fa.MeasureString(c.src.Text(i)))
+	}
+	size := gb.Vec2{width, fa.Height()}
+	size.Add(c.padding.Size())
+	return c.ConstrainSize(size)
+}
+
+// OnEvent satisfies the IView interface
+func (c *ComboBox) OnEvent(w *window.Window, ev *Event) bool {
+
+	switch ev.Type {
+	case EventMouseEnter:
+		c.arrowHover = c.arrowAt(w, ev.Pos)
+		if !c.editable || c.arrowHover {
+			c.hovered = true
+			w.SetCursor(gb.CursorDefault)
+			return false
+		}
+	case EventMouseMove:
+		if !c.editable {
+			return false
+		}
+		hover := c.arrowAt(w, ev.Pos)
+		if hover != c.arrowHover {
+			c.arrowHover = hover
+			if hover {
+				w.SetCursor(gb.CursorDefault)
+			} else {
+				w.SetCursor(gb.CursorIBeam)
+			}
+		}
+	case EventMouseLeave:
+		c.arrowHover = false
+		if !c.editable {
+			c.hovered = false
+			return false
+		}
+	case EventMouseDown:
+		if c.disabled || ev.Button != gb.MouseButtonLeft || (c.editable && !c.arrowAt(w, ev.Pos)) {
+			break
+		}
+		// The press which closed the popup as an outside press must not open it again
+		if c.open {
+			c.Close(w)
+		} else if c.closedAt != w.FrameTime() {
+			c.Open(w)
+		}
+		return true
+	case EventMouseUp:
+		if !c.editable {
+			return ev.Button == gb.MouseButtonLeft
+		}
+	case EventFocusOut:
+		// The popup list gives the focus back to the box when clicked
+		if Focused(w) != c.popup {
+			c.Close(w)
+		}
+	case EventKeyDown:
+		if c.disabled {
+			break
+		}
+		if c.onKey(w, ev) {
+			return true
+		}
+		if !c.editable {
+			return false
+		}
+		before := c.TextEdit.Text()
+		handled := c.TextEdit.OnEvent(w, ev)
+		if c.TextEdit.Text() != before {
+			c.textEdited(w)
+		}
+		return handled
+	case EventChar:
+		if c.disabled {
+			break
+		}
+		if !c.editable {
+			c.typeAhead(w, ev.Char)
+			return true
+		}
+		handled := c.TextEdit.OnEvent(w, ev)
+		c.textEdited(w)
+		return handled
+	}
+	if !c.editable {
+		return false
+	}
+	return c.TextEdit.OnEvent(w, ev)
+}
+
+// Render satisfies the IView interface
+func (c *ComboBox) Render(w *window.Window) {
+
+	if !c.visible {
+		return
+	}
+	if c.editable {
+		c.renderEditable(w)
+	} else {
+		c.renderBox(w)
+	}
+}
+
+// renderEditable renders the text entry and its arrow button over the right side
+func (c *ComboBox) renderEditable(w *window.Window) {
+
+	c.TextEdit.Render(w)
+	dl := c.BeginRender()
+	aw := c.arrowWidth(w)
+	min := gb.Vec2{c.size.X - aw, 0}
+	bg := StyleColorButton
+	switch {
+	case c.disabled:
+		bg = StyleColorButtonDisabled
+	case c.open:
+		bg = StyleColorButtonPressed
+	case c.arrowHover:
+		bg = StyleColorButtonHovered
+	}
+	w.AddRectFilled(dl, min, c.size, c.StyleColor(w, bg).RGBA(), c.StyleFrameRounding(w),
+		window.DrawFlags_RoundCornersTopRight|window.DrawFlags_RoundCornersBottomRight)
+	w.AddLine(dl, min, gb.Vec2{min.X, c.size.Y}, c.StyleColor(w, StyleColorBorder).RGBA(), 1)
+	c.drawArrow(w, dl, aw)
+	c.EndRender(w)
+}
+
+// renderBox renders the non editable box as a button with the selected item text
+func (c *ComboBox) renderBox(w *window.Window) {
+
+	dl := c.BeginRender()
+	bg := StyleColorButton
+	textColor := StyleColorText
+	switch {
+	case c.disabled:
+		bg = StyleColorButtonDisabled
+		textColor = StyleColorTextDisabled
+	case c.open:
+		bg = StyleColorButtonPressed
+	case c.hovered:
+		bg = StyleColorButtonHovered
+	}
+	rounding := c.StyleFrameRounding(w)
+	w.AddRectFilled(dl, gb.Vec2{}, c.size, c.StyleColor(w, bg).RGBA(), rounding, window.DrawFlags_RoundCornersAll)
+	if c.HasFocus(w) && !c.disabled {
+		w.AddRect(dl, gb.Vec2{}, c.size, c.StyleColor(w, StyleColorFocus).RGBA(), rounding, window.DrawFlags_RoundCornersAll, 2)
+	}
+
+	// Draws the text or the placeholder clipped to the content area
+	fa := w.Font(c.ff, 0)
+	content := c.ContentRect()
+	text := c.TextEdit.Text()
+	if text == "" {
+		text = c.placeholder
+		textColor = StyleColorTextDisabled
+	}
+	w.PushClipRect(c.WindowRect(content))
+	pos := gb.Vec2{content.Min.X, content.Min.Y + (content.Size().Y-fa.Height())/2}
+	w.AddText(dl, fa, &pos, c.StyleColor(w, textColor).RGBA(), window.TextVAlignTop, text)
+	w.PopClipRect()
+	c.drawArrow(w, dl, c.arrowWidth(w))
+	c.EndRender(w)
+}
+
+// drawArrow draws the down arrow centered in the arrow button area with the specified width
+func (c *ComboBox) drawArrow(w *window.Window, dl *gb.DrawList, aw float32) {
+
+	col := StyleColorText
+	if c.disabled {
+		col = StyleColorTextDisabled
+	}
+	center := gb.Vec2{c.size.X - aw/2, c.size.Y / 2}
+	drawArrow(w, dl, center, aw*0.4, arrowDown, c.StyleColor(w, col).RGBA())
+}
+
+// onKey processes the navigation keys and returns if the key was handled
+func (c *ComboBox) onKey(w *window.Window, ev *Event) bool {
+
+	if c.open {
+		switch ev.Key {
+		case gb.KeyEscape, gb.KeyF4:
+			c.Close(w)
+			return true
+		case gb.KeyUp:
+			if ev.Mods&gb.ModAlt != 0 {
+				c.Close(w)
+				return true
+			}
+		case gb.KeyEnter, gb.KeyKPEnter:
+			if c.popup.SelectedIndex() < 0 {
+				return false
+			}
+			c.choose(w, c.popup.SelectedIndex())
+			return true
+		case gb.KeyHome, gb.KeyEnd:
+			if c.editable {
+				return false
+			}
+		}
+		if next, ok := c.moveKey(ev, c.popup.SelectedIndex(), c.visibleCount(), c.maxVisible); ok {
+			c.popup.Select(next)
+			c.popup.ScrollToIndex(next)
+			return true
+		}
+		return false
+	}
+
+	switch ev.Key {
+	case gb.KeyF4:
+		c.Open(w)
+		return true
+	case gb.KeyDown:
+		if ev.Mods&gb.ModAlt != 0 || c.editable {
+			c.Open(w)
+			return true
+		}
+	case gb.KeySpace, gb.KeyEnter, gb.KeyKPEnter:
+		if !c.editable {
+			c.Open(w)
+			return true
+		}
+	}
+	if c.editable {
+		return false
+	}
+	if next, ok := c.moveKey(ev, c.selected, c.count(), c.maxVisible); ok {
+		c.SetSelected(next)
+		return true
+	}
+	return false
+}
+
+// moveKey returns the index moved from the current index by an Up/Down, PageUp/PageDown
+// or Home/End key in a list with the specified number of items and page size
+func (c *ComboBox) moveKey(ev *Event, current, count, page int) (int, bool) {
+
+	if count == 0 {
+		return 0, false
+	}
+	next := current
+	switch ev.Key {
+	case gb.KeyUp:
+		next--
+	case gb.KeyDown:
+		next++
+	case gb.KeyPageUp:
+		next -= page
+	case gb.KeyPageDown:
+		next += page
+	case gb.KeyHome:
+		next = 0
+	case gb.KeyEnd:
+		next = count - 1
+	default:
+		return 0, false
+	}
+	if current < 0 && ev.Key == gb.KeyUp {
+		next = count - 1
+	}
+	return util.Clamp(next, 0, count-1), true
+}
+
+// typeAhead adds the typed character to the search text and selects, or highlights in the
+// open popup, the next item starting with it. Typing the same character cycles the items
+// starting with it.
+func (c *ComboBox) typeAhead(w *window.Window, ch rune) {
+
+	now := w.FrameTime()
+	if now.Sub(c.searchAt).Seconds() > comboSearchTimeout {
+		c.search = ""
+	}
+	c.searchAt = now
+	c.search += strings.ToLower(string(ch))
+
+	// A repeated character searches for its single character prefix from the next item
+	prefix := c.search
+	first, size := utf8.DecodeRuneInString(prefix)
+	if strings.Count(prefix, string(first)) == utf8.RuneCountInString(prefix) {
+		prefix = prefix[:size]
+	}
+	current := c.selected
+	if c.open {
+		current = c.sourceIndex(c.popup.SelectedIndex())
+	}
+	from := current
+	if len(prefix) == size {
+		from++
+	}
+	found := -1
+	n := c.count()
+	for k := 0; k < n; k++ {
+		i := (util.Max(from, 0) + k) % n
+		if strings.HasPrefix(strings.ToLower(c.src.Text(i)), prefix) {
+			found = i
+			break
+		}
+	}
+	if found < 0 {
+		return
+	}
+	if c.open {
+		c.highlight(found)
+	} else {
+		c.SetSelected(found)
+	}
+}
+
+// textEdited filters the items of an editable box by its new text, opening the popup
+// with the items containing the text or closing it if there are none
+func (c *ComboBox) textEdited(w *window.Window) {
+
+	text := c.TextEdit.Text()
+	if c.selected >= 0 && text != c.src.Text(c.selected) {
+		c.selected = -1
+		if c.onChange != nil {
+			c.onChange(c)
+		}
+	}
+	c.filter = []int{}
+	lower := strings.ToLower(text)
+	for i := 0; i < c.count(); i++ {
+		if strings.Contains(strings.ToLower(c.src.Text(i)), lower) {
+			c.filter = append(c.filter, i)
+		}
+	}
+	if text == "" || len(c.filter) == 0 {
+		c.Close(w)
+		return
+	}
+	c.showPopup(w)
+	c.popup.Select(0)
+	c.popup.ScrollToIndex(0)
+}
+
+// choose selects the item with the specified popup list index and closes the popup
+func (c *ComboBox) choose(w *window.Window, index int) {
+
+	src := c.sourceIndex(index)
+	c.Close(w)
+	if src >= 0 {
+		c.SetSelected(src)
+	}
+}
+
+// showPopup opens the popup list below the box if there are items to show
+// or updates the popup list if it is already open
+func (c *ComboBox) showPopup(w *window.Window) {
+
+	c.popup.DataChanged()
+	if c.open {
+		return
+	}
+	if c.visibleCount() == 0 {
+		return
+	}
+	c.popup.ClearSelection()
+	ShowPopup(w, c.popup, c.WindowRect(gb.Rect{Max: c.size}), PlaceBelow)
+	OnOverlayClose(w, c.popup, func() {
+		c.open = false
+		c.closedAt = w.FrameTime()
+	})
+	c.open = true
+}
+
+// highlight selects the popup list item of the specified item index and scrolls to show it
+func (c *ComboBox) highlight(index int) {
+
+	for i := 0; i < c.visibleCount(); i++ {
+		if c.sourceIndex(i) == index {
+			c.popup.Select(i)
+			c.popup.ScrollToIndex(i)
+			return
+		}
+	}
+	c.popup.ClearSelection()
+}
+
+// count returns the number of items of the data source
+func (c *ComboBox) count() int {
+
+	if c.src == nil {
+		return 0
+	}
+	return c.src.Len()
+}
+
+// visibleCount returns the number of items in the popup list
+func (c *ComboBox) visibleCount() int {
+
+	if c.filter != nil {
+		return len(c.filter)
+	}
+	return c.count()
+}
+
+// sourceIndex returns the data source index of the item with the specified popup list index
+func (c *ComboBox) sourceIndex(index int) int {
+
+	if index < 0 || c.filter == nil {
+		return index
+	}
+	return c.filter[index]
+}
+
+// arrowWidth returns the width of the arrow button
+func (c *ComboBox) arrowWidth(w *window.Window) float32 {
+
+	return w.Font(c.ff, 0).Height()
+}
+
+// arrowAt returns if the specified local point is over the arrow button
+func (c *ComboBox) arrowAt(w *window.Window, p gb.Vec2) bool {
+
+	return (gb.Rect{Max: c.size}).Contains(p) && p.X >= c.size.X-c.arrowWidth(w)
+}
+
+// Measure satisfies the IView interface
+func (p *comboPopup) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	p.ListView.Measure(w, avail)
+	rows := util.Min(p.count(), p.cb.maxVisible)
+	return gb.Vec2{p.cb.size.X, float32(rows)*p.rowHeight() + p.padding.Vertical()}
+}
+
+// OnEvent satisfies the IView interface
+func (p *comboPopup) OnEvent(w *window.Window, ev *Event) bool {
+
+	switch ev.Type {
+	case EventFocusIn:
+		// The box keeps the focus for the keyboard navigation
+		SetFocus(w, p.cb)
+		return true
+	case EventMouseMove:
+		if index := p.ItemAt(ev.Pos); index >= 0 {
+			p.Select(index)
+		}
+	case EventMouseDown:
+		p.pressed = ev.Button == gb.MouseButtonLeft && p.ItemAt(ev.Pos) >= 0
+	case EventMouseUp:
+		if p.pressed && ev.Button == gb.MouseButtonLeft {
+			p.pressed = false
+			if index := p.ItemAt(ev.Pos); index >= 0 {
+				p.cb.choose(w, index)
+			}
+			return true
+		}
+	}
+	return p.ListView.OnEvent(w, ev)
+}
+
+// Render satisfies the IView interface
+func (p *comboPopup) Render(w *window.Window) {
+
+	if !p.visible {
+		return
+	}
+	dl := p.BeginRender()
+	w.AddRectFilled(dl, gb.Vec2{}, p.size, p.StyleColor(w, StyleColorMenu).RGBA(), 0, 0)
+	p.EndRender(w)
+	p.ListView.Render(w)
+	dl = p.BeginRender()
+	w.AddRect(dl, gb.Vec2{}, p.size, p.StyleColor(w, StyleColorBorder).RGBA(), 0, 0, 1)
+	p.EndRender(w)
+}
+
+// Len satisfies the ListDataSource interface
+func (items comboItems) Len() int {
+
+	return items.cb.visibleCount()
+}
+
+// RenderItem satisfies the ListDataSource interface
+func (items comboItems) RenderItem(w *window.Window, item *ListItem) {
+
+	index := items.cb.sourceIndex(item.Index)
+	if r, ok := items.cb.src.(ComboItemRenderer); ok {
+		srcItem := *item
+		srcItem.Index = index
+		r.RenderItem(w, &srcItem)
+		return
+	}
+	item.DrawText(w, items.cb.src.Text(index))
+}
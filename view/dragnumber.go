@@ -0,0 +1,196 @@
+package view
+
+import (
+	"math"
+
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// Number of pixels to drag over the full range of a logarithmic DragNumber
+const dragNumberLogPixels = 200
+
+// DragNumber is a view which shows a numeric value changed by dragging the mouse horizontally.
+// Holding Shift while dragging gives fine changes and holding Control coarse changes.
+// When focused the value is also changed by the arrow keys with the same modifiers.
+type DragNumber struct {
+	View
+	numericValue
+	ff       window.FontStyleType // Text font style
+	speed    float64              // Value change per dragged pixel (0 uses the default)
+	hovered  bool                 // Cursor is over the view
+	dragging bool                 // Value is being dragged
+	lastX    float32              // Last horizontal cursor position in window coordinates
+	dragPos  float64              // Unsnapped value (or fraction for logarithmic ranges) while dragging
+	onChange func(*DragNumber)    // Value change callback
+}
+
+// NewDragNumber creates and returns a new DragNumber with the specified range.
+// Infinite limits can be used for an unbounded value.
+func NewDragNumber(min, max float64) *DragNumber {
+
+	d := new(DragNumber)
+	d.Init(d)
+	d.initNumeric(min, max, func() {
+		if d.onChange != nil {
+			d.onChange(d)
+		}
+	})
+	d.ff = window.FontRegular
+	d.focusable = true
+	d.padding = InsetsXY(6, 4)
+	return d
+}
+
+// SetSpeed sets the value change per dragged pixel.
+// The default (0) uses the step if set, otherwise a two hundredth of a bounded range or 0.1.
+func (d *DragNumber) SetSpeed(speed float64) {
+
+	d.speed = math.Abs(speed)
+}
+
+// Speed returns the value change per dragged pixel
+func (d *DragNumber) Speed() float64 {
+
+	return d.speed
+}
+
+// Dragging returns if the value is being dragged
+func (d *DragNumber) Dragging() bool {
+
+	return d.dragging
+}
+
+// OnChange sets the function called when the value changes
+func (d *DragNumber) OnChange(cb func(d *DragNumber)) {
+
+	d.onChange = cb
+}
+
+// Measure satisfies the IView interface
+func (d *DragNumber) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	fa := w.Font(d.ff, 0)
+	size := gb.Vec2{util.Max(fa.MeasureString(d.formatValue()), fa.MeasureString("00000000")), fa.Height()}
+	size.Add(d.padding.Size())
+	return d.ConstrainSize(size)
+}
+
+// OnEvent satisfies the IView interface
+func (d *DragNumber) OnEvent(w *window.Window, ev *Event) bool {
+
+	switch ev.Type {
+	case EventMouseEnter:
+		d.hovered = true
+		w.SetCursor(gb.CursorHResize)
+	case EventMouseLeave:
+		d.hovered = false
+		if !d.dragging {
+			w.SetCursor(gb.CursorDefault)
+		}
+	case EventMouseDown:
+		if d.disabled || ev.Button != gb.MouseButtonLeft {
+			return false
+		}
+		d.dragging = true
+		d.lastX = ev.WinPos.X
+		d.dragPos = d.value
+		if d.useLog() {
+			d.dragPos = d.fraction()
+		}
+		return true
+	case EventMouseMove:
+		if !d.dragging {
+			return false
+		}
+		delta := float64(ev.WinPos.X-d.lastX) * stepMultiplier(ev.Mods)
+		d.lastX = ev.WinPos.X
+		if d.useLog() {
+			d.dragPos = math.Max(0, math.Min(d.dragPos+delta/dragNumberLogPixels, 1))
+			d.setFraction(d.dragPos)
+		} else {
+			d.dragPos = math.Max(d.min, math.Min(d.dragPos+delta*d.dragSpeed(), d.max))
+			d.SetValue(d.dragPos)
+		}
+		return true
+	case EventMouseUp:
+		if d.dragging && ev.Button == gb.MouseButtonLeft {
+			d.dragging = false
+			if !d.hovered {
+				w.SetCursor(gb.CursorDefault)
+			}
+			return true
+		}
+	case EventKeyDown:
+		if d.disabled {
+			return false
+		}
+		steps := numericKeySteps(ev)
+		if steps == 0 {
+			return false
+		}
+		d.stepBy(steps)
+		return true
+	}
+	return false
+}
+
+// Render satisfies the IView interface
+func (d *DragNumber) Render(w *window.Window) {
+
+	if !d.visible {
+		return
+	}
+	dl := d.BeginRender()
+	rounding := d.StyleFrameRounding(w)
+
+	// Draws the frame
+	bg := StyleColorFrame
+	switch {
+	case d.disabled:
+		bg = StyleColorButtonDisabled
+	case d.dragging:
+		bg = StyleColorFrameActive
+	case d.hovered:
+		bg = StyleColorFrameHovered
+	}
+	w.AddRectFilled(dl, gb.Vec2{}, d.size, d.StyleColor(w, bg).RGBA(), rounding, window.DrawFlags_RoundCornersAll)
+	border := d.StyleColor(w, StyleColorBorder).RGBA()
+	thickness := float32(1)
+	if d.HasFocus(w) && !d.disabled {
+		border = d.StyleColor(w, StyleColorFocus).RGBA()
+		thickness = 2
+	}
+	w.AddRect(dl, gb.Vec2{}, d.size, border, rounding, window.DrawFlags_RoundCornersAll, thickness)
+
+	// Draws the value centered
+	fa := w.Font(d.ff, 0)
+	text := d.formatValue()
+	content := d.ContentRect()
+	pos := gb.Vec2{
+		content.Min.X + (content.Size().X-fa.MeasureString(text))/2,
+		content.Min.Y + (content.Size().Y-fa.Height())/2,
+	}
+	textColor := d.StyleColor(w, StyleColorText).RGBA()
+	if d.disabled {
+		textColor = d.StyleColor(w, StyleColorTextDisabled).RGBA()
+	}
+	w.AddText(dl, fa, &pos, textColor, window.TextVAlignTop, text)
+	d.EndRender(w)
+}
+
+// dragSpeed returns the value change per dragged pixel
+func (d *DragNumber) dragSpeed() float64 {
+
+	switch {
+	case d.speed > 0:
+		return d.speed
+	case d.step > 0:
+		return d.step
+	case d.bounded() && d.max > d.min:
+		return (d.max - d.min) / 200
+	default:
+		return 0.1
+	}
+}
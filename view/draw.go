@@ -0,0 +1,72 @@
+package view
+
+import (
+	"github.com/leonsal/gux/color"
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// arrowDir is the direction of the arrows drawn by drawArrow
+type arrowDir int
+
+const (
+	arrowUp arrowDir = iota
+	arrowDown
+	arrowLeft
+	arrowRight
+)
+
+// drawArrow draws a filled triangle pointing in the specified direction
+// centered at the specified point and with the specified width.
+func drawArrow(w *window.Window, dl *gb.DrawList, center gb.Vec2, size float32, dir arrowDir, col gb.RGBA) {
+
+	h := size / 2
+	q := size / 4
+	points := w.ReserveVec2(3)
+	switch dir {
+	case arrowUp:
+		points[0] = gb.Vec2{center.X - h, center.Y + q}
+		points[1] = gb.Vec2{center.X, center.Y - q}
+		points[2] = gb.Vec2{center.X + h, center.Y + q}
+	case arrowDown:
+		points[0] = gb.Vec2{center.X - h, center.Y - q}
+		points[1] = gb.Vec2{center.X + h, center.Y - q}
+		points[2] = gb.Vec2{center.X, center.Y + q}
+	case arrowLeft:
+		points[0] = gb.Vec2{center.X + q, center.Y - h}
+		points[1] = gb.Vec2{center.X + q, center.Y + h}
+		points[2] = gb.Vec2{center.X - q, center.Y}
+	default:
+		points[0] = gb.Vec2{center.X - q, center.Y - h}
+		points[1] = gb.Vec2{center.X + q, center.Y}
+		points[2] = gb.Vec2{center.X - q, center.Y + h}
+	}
+	w.AddConvexPolyFilled(dl, points, col)
+}
+
+// drawCheckMark draws a check mark inside the square with the specified top left corner and side
+func drawCheckMark(w *window.Window, dl *gb.DrawList, min gb.Vec2, side float32, col gb.RGBA) {
+
+	points := w.ReserveVec2(3)
+	points[0] = gb.Vec2{min.X + side*0.22, min.Y + side*0.52}
+	points[1] = gb.Vec2{min.X + side*0.42, min.Y + side*0.72}
+	points[2] = gb.Vec2{min.X + side*0.78, min.Y + side*0.30}
+	w.AddPolyLine(dl, points, col, window.DrawFlags_None, side*0.12)
+}
+
+// drawChecker draws a checkerboard with squares of the specified size in the specified rectangle,
+// used as background of colors with transparency
+func drawChecker(w *window.Window, dl *gb.DrawList, min, max gb.Vec2, cell float32) {
+
+	w.AddRectFilled(dl, min, max, color.White.RGBA(), 0, 0)
+	dark := color.Lightgray.RGBA()
+	for y, row := min.Y, 0; y < max.Y; y, row = y+cell, row+1 {
+		for x, col := min.X, 0; x < max.X; x, col = x+cell, col+1 {
+			if (row+col)%2 == 0 {
+				continue
+			}
+			w.AddRectFilled(dl, gb.Vec2{x, y}, gb.Vec2{util.Min(x+cell, max.X), util.Min(y+cell, max.Y)}, dark, 0, 0)
+		}
+	}
+}
@@ -0,0 +1,434 @@
+package view
+
+import (
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// EventType is the type of the events dispatched to views
+type EventType int
+
+const (
+	EventMouseDown  EventType = iota // Mouse button pressed over the view
+	EventMouseUp                     // Mouse button released
+	EventMouseMove                   // Cursor moved over the view
+	EventMouseEnter                  // Cursor entered the view or one of its children (not bubbled)
+	EventMouseLeave                  // Cursor left the view and its children (not bubbled)
+	EventScroll                      // Mouse wheel scrolled over the view
+	EventKeyDown                     // Key pressed or repeated while the view has the focus
+	EventKeyUp                       // Key released while the view has the focus
+	EventChar                        // Character input while the view has the focus
+	EventFocusIn                     // View received the keyboard focus (not bubbled)
+	EventFocusOut                    // View lost the keyboard focus (not bubbled)
+)
+
+// Event describes an event dispatched to a view
+type Event struct {
+	Type   EventType      // Event type
+	Pos    gb.Vec2        // Cursor position in the local coordinates of the view receiving the event
+	WinPos gb.Vec2        // Cursor position in window coordinates
+	Button gb.MouseButton // Mouse button for EventMouseDown and EventMouseUp
+	Key    gb.Key         // Key for EventKeyDown and EventKeyUp
+	Mods   gb.ModKey      // Modifier keys held down
+	Repeat bool           // EventKeyDown generated by key repeat
+	Char   rune           // Unicode code point for EventChar
+	Scroll gb.Vec2        // Scroll offsets for EventScroll
+}
+
+// eventState contains the per window state of the event dispatcher
+type eventState struct {
+	root     IView     // Top view of the window in the last dispatch
+	cursor   gb.Vec2   // Last cursor position in window coordinates
+	inside   bool      // Cursor is inside the window
+	mods     gb.ModKey // Last modifier keys state
+	buttons  int       // Number of mouse buttons currently pressed
+	hovered  IView     // Deepest view under the cursor
+	captured IView     // View receiving the mouse events while buttons are pressed
+	focused  IView     // View with the keyboard focus
+}
+
+// windowEventState maps Windows to its event dispatcher state
+var windowEventState = map[*window.Window]*eventState{}
+
+// getEventState returns the event dispatcher state for the window, creating it if necessary
+func getEventState(w *window.Window) *eventState {
+
+	s, ok := windowEventState[w]
+	if !ok {
+		s = new(eventState)
+		windowEventState[w] = s
+		w.OnDestroy(func(w *window.Window) { delete(windowEventState, w) })
+	}
+	return s
+}
+
+// OnEvent satisfies the IView interface.
+// The default implementation does not handle the event, which is then bubbled to the parent view.
+func (v *View) OnEvent(w *window.Window, ev *Event) bool {
+
+	return false
+}
+
+// SetEnabled sets if the view is enabled. Disabled views ignore input and are drawn with disabled colors.
+func (v *View) SetEnabled(enabled bool) {
+
+	v.disabled = !enabled
+}
+
+// Enabled returns if the view is enabled
+func (v *View) Enabled() bool {
+
+	return !v.disabled
+}
+
+// SetFocusable sets if the view can receive the keyboard focus when clicked
+func (v *View) SetFocusable(focusable bool) {
+
+	v.focusable = focusable
+}
+
+// Focusable returns if the view can receive the keyboard focus
+func (v *View) Focusable() bool {
+
+	return v.focusable
+}
+
+// HasFocus returns if the view has the keyboard focus of the specified window
+func (v *View) HasFocus(w *window.Window) bool {
+
+	return v.iview != nil && getEventState(w).focused == v.iview
+}
+
+// SetFocus sets the view which receives the keyboard events of the window.
+// The previous focused view receives EventFocusOut and the new one EventFocusIn.
+// A nil view clears the focus.
+func SetFocus(w *window.Window, iv IView) {
+
+	s := getEventState(w)
+	if s.focused == iv {
+		return
+	}
+	old := s.focused
+	s.focused = iv
+	if old != nil {
+		sendEvent(w, old, &Event{Type: EventFocusOut, WinPos: s.cursor, Mods: s.mods})
+	}
+	if iv != nil {
+		sendEvent(w, iv, &Event{Type: EventFocusIn, WinPos: s.cursor, Mods: s.mods})
+	}
+}
+
+// Focused returns the view with the keyboard focus of the window or nil
+func Focused(w *window.Window) IView {
+
+	return getEventState(w).focused
+}
+
+// Hovered returns the deepest view under the cursor of the window or nil
+func Hovered(w *window.Window) IView {
+
+	return getEventState(w).hovered
+}
+
+// CursorPos returns the last cursor position of the window in window coordinates
+func CursorPos(w *window.Window) gb.Vec2 {
+
+	return getEventState(w).cursor
+}
+
+// ToLocal converts a point from window coordinates to the local coordinates
+// of the view using its transform from the last render.
+func (v *View) ToLocal(p gb.Vec2) gb.Vec2 {
+
+	var inv gb.Mat3
+	if inv.GetInverse(&v.transform) != nil {
+		return p
+	}
+	p.ApplyMat3(&inv)
+	return p
+}
+
+// ToWindow converts a point from the local coordinates of the view to window coordinates
+func (v *View) ToWindow(p gb.Vec2) gb.Vec2 {
+
+	p.ApplyMat3(&v.transform)
+	return p
+}
+
+// WindowRect returns the bounding rectangle in window coordinates of
+// the specified rectangle in the local coordinates of the view
+func (v *View) WindowRect(r gb.Rect) gb.Rect {
+
+	corners := [4]gb.Vec2{r.Min, {r.Max.X, r.Min.Y}, r.Max, {r.Min.X, r.Max.Y}}
+	res := gb.Rect{Min: v.ToWindow(corners[0]), Max: v.ToWindow(corners[0])}
+	for _, c := range corners[1:] {
+		p := v.ToWindow(c)
+		res.Min.X = util.Min(res.Min.X, p.X)
+		res.Min.Y = util.Min(res.Min.Y, p.Y)
+		res.Max.X = util.Max(res.Max.X, p.X)
+		res.Max.Y = util.Max(res.Max.Y, p.Y)
+	}
+	return res
+}
+
+// DispatchEvents dispatches the events of the current window frame to the
+// views of the tree with the specified top view.
+// Mouse events are sent to the deepest view under the cursor, or to the view which received
+// the last mouse button press while any button is held down, and keyboard events to the focused view.
+// Events not handled by a view are bubbled up to its parents.
+// The tooltip of the view under the cursor is shown after the cursor rests over it.
+func DispatchEvents(w *window.Window, iv IView) {
+
+	s := getEventState(w)
+	if s.root != nil && s.root != iv {
+		s.hovered = nil
+		s.captured = nil
+		s.buttons = 0
+		s.focused = nil
+	}
+	s.root = iv
+	moved := false
+	pressed := false
+	for i := range w.FrameInfo().Events {
+		gev := &w.FrameInfo().Events[i]
+		switch gev.Type {
+		case gb.EventCursorPos:
+			s.cursor = gev.Vec2()
+			s.inside = true
+			moved = true
+			updateHovered(w, s)
+			target := s.captured
+			if target == nil {
+				target = s.hovered
+			}
+			bubbleEvent(w, target, &Event{Type: EventMouseMove, WinPos: s.cursor, Mods: s.mods})
+		case gb.EventCursorEnter:
+			s.inside = gev.Entered()
+			updateHovered(w, s)
+		case gb.EventMouseButton:
+			s.mods = gev.Mods()
+			ev := &Event{WinPos: s.cursor, Button: gev.Button(), Mods: s.mods}
+			if gev.Action() == gb.ActionPress {
+				ev.Type = EventMouseDown
+				pressed = true
+				if s.buttons == 0 {
+					closeOutside(w, s.cursor)
+				}
+				target := hitTestWindow(w, iv, s.cursor)
+				if s.buttons == 0 {
+					s.captured = target
+					// Presses blocked by a modal overlay keep the focus
+					if target != nil || getOverlayLayer(w).topModal() < 0 {
+						updateFocusFrom(w, target)
+					}
+				}
+				s.buttons++
+				if !bubbleEvent(w, s.captured, ev) && ev.Button == gb.MouseButtonRight {
+					openContextMenu(w, s.captured, s.cursor)
+				}
+			} else {
+				ev.Type = EventMouseUp
+				target := s.captured
+				if target == nil {
+					target = hitTestWindow(w, iv, s.cursor)
+				}
+				if s.buttons > 0 {
+					s.buttons--
+				}
+				if s.buttons == 0 {
+					s.captured = nil
+				}
+				bubbleEvent(w, target, ev)
+				updateHovered(w, s)
+			}
+		case gb.EventScroll:
+			bubbleEvent(w, hitTestWindow(w, iv, s.cursor), &Event{Type: EventScroll, WinPos: s.cursor, Mods: s.mods, Scroll: gev.Vec2()})
+		case gb.EventKey:
+			s.mods = gev.Mods()
+			ev := &Event{WinPos: s.cursor, Key: gev.Key(), Mods: s.mods}
+			switch gev.Action() {
+			case gb.ActionPress:
+				ev.Type = EventKeyDown
+			case gb.ActionRepeat:
+				ev.Type = EventKeyDown
+				ev.Repeat = true
+			default:
+				ev.Type = EventKeyUp
+			}
+			if !bubbleEvent(w, keyTarget(w, s), ev) && ev.Type == EventKeyDown {
+				dispatchShortcut(w, iv, ev)
+			}
+		case gb.EventChar:
+			bubbleEvent(w, keyTarget(w, s), &Event{Type: EventChar, WinPos: s.cursor, Mods: s.mods, Char: gev.Char()})
+		}
+	}
+	updateTooltip(w, s, moved, pressed)
+}
+
+// keyTarget returns the view which receives the keyboard events:
+// the focused view or the top view, replaced by the top modal overlay if blocked by it.
+func keyTarget(w *window.Window, s *eventState) IView {
+
+	target := s.focused
+	if target == nil {
+		target = s.root
+	}
+	l := getOverlayLayer(w)
+	if modal := l.topModal(); modal >= 0 && modalBlocks(w, target) {
+		return l.stack[modal].view
+	}
+	return target
+}
+
+// updateFocusFrom sets the focus to the first enabled focusable view
+// starting from the specified view up to the top view, or clears the focus if none found.
+func updateFocusFrom(w *window.Window, iv IView) {
+
+	for iv != nil {
+		v := iv.GetView()
+		if v.focusable && !v.disabled {
+			SetFocus(w, iv)
+			return
+		}
+		iv = v.parent
+	}
+	SetFocus(w, nil)
+}
+
+// updateHovered updates the view under the cursor sending EventMouseLeave
+// to the views no longer under the cursor and EventMouseEnter to the new ones.
+func updateHovered(w *window.Window, s *eventState) {
+
+	var hovered IView
+	if s.inside && s.root != nil {
+		hovered = hitTestWindow(w, s.root, s.cursor)
+	}
+	if hovered == s.hovered {
+		return
+	}
+	oldChain := viewChain(s.hovered)
+	newChain := viewChain(hovered)
+	s.hovered = hovered
+
+	// Leave events from the deepest view up
+	for _, old := range oldChain {
+		if !containsView(newChain, old) {
+			sendEvent(w, old, &Event{Type: EventMouseLeave, WinPos: s.cursor, Mods: s.mods})
+		}
+	}
+	// Enter events from the outermost view down
+	for i := len(newChain) - 1; i >= 0; i-- {
+		if !containsView(oldChain, newChain[i]) {
+			sendEvent(w, newChain[i], &Event{Type: EventMouseEnter, WinPos: s.cursor, Mods: s.mods})
+		}
+	}
+}
+
+// viewChain returns the list with the specified view and its ancestors
+func viewChain(iv IView) []IView {
+
+	var chain []IView
+	for iv != nil {
+		chain = append(chain, iv)
+		iv = iv.GetView().parent
+	}
+	return chain
+}
+
+// containsView returns if the list contains the specified view
+func containsView(list []IView, iv IView) bool {
+
+	for _, v := range list {
+		if v == iv {
+			return true
+		}
+	}
+	return false
+}
+
+// sendEvent sends the event to the specified view only, setting the local cursor position
+func sendEvent(w *window.Window, iv IView, ev *Event) bool {
+
+	ev.Pos = iv.GetView().ToLocal(ev.WinPos)
+	return iv.OnEvent(w, ev)
+}
+
+// bubbleEvent sends the event to the specified view and then to its parents until handled.
+// Returns if the event was handled.
+func bubbleEvent(w *window.Window, iv IView, ev *Event) bool {
+
+	for iv != nil {
+		if sendEvent(w, iv, ev) {
+			return true
+		}
+		iv = iv.GetView().parent
+	}
+	return false
+}
+
+// shortcutHandler is implemented by views which process the key down events
+// not handled by the focused view and its parents, such as MenuBar.
+type shortcutHandler interface {
+	onShortcut(w *window.Window, ev *Event) bool
+}
+
+// dispatchShortcut sends a key down event not handled by the focused view to the visible and enabled
+// shortcut handlers of the overlays and of the tree with the specified top view until handled.
+// The views below the top modal overlay do not receive the event.
+func dispatchShortcut(w *window.Window, iv IView, ev *Event) bool {
+
+	l := getOverlayLayer(w)
+	for i := len(l.stack) - 1; i >= 0; i-- {
+		if findShortcut(w, l.stack[i].view, ev) {
+			return true
+		}
+		if l.stack[i].flags&OverlayModal != 0 {
+			return false
+		}
+	}
+	return findShortcut(w, iv, ev)
+}
+
+// findShortcut sends the key down event to the shortcut handlers of the tree with the specified view until handled
+func findShortcut(w *window.Window, iv IView, ev *Event) bool {
+
+	v := iv.GetView()
+	if !v.visible || v.disabled {
+		return false
+	}
+	if h, ok := iv.(shortcutHandler); ok && h.onShortcut(w, ev) {
+		return true
+	}
+	for _, c := range v.children {
+		if findShortcut(w, c, ev) {
+			return true
+		}
+	}
+	return false
+}
+
+// hitTest returns the deepest visible view of the tree with the specified top view
+// which contains the specified point in window coordinates or nil if none found.
+// Children are tested from last to first as the last ones are rendered on top
+// and only inside the clip rectangle of views which clip their children.
+func hitTest(iv IView, p gb.Vec2) IView {
+
+	v := iv.GetView()
+	if !v.visible {
+		return nil
+	}
+	local := v.ToLocal(p)
+	if !v.clipChild || v.childClip.Contains(local) {
+		for i := len(v.children) - 1; i >= 0; i-- {
+			hit := hitTest(v.children[i], p)
+			if hit != nil {
+				return hit
+			}
+		}
+	}
+	if (gb.Rect{Max: v.size}).Contains(local) {
+		return iv
+	}
+	return nil
+}
@@ -0,0 +1,455 @@
+package view
+
+import (
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// FlexDirection specifies the main axis of a Flex container and the direction children are placed
+type FlexDirection int
+
+const (
+	FlexRow           FlexDirection = iota // Children are placed from left to right
+	FlexRowReverse                         // Children are placed from right to left
+	FlexColumn                             // Children are placed from top to bottom
+	FlexColumnReverse                      // Children are placed from bottom to top
+)
+
+// Justify specifies how the free space in the main axis of a Flex line is distributed
+type Justify int
+
+const (
+	JustifyStart        Justify = iota // Children are packed at the start of the line
+	JustifyEnd                         // Children are packed at the end of the line
+	JustifyCenter                      // Children are packed at the center of the line
+	JustifySpaceBetween                // Free space is distributed between children
+	JustifySpaceAround                 // Free space is distributed around children with half space at the ends
+	JustifySpaceEvenly                 // Free space is distributed evenly between and around children
+)
+
+// FlexBasisAuto specifies that the flex basis of a child is its desired size in the main axis
+const FlexBasisAuto float32 = -1
+
+// FlexItem contains the flex properties of a Flex container child
+type FlexItem struct {
+	Grow      float32 // Proportion of the positive free space of the line given to the child
+	Shrink    float32 // Proportion, scaled by the basis, of the negative free space removed from the child
+	Basis     float32 // Initial size of the child in the main axis or FlexBasisAuto
+	AlignSelf Align   // Alignment of the child in the cross axis or AlignAuto to use the container alignment
+}
+
+// DefaultFlexItem returns the flex properties used for children without specific properties
+func DefaultFlexItem() FlexItem {
+
+	return FlexItem{Grow: 0, Shrink: 1, Basis: FlexBasisAuto, AlignSelf: AlignAuto}
+}
+
+// Flex is a container which implements the core of the CSS flexible box layout.
+// Children are placed along the main axis in one or more lines, grown or shrunk
+// according to their flex properties and aligned in the cross axis of their line.
+type Flex struct {
+	Group
+	direction  FlexDirection      // Main axis and direction
+	wrap       bool               // Children wrap to new lines
+	justify    Justify            // Distribution of the free space in the main axis
+	alignItems Align              // Default alignment of children in the cross axis
+	rowGap     float32            // Gap between rows
+	columnGap  float32            // Gap between columns
+	items      map[IView]FlexItem // Specific flex properties of children
+	lines      []flexLine         // Lines calculated in the last layout pass
+}
+
+// flexLine contains the state of a line of children during layout
+type flexLine struct {
+	entries []flexEntry // Children in this line
+	main    float32     // Sum of hypothetical sizes including margins and gaps
+	cross   float32     // Maximum cross size including margins
+}
+
+// flexEntry contains the state of a child during layout
+type flexEntry struct {
+	iv   IView    // Child view
+	item FlexItem // Flex properties
+	base float32  // Hypothetical main size without margins
+	size float32  // Resolved main size without margins
+}
+
+// NewFlex creates and returns a new empty Flex container with the specified direction
+func NewFlex(direction FlexDirection) *Flex {
+
+	f := new(Flex)
+	f.Init(f)
+	f.direction = direction
+	f.alignItems = AlignStretch
+	f.items = make(map[IView]FlexItem)
+	return f
+}
+
+// Add appends the specified child views with default flex properties and returns this Flex
+func (f *Flex) Add(children ...IView) *Flex {
+
+	f.View.Add(children...)
+	return f
+}
+
+// SetDirection sets the main axis and direction of the children
+func (f *Flex) SetDirection(direction FlexDirection) {
+
+	f.direction = direction
+}
+
+// Direction returns the current direction of the children
+func (f *Flex) Direction() FlexDirection {
+
+	return f.direction
+}
+
+// SetWrap sets if children wrap to new lines when they do not fit in the main axis
+func (f *Flex) SetWrap(wrap bool) {
+
+	f.wrap = wrap
+}
+
+// Wrap returns if children wrap to new lines
+func (f *Flex) Wrap() bool {
+
+	return f.wrap
+}
+
+// SetJustify sets how the free space in the main axis is distributed.
+// The default is JustifyStart.
+func (f *Flex) SetJustify(justify Justify) {
+
+	f.justify = justify
+}
+
+// Justify returns the current distribution of the free space in the main axis
+func (f *Flex) Justify() Justify {
+
+	return f.justify
+}
+
+// SetAlignItems sets the default alignment of the children in the cross axis of their lines.
+// The default is AlignStretch.
+func (f *Flex) SetAlignItems(align Align) {
+
+	f.alignItems = align
+}
+
+// AlignItems returns the current default alignment of the children in the cross axis
+func (f *Flex) AlignItems() Align {
+
+	return f.alignItems
+}
+
+// SetGap sets the gap between rows and between columns.
+// For row directions the column gap separates children and the row gap separates lines.
+func (f *Flex) SetGap(row, column float32) {
+
+	f.rowGap = row
+	f.columnGap = column
+}
+
+// Gap returns the current gaps between rows and columns
+func (f *Flex) Gap() (row, column float32) {
+
+	return f.rowGap, f.columnGap
+}
+
+// AddItem appends a child view with the specified flex properties
+func (f *Flex) AddItem(iv IView, item FlexItem) {
+
+	f.Add(iv)
+	f.items[iv] = item
+}
+
+// SetItem sets the flex properties of the specified child view
+func (f *Flex) SetItem(iv IView, item FlexItem) {
+
+	f.items[iv] = item
+}
+
+// Item returns the flex properties of the specified child view
+func (f *Flex) Item(iv IView) FlexItem {
+
+	item, ok := f.items[iv]
+	if !ok {
+		return DefaultFlexItem()
+	}
+	return item
+}
+
+// SetGrow sets the flex grow factor of the specified child view
+func (f *Flex) SetGrow(iv IView, grow float32) {
+
+	item := f.Item(iv)
+	item.Grow = grow
+	f.items[iv] = item
+}
+
+// SetShrink sets the flex shrink factor of the specified child view
+func (f *Flex) SetShrink(iv IView, shrink float32) {
+
+	item := f.Item(iv)
+	item.Shrink = shrink
+	f.items[iv] = item
+}
+
+// SetBasis sets the flex basis of the specified child view
+func (f *Flex) SetBasis(iv IView, basis float32) {
+
+	item := f.Item(iv)
+	item.Basis = basis
+	f.items[iv] = item
+}
+
+// SetAlignSelf sets the alignment in the cross axis of the specified child view
+func (f *Flex) SetAlignSelf(iv IView, align Align) {
+
+	item := f.Item(iv)
+	item.AlignSelf = align
+	f.items[iv] = item
+}
+
+// Remove removes the specified child view and its flex properties.
+// Returns false if the view was not found.
+func (f *Flex) Remove(iv IView) bool {
+
+	delete(f.items, iv)
+	return f.View.Remove(iv)
+}
+
+// RemoveAll removes all the children and their flex properties
+func (f *Flex) RemoveAll() {
+
+	f.items = make(map[IView]FlexItem)
+	f.View.RemoveAll()
+}
+
+// Measure satisfies the IView interface
+func (f *Flex) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	vertical := f.vertical()
+	content := gb.Vec2{
+		util.Max(avail.X-f.padding.Horizontal(), 0),
+		util.Max(avail.Y-f.padding.Vertical(), 0),
+	}
+	for _, c := range f.children {
+		if !c.GetView().visible {
+			continue
+		}
+		item := f.Item(c)
+		cavail := content
+		if item.Basis >= 0 {
+			cavail = axisVec2(item.Basis, axisCross(content, vertical), vertical)
+		}
+		MeasureChild(w, c, cavail)
+	}
+
+	f.buildLines(axisMain(content, vertical))
+	var main, cross float32
+	for i := range f.lines {
+		if i > 0 {
+			cross += f.lineGap()
+		}
+		main = util.Max(main, f.lines[i].main)
+		cross += f.lines[i].cross
+	}
+	size := axisVec2(main, cross, vertical)
+	size.Add(f.padding.Size())
+	return f.ConstrainSize(size)
+}
+
+// Arrange satisfies the IView interface
+func (f *Flex) Arrange(w *window.Window, pos gb.Vec2, size gb.Vec2) {
+
+	f.View.Arrange(w, pos, size)
+	vertical := f.vertical()
+	reverse := f.direction == FlexRowReverse || f.direction == FlexColumnReverse
+	content := f.ContentRect()
+	contentSize := gb.Vec2Sub(content.Max, content.Min)
+	contentMain := axisMain(contentSize, vertical)
+	f.buildLines(contentMain)
+
+	// A single line without wrapping uses all the cross space of the container
+	if !f.wrap && len(f.lines) == 1 {
+		f.lines[0].cross = axisCross(contentSize, vertical)
+	}
+
+	crossPos := axisCross(content.Min, vertical)
+	for li := range f.lines {
+		line := &f.lines[li]
+		free := f.resolveSizes(line, contentMain)
+		start, between := f.justifySpace(free, len(line.entries))
+
+		cursor := start
+		for _, e := range line.entries {
+			cv := e.iv.GetView()
+			mstart := axisMain(gb.Vec2{cv.margin.Left, cv.margin.Top}, vertical)
+			mcross := axisCross(gb.Vec2{cv.margin.Left, cv.margin.Top}, vertical)
+			outer := e.size + axisMain(cv.margin.Size(), vertical)
+
+			// Main axis position relative to the content start
+			mainPos := cursor
+			if reverse {
+				mainPos = contentMain - cursor - outer
+			}
+			mainPos += axisMain(content.Min, vertical) + mstart
+
+			// Cross axis size and position
+			align := e.item.AlignSelf
+			if align == AlignAuto {
+				align = f.alignItems
+			}
+			space := line.cross - axisCross(cv.margin.Size(), vertical)
+			childCross := axisCross(cv.desired, vertical)
+			if align == AlignStretch && axisCross(cv.prefSize, vertical) <= 0 {
+				childCross = util.Max(space, 0)
+			}
+			cpos := crossPos + mcross + alignOffset(align, space, childCross)
+
+			ArrangeChild(w, e.iv, axisVec2(mainPos, cpos, vertical), axisVec2(e.size, childCross, vertical))
+			cursor += outer + f.itemGap() + between
+		}
+		crossPos += line.cross + f.lineGap()
+	}
+}
+
+// vertical returns if the main axis is vertical
+func (f *Flex) vertical() bool {
+
+	return f.direction == FlexColumn || f.direction == FlexColumnReverse
+}
+
+// itemGap returns the gap between children in the main axis
+func (f *Flex) itemGap() float32 {
+
+	if f.vertical() {
+		return f.rowGap
+	}
+	return f.columnGap
+}
+
+// lineGap returns the gap between lines in the cross axis
+func (f *Flex) lineGap() float32 {
+
+	if f.vertical() {
+		return f.columnGap
+	}
+	return f.rowGap
+}
+
+// buildLines collects the visible children in lines using their desired sizes
+// and wrapping, if enabled, at the specified main axis size.
+func (f *Flex) buildLines(contentMain float32) {
+
+	vertical := f.vertical()
+	gap := f.itemGap()
+	f.lines = f.lines[:0]
+	var line flexLine
+	for _, c := range f.children {
+		cv := c.GetView()
+		if !cv.visible {
+			continue
+		}
+		item := f.Item(c)
+		base := axisMain(cv.desired, vertical)
+		if item.Basis >= 0 {
+			base = item.Basis
+		}
+		base = axisMain(cv.ClampSize(axisVec2(base, axisCross(cv.desired, vertical), vertical)), vertical)
+		outer := base + axisMain(cv.margin.Size(), vertical)
+
+		// Starts a new line if the child does not fit in the current one
+		if len(line.entries) > 0 {
+			if f.wrap && line.main+gap+outer > contentMain {
+				f.lines = append(f.lines, line)
+				line = flexLine{}
+			} else {
+				line.main += gap
+			}
+		}
+		line.entries = append(line.entries, flexEntry{iv: c, item: item, base: base, size: base})
+		line.main += outer
+		line.cross = util.Max(line.cross, axisCross(cv.desired, vertical)+axisCross(cv.margin.Size(), vertical))
+	}
+	if len(line.entries) > 0 {
+		f.lines = append(f.lines, line)
+	}
+}
+
+// resolveSizes grows or shrinks the children of the line according to their flex factors
+// to fill the specified main axis size and returns the remaining free space.
+func (f *Flex) resolveSizes(line *flexLine, contentMain float32) float32 {
+
+	vertical := f.vertical()
+	free := contentMain - line.main
+	if free > 0 {
+		var total float32
+		for _, e := range line.entries {
+			total += e.item.Grow
+		}
+		if total > 0 {
+			for i := range line.entries {
+				e := &line.entries[i]
+				e.size = e.base + free*e.item.Grow/total
+			}
+		}
+	} else if free < 0 {
+		var total float32
+		for _, e := range line.entries {
+			total += e.item.Shrink * e.base
+		}
+		if total > 0 {
+			for i := range line.entries {
+				e := &line.entries[i]
+				e.size = util.Max(e.base+free*e.item.Shrink*e.base/total, 0)
+			}
+		}
+	}
+
+	// Clamps the resolved sizes and calculates the remaining free space
+	used := line.main
+	for i := range line.entries {
+		e := &line.entries[i]
+		cv := e.iv.GetView()
+		e.size = axisMain(cv.ClampSize(axisVec2(e.size, axisCross(cv.desired, vertical), vertical)), vertical)
+		used += e.size - e.base
+	}
+	return contentMain - used
+}
+
+// justifySpace returns the offset of the first child and the extra space
+// between children for the specified free space and number of children.
+func (f *Flex) justifySpace(free float32, count int) (start, between float32) {
+
+	if count == 0 {
+		return 0, 0
+	}
+	switch f.justify {
+	case JustifyEnd:
+		return free, 0
+	case JustifyCenter:
+		return free / 2, 0
+	case JustifySpaceBetween:
+		if free <= 0 || count < 2 {
+			return 0, 0
+		}
+		return 0, free / float32(count-1)
+	case JustifySpaceAround:
+		if free <= 0 {
+			return free / 2, 0
+		}
+		between = free / float32(count)
+		return between / 2, between
+	case JustifySpaceEvenly:
+		if free <= 0 {
+			return free / 2, 0
+		}
+		between = free / float32(count+1)
+		return between, between
+	default:
+		return 0, 0
+	}
+}
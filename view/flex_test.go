@@ -0,0 +1,154 @@
+package view
+
+import (
+	"math"
+	"testing"
+
+	"github.com/leonsal/gux/gb"
+)
+
+// newTestFlex returns a Flex with the specified direction and three 20x10 children
+func newTestFlex(direction FlexDirection) (*Flex, []IView) {
+
+	children := []IView{newTestView(20, 10), newTestView(20, 10), newTestView(20, 10)}
+	f := NewFlex(direction)
+	for _, c := range children {
+		f.Add(c)
+	}
+	return f, children
+}
+
+// checkFlexMain checks the positions and sizes of the children in the main axis after a layout
+func checkFlexMain(t *testing.T, name string, f *Flex, size gb.Vec2, pos, sizes []float32) {
+
+	t.Helper()
+	MeasureChild(nil, f, size)
+	ArrangeChild(nil, f, gb.Vec2{}, size)
+	for i, c := range f.Children() {
+		v := c.GetView()
+		p, s := axisMain(v.Pos(), f.vertical()), axisMain(v.Size(), f.vertical())
+		if math.Abs(float64(p-pos[i])) > 1e-4 || math.Abs(float64(s-sizes[i])) > 1e-4 {
+			t.Errorf("%s: child %d at %v size %v, want %v size %v", name, i, p, s, pos[i], sizes[i])
+		}
+	}
+}
+
+func TestFlexGrowShrink(t *testing.T) {
+
+	cases := []struct {
+		name    string
+		width   float32
+		grow    []float32
+		shrink  []float32
+		basis   []float32
+		pos     []float32
+		sizes   []float32
+		measure float32
+	}{
+		{"no grow", 100, nil, nil, nil, []float32{0, 20, 40}, []float32{20, 20, 20}, 60},
+		{"grow", 100, []float32{1, 3, 0}, nil, nil, []float32{0, 30, 80}, []float32{30, 50, 20}, 60},
+		{"shrink", 45, nil, nil, nil, []float32{0, 15, 30}, []float32{15, 15, 15}, 60},
+		{"no shrink", 45, nil, []float32{1, 1, 0}, nil, []float32{0, 12.5, 25}, []float32{12.5, 12.5, 20}, 60},
+		{"basis", 100, nil, nil, []float32{50, FlexBasisAuto, 0}, []float32{0, 50, 70}, []float32{50, 20, 0}, 70},
+		{"basis grow", 100, []float32{1, 1, 1}, nil, []float32{50, FlexBasisAuto, 0}, []float32{0, 60, 90}, []float32{60, 30, 10}, 70},
+	}
+	for _, c := range cases {
+		f, children := newTestFlex(FlexRow)
+		for i, iv := range children {
+			if c.grow != nil {
+				f.SetGrow(iv, c.grow[i])
+			}
+			if c.shrink != nil {
+				f.SetShrink(iv, c.shrink[i])
+			}
+			if c.basis != nil {
+				f.SetBasis(iv, c.basis[i])
+			}
+		}
+		if got := MeasureChild(nil, f, gb.Vec2{c.width, 100}); got.X != c.measure || got.Y != 10 {
+			t.Errorf("%s: desired size %v, want %v", c.name, got, gb.Vec2{c.measure, 10})
+		}
+		checkFlexMain(t, c.name, f, gb.Vec2{c.width, 10}, c.pos, c.sizes)
+	}
+}
+
+func TestFlexJustify(t *testing.T) {
+
+	third := float32(40) / 3
+	cases := []struct {
+		justify Justify
+		pos     []float32
+	}{
+		{JustifyStart, []float32{0, 20, 40}},
+		{JustifyEnd, []float32{40, 60, 80}},
+		{JustifyCenter, []float32{20, 40, 60}},
+		{JustifySpaceBetween, []float32{0, 40, 80}},
+		{JustifySpaceAround, []float32{third / 2, third*1.5 + 20, third*2.5 + 40}},
+		{JustifySpaceEvenly, []float32{10, 40, 70}},
+	}
+	for _, c := range cases {
+		f, _ := newTestFlex(FlexRow)
+		f.SetJustify(c.justify)
+		checkFlexMain(t, "justify", f, gb.Vec2{100, 10}, c.pos, []float32{20, 20, 20})
+	}
+}
+
+func TestFlexDirection(t *testing.T) {
+
+	cases := []struct {
+		direction FlexDirection
+		size      gb.Vec2
+		pos       []float32
+		sizes     []float32
+	}{
+		{FlexRow, gb.Vec2{100, 10}, []float32{0, 25, 50}, []float32{20, 20, 20}},
+		{FlexRowReverse, gb.Vec2{100, 10}, []float32{80, 55, 30}, []float32{20, 20, 20}},
+		{FlexColumn, gb.Vec2{20, 100}, []float32{0, 15, 30}, []float32{10, 10, 10}},
+		{FlexColumnReverse, gb.Vec2{20, 100}, []float32{90, 75, 60}, []float32{10, 10, 10}},
+	}
+	for _, c := range cases {
+		f, _ := newTestFlex(c.direction)
+		f.SetGap(5, 5)
+		checkFlexMain(t, "direction", f, c.size, c.pos, c.sizes)
+	}
+}
+
+func TestFlexWrap(t *testing.T) {
+
+	f, children := newTestFlex(FlexRow)
+	f.SetWrap(true)
+	f.SetGap(4, 5)
+	checkLayout(t, "wrap", f, gb.Vec2{50, 100}, gb.Vec2{45, 24}, gb.Vec2{50, 24}, []layoutCase{
+		{children[0], gb.Vec2{0, 0}, gb.Vec2{20, 10}},
+		{children[1], gb.Vec2{25, 0}, gb.Vec2{20, 10}},
+		{children[2], gb.Vec2{0, 14}, gb.Vec2{20, 10}},
+	})
+
+	// Without wrapping the children shrink to fit in one line
+	f.SetWrap(false)
+	checkLayout(t, "no wrap", f, gb.Vec2{55, 100}, gb.Vec2{70, 10}, gb.Vec2{55, 10}, []layoutCase{
+		{children[0], gb.Vec2{0, 0}, gb.Vec2{15, 10}},
+		{children[1], gb.Vec2{20, 0}, gb.Vec2{15, 10}},
+		{children[2], gb.Vec2{40, 0}, gb.Vec2{15, 10}},
+	})
+}
+
+func TestFlexAlign(t *testing.T) {
+
+	f, children := newTestFlex(FlexRow)
+	children[2].GetView().SetPrefSize(0, 6)
+	checkLayout(t, "stretch", f, gb.Vec2{100, 30}, gb.Vec2{60, 10}, gb.Vec2{100, 30}, []layoutCase{
+		{children[0], gb.Vec2{0, 0}, gb.Vec2{20, 30}},
+		{children[1], gb.Vec2{20, 0}, gb.Vec2{20, 30}},
+		{children[2], gb.Vec2{40, 0}, gb.Vec2{20, 6}},
+	})
+
+	f.SetAlignItems(AlignCenter)
+	f.SetAlignSelf(children[0], AlignEnd)
+	f.SetAlignSelf(children[1], AlignStretch)
+	checkLayout(t, "align self", f, gb.Vec2{100, 30}, gb.Vec2{60, 10}, gb.Vec2{100, 30}, []layoutCase{
+		{children[0], gb.Vec2{0, 20}, gb.Vec2{20, 10}},
+		{children[1], gb.Vec2{20, 0}, gb.Vec2{20, 30}},
+		{children[2], gb.Vec2{40, 12}, gb.Vec2{20, 6}},
+	})
+}
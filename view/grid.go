@@ -0,0 +1,164 @@
+package view
+
+import (
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// Grid is a container which arranges its children in cells of a grid with a fixed
+// number of columns, filling rows from left to right and top to bottom.
+// Each column width is the largest width of its children and each row height
+// is the largest height of its children.
+type Grid struct {
+	Group
+	columns   int       // Number of columns
+	spacing   gb.Vec2   // Horizontal and vertical spacing between cells
+	halign    Align     // Horizontal alignment of children in their cells
+	valign    Align     // Vertical alignment of children in their cells
+	colWidth  []float32 // Width of each column calculated by the last measure pass
+	rowHeight []float32 // Height of each row calculated by the last measure pass
+}
+
+// NewGrid creates and returns a new empty Grid with the specified number of columns
+func NewGrid(columns int) *Grid {
+
+	g := new(Grid)
+	g.Init(g)
+	g.columns = util.Max(columns, 1)
+	g.halign = AlignStretch
+	g.valign = AlignStretch
+	return g
+}
+
+// Add appends the specified child views and returns this Grid
+func (g *Grid) Add(children ...IView) *Grid {
+
+	g.View.Add(children...)
+	return g
+}
+
+// SetColumns sets the number of columns of the grid
+func (g *Grid) SetColumns(columns int) {
+
+	g.columns = util.Max(columns, 1)
+}
+
+// Columns returns the number of columns of the grid
+func (g *Grid) Columns() int {
+
+	return g.columns
+}
+
+// SetSpacing sets the horizontal and vertical spacing between cells
+func (g *Grid) SetSpacing(x, y float32) {
+
+	g.spacing = gb.Vec2{x, y}
+}
+
+// Spacing returns the horizontal and vertical spacing between cells
+func (g *Grid) Spacing() gb.Vec2 {
+
+	return g.spacing
+}
+
+// SetAlign sets the horizontal and vertical alignment of the children in their cells.
+// The default is AlignStretch for both.
+func (g *Grid) SetAlign(halign, valign Align) {
+
+	g.halign = halign
+	g.valign = valign
+}
+
+// Measure satisfies the IView interface
+func (g *Grid) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	content := gb.Vec2{
+		util.Max(avail.X-g.padding.Horizontal(), 0),
+		util.Max(avail.Y-g.padding.Vertical(), 0),
+	}
+	g.colWidth = g.colWidth[:0]
+	g.rowHeight = g.rowHeight[:0]
+	for i := 0; i < g.columns; i++ {
+		g.colWidth = append(g.colWidth, 0)
+	}
+	cell := 0
+	for _, c := range g.children {
+		cv := c.GetView()
+		if !cv.visible {
+			continue
+		}
+		col := cell % g.columns
+		row := cell / g.columns
+		if row >= len(g.rowHeight) {
+			g.rowHeight = append(g.rowHeight, 0)
+		}
+		d := MeasureChild(w, c, content)
+		g.colWidth[col] = util.Max(g.colWidth[col], d.X+cv.margin.Horizontal())
+		g.rowHeight[row] = util.Max(g.rowHeight[row], d.Y+cv.margin.Vertical())
+		cell++
+	}
+	var size gb.Vec2
+	for i, cw := range g.colWidth {
+		if i > 0 {
+			size.X += g.spacing.X
+		}
+		size.X += cw
+	}
+	for i, rh := range g.rowHeight {
+		if i > 0 {
+			size.Y += g.spacing.Y
+		}
+		size.Y += rh
+	}
+	size.Add(g.padding.Size())
+	return g.ConstrainSize(size)
+}
+
+// Arrange satisfies the IView interface
+func (g *Grid) Arrange(w *window.Window, pos gb.Vec2, size gb.Vec2) {
+
+	g.View.Arrange(w, pos, size)
+
+	// Measures again if the columns or the visible children changed since the last measure
+	visible := 0
+	for _, c := range g.children {
+		if c.GetView().visible {
+			visible++
+		}
+	}
+	if len(g.colWidth) != g.columns || len(g.rowHeight) != (visible+g.columns-1)/g.columns {
+		g.Measure(w, size)
+	}
+
+	content := g.ContentRect()
+	cell := 0
+	cellPos := content.Min
+	for _, c := range g.children {
+		cv := c.GetView()
+		if !cv.visible {
+			continue
+		}
+		col := cell % g.columns
+		row := cell / g.columns
+		if col == 0 && row > 0 {
+			cellPos.X = content.Min.X
+			cellPos.Y += g.rowHeight[row-1] + g.spacing.Y
+		}
+		space := gb.Vec2{g.colWidth[col] - cv.margin.Horizontal(), g.rowHeight[row] - cv.margin.Vertical()}
+		csize := cv.desired
+		if g.halign == AlignStretch {
+			csize.X = space.X
+		}
+		if g.valign == AlignStretch {
+			csize.Y = space.Y
+		}
+		cpos := gb.Vec2{
+			cellPos.X + cv.margin.Left + alignOffset(g.halign, space.X, csize.X),
+			cellPos.Y + cv.margin.Top + alignOffset(g.valign, space.Y, csize.Y),
+		}
+		ArrangeChild(w, c, cpos, csize)
+		cellPos.X += g.colWidth[col] + g.spacing.X
+		cell++
+	}
+}
@@ -1,8 +1,13 @@
 package view
 
 //
-import "github.com/leonsal/gux/window"
+import (
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
 
+// Group is a container which keeps its children at their own positions
 type Group struct {
 	View
 }
@@ -14,6 +19,46 @@ func NewGroup() *Group {
 	return g
 }
 
+// Add appends the specified child views and returns this Group
+func (g *Group) Add(children ...IView) *Group {
+
+	g.View.Add(children...)
+	return g
+}
+
+// Measure satisfies the IView interface.
+// The desired size of the Group is the bounding box of its children.
+func (g *Group) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	var size gb.Vec2
+	for _, c := range g.children {
+		cv := c.GetView()
+		if !cv.visible {
+			continue
+		}
+		d := MeasureChild(w, c, avail)
+		size.X = util.Max(size.X, cv.pos.X+d.X+cv.margin.Right)
+		size.Y = util.Max(size.Y, cv.pos.Y+d.Y+cv.margin.Bottom)
+	}
+	size.X += g.padding.Right
+	size.Y += g.padding.Bottom
+	return g.ConstrainSize(size)
+}
+
+// Arrange satisfies the IView interface.
+// The children are arranged at their current positions with their desired sizes.
+func (g *Group) Arrange(w *window.Window, pos gb.Vec2, size gb.Vec2) {
+
+	g.View.Arrange(w, pos, size)
+	for _, c := range g.children {
+		cv := c.GetView()
+		if !cv.visible {
+			continue
+		}
+		ArrangeChild(w, c, cv.pos, cv.desired)
+	}
+}
+
 func (g *Group) Render(w *window.Window) {
 
 	g.RenderChildren(w)
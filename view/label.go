@@ -1,6 +1,9 @@
 package view
 
 import (
+	"strings"
+
+	"github.com/leonsal/gux/gb"
 	"github.com/leonsal/gux/window"
 )
 
@@ -33,12 +36,25 @@ func (l *Label) Text() string {
 	return l.text
 }
 
+// Measure satisfies the IView interface.
+// The desired size of the Label is the size of its text plus its padding.
+func (l *Label) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	fa := w.Font(l.ff, 0)
+	lines := strings.Count(l.text, "\n") + 1
+	size := gb.Vec2{fa.MeasureString(l.text), float32(lines) * fa.Height()}
+	size.Add(l.padding.Size())
+	return l.ConstrainSize(size)
+}
+
 func (l *Label) Render(w *window.Window) {
 
 	if !l.visible {
 		return
 	}
+	dl := l.BeginRender()
 	color := l.StyleColor(w, StyleColorText).RGBA()
-	pos := l.pos
-	w.AddText(w.DrawList(), w.Font(l.ff, 0), &pos, color, l.valign, l.text)
+	pos := gb.Vec2{l.padding.Left, l.padding.Top}
+	w.AddText(dl, w.Font(l.ff, 0), &pos, color, l.valign, l.text)
+	l.EndRender(w)
 }
@@ -0,0 +1,248 @@
+package view
+
+import (
+	"math"
+
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// Layout is done in two passes starting from the top view of a window:
+//
+//   - Measure: each container asks its children for their desired sizes for the available size
+//     and calculates its own desired size. Desired sizes include the view padding but not its margins.
+//   - Arrange: each container sets the final position and size of its children inside its content area.
+
+// Unlimited is the available size used for axes without limits when measuring views
+var Unlimited = float32(math.Inf(1))
+
+// Insets specifies the space at each side of a rectangle
+type Insets struct {
+	Top    float32
+	Right  float32
+	Bottom float32
+	Left   float32
+}
+
+// InsetsAll returns Insets with the same value for all sides
+func InsetsAll(v float32) Insets {
+
+	return Insets{v, v, v, v}
+}
+
+// InsetsXY returns Insets with the specified horizontal and vertical values
+func InsetsXY(x, y float32) Insets {
+
+	return Insets{Top: y, Right: x, Bottom: y, Left: x}
+}
+
+// Horizontal returns the sum of the left and right insets
+func (in Insets) Horizontal() float32 {
+
+	return in.Left + in.Right
+}
+
+// Vertical returns the sum of the top and bottom insets
+func (in Insets) Vertical() float32 {
+
+	return in.Top + in.Bottom
+}
+
+// Size returns the total horizontal and vertical insets
+func (in Insets) Size() gb.Vec2 {
+
+	return gb.Vec2{in.Horizontal(), in.Vertical()}
+}
+
+// Align specifies how a view is aligned inside the space given by its container
+type Align int
+
+const (
+	AlignStart   Align = iota // Aligns to the start (left or top) of the space
+	AlignCenter               // Centers in the space
+	AlignEnd                  // Aligns to the end (right or bottom) of the space
+	AlignStretch              // Stretches to fill the space
+)
+
+// AlignAuto specifies that the alignment of a child view is inherited from its container
+const AlignAuto Align = -1
+
+// Orientation specifies the main direction of views such as sliders and splitters
+type Orientation int
+
+const (
+	Horizontal Orientation = iota // Left to right
+	Vertical                      // Top to bottom
+)
+
+// SetPrefSize sets the preferred size of the view which overrides its
+// measured content size. Zero components are not used.
+func (v *View) SetPrefSize(width, height float32) {
+
+	v.prefSize = gb.Vec2{width, height}
+}
+
+// PrefSize returns the preferred size of the view
+func (v *View) PrefSize() gb.Vec2 {
+
+	return v.prefSize
+}
+
+// SetMinSize sets the minimum size of the view
+func (v *View) SetMinSize(width, height float32) {
+
+	v.minSize = gb.Vec2{width, height}
+}
+
+// MinSize returns the minimum size of the view
+func (v *View) MinSize() gb.Vec2 {
+
+	return v.minSize
+}
+
+// SetMaxSize sets the maximum size of the view. Zero components are not used.
+func (v *View) SetMaxSize(width, height float32) {
+
+	v.maxSize = gb.Vec2{width, height}
+}
+
+// MaxSize returns the maximum size of the view
+func (v *View) MaxSize() gb.Vec2 {
+
+	return v.maxSize
+}
+
+// SetMargin sets the space around the view used by its container
+func (v *View) SetMargin(m Insets) {
+
+	v.margin = m
+}
+
+// Margin returns the current view margin
+func (v *View) Margin() Insets {
+
+	return v.margin
+}
+
+// SetPadding sets the space between the view bounds and its content
+func (v *View) SetPadding(p Insets) {
+
+	v.padding = p
+}
+
+// Padding returns the current view padding
+func (v *View) Padding() Insets {
+
+	return v.padding
+}
+
+// DesiredSize returns the desired size of the view calculated by the last measure pass
+func (v *View) DesiredSize() gb.Vec2 {
+
+	return v.desired
+}
+
+// ContentRect returns the rectangle inside the view padding in local coordinates
+func (v *View) ContentRect() gb.Rect {
+
+	return gb.Rect{
+		Min: gb.Vec2{v.padding.Left, v.padding.Top},
+		Max: gb.Vec2{v.size.X - v.padding.Right, v.size.Y - v.padding.Bottom},
+	}
+}
+
+// ConstrainSize returns the specified size replaced by the view preferred size
+// components, if set, and clamped to the view minimum and maximum sizes.
+func (v *View) ConstrainSize(size gb.Vec2) gb.Vec2 {
+
+	if v.prefSize.X > 0 {
+		size.X = v.prefSize.X
+	}
+	if v.prefSize.Y > 0 {
+		size.Y = v.prefSize.Y
+	}
+	return v.ClampSize(size)
+}
+
+// ClampSize returns the specified size clamped to the view minimum and maximum sizes
+func (v *View) ClampSize(size gb.Vec2) gb.Vec2 {
+
+	if v.maxSize.X > 0 {
+		size.X = util.Min(size.X, v.maxSize.X)
+	}
+	if v.maxSize.Y > 0 {
+		size.Y = util.Min(size.Y, v.maxSize.Y)
+	}
+	size.X = util.Max(size.X, v.minSize.X)
+	size.Y = util.Max(size.Y, v.minSize.Y)
+	return size
+}
+
+// Measure satisfies the IView interface.
+// The default implementation returns the view padding size constrained by the view sizes.
+func (v *View) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	return v.ConstrainSize(v.padding.Size())
+}
+
+// Arrange satisfies the IView interface.
+// The default implementation sets the view position and size.
+func (v *View) Arrange(w *window.Window, pos gb.Vec2, size gb.Vec2) {
+
+	v.pos = pos
+	v.size = size
+}
+
+// MeasureChild measures the specified view for the available size, not including its margins,
+// saving and returning its desired size.
+// Containers should use this function to measure their children.
+func MeasureChild(w *window.Window, iv IView, avail gb.Vec2) gb.Vec2 {
+
+	v := iv.GetView()
+	avail.X = util.Max(avail.X-v.margin.Horizontal(), 0)
+	avail.Y = util.Max(avail.Y-v.margin.Vertical(), 0)
+	v.desired = iv.Measure(w, avail)
+	return v.desired
+}
+
+// ArrangeChild arranges the specified view at the specified position and size
+// after clamping the size to the view minimum and maximum sizes.
+// The position and size must not include the view margins.
+// Containers should use this function to arrange their children.
+func ArrangeChild(w *window.Window, iv IView, pos gb.Vec2, size gb.Vec2) {
+
+	iv.Arrange(w, pos, iv.GetView().ClampSize(size))
+}
+
+// Layout measures and arranges the specified top view and its children to fill the window
+// and the window overlays at their positions.
+func Layout(w *window.Window, iv IView) {
+
+	v := iv.GetView()
+	wsize := w.Size()
+	MeasureChild(w, iv, wsize)
+	pos := gb.Vec2{v.margin.Left, v.margin.Top}
+	size := gb.Vec2{wsize.X - v.margin.Horizontal(), wsize.Y - v.margin.Vertical()}
+	ArrangeChild(w, iv, pos, size)
+
+	// Sets the top view transform
+	var ident gb.Mat3
+	ident.Identity()
+	iv.SetTransform(&ident)
+	layoutOverlays(w)
+}
+
+// alignOffset returns the offset of an item with the specified size
+// inside the specified space for the specified alignment.
+func alignOffset(align Align, space, size float32) float32 {
+
+	switch align {
+	case AlignCenter:
+		return (space - size) / 2
+	case AlignEnd:
+		return space - size
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,199 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/window"
+)
+
+// testView is a leaf view with a fixed content size used to test the layout of containers
+type testView struct {
+	View
+	content gb.Vec2 // Measured size before constraints
+}
+
+// newTestView creates and returns a new testView with the specified measured size
+func newTestView(width, height float32) *testView {
+
+	v := &testView{content: gb.Vec2{width, height}}
+	v.Init(v)
+	return v
+}
+
+// Measure satisfies the IView interface
+func (v *testView) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	return v.ConstrainSize(v.content)
+}
+
+// Render satisfies the IView interface
+func (v *testView) Render(w *window.Window) {}
+
+// layoutCase contains the expected position and size of a view after a layout
+type layoutCase struct {
+	iv   IView
+	pos  gb.Vec2
+	size gb.Vec2
+}
+
+// checkLayout measures and arranges the specified container with the specified size and
+// checks the container desired size and the positions and sizes of the specified views
+func checkLayout(t *testing.T, name string, iv IView, avail, desired, size gb.Vec2, cases []layoutCase) {
+
+	t.Helper()
+	if got := MeasureChild(nil, iv, avail); got != desired {
+		t.Errorf("%s: desired size %v, want %v", name, got, desired)
+	}
+	ArrangeChild(nil, iv, gb.Vec2{}, size)
+	for i, c := range cases {
+		v := c.iv.GetView()
+		if v.Pos() != c.pos || v.Size() != c.size {
+			t.Errorf("%s: child %d at %v size %v, want %v size %v", name, i, v.Pos(), v.Size(), c.pos, c.size)
+		}
+	}
+}
+
+func TestHBoxLayout(t *testing.T) {
+
+	avail := gb.Vec2{1000, 1000}
+	cases := []struct {
+		align Align
+		a, b  gb.Vec2 // Expected positions of the children
+		as    gb.Vec2 // Expected sizes of the children
+		bs    gb.Vec2
+	}{
+		{AlignStretch, gb.Vec2{5, 5}, gb.Vec2{18, 6}, gb.Vec2{10, 30}, gb.Vec2{30, 28}},
+		{AlignStart, gb.Vec2{5, 5}, gb.Vec2{18, 6}, gb.Vec2{10, 20}, gb.Vec2{30, 10}},
+		{AlignCenter, gb.Vec2{5, 10}, gb.Vec2{18, 15}, gb.Vec2{10, 20}, gb.Vec2{30, 10}},
+		{AlignEnd, gb.Vec2{5, 15}, gb.Vec2{18, 24}, gb.Vec2{10, 20}, gb.Vec2{30, 10}},
+	}
+	for _, c := range cases {
+		a := newTestView(10, 20)
+		b := newTestView(30, 10)
+		b.SetMargin(InsetsAll(1))
+		hidden := newTestView(100, 100)
+		hidden.SetVisible(false)
+		box := NewHBox()
+		box.Add(a)
+		box.Add(hidden)
+		box.Add(b)
+		box.SetPadding(InsetsAll(5))
+		box.SetSpacing(2)
+		box.SetAlign(c.align)
+		checkLayout(t, "HBox", box, avail, gb.Vec2{54, 30}, gb.Vec2{100, 40}, []layoutCase{
+			{a, c.a, c.as},
+			{b, c.b, c.bs},
+		})
+	}
+}
+
+func TestVBoxLayout(t *testing.T) {
+
+	a := newTestView(10, 20)
+	b := newTestView(30, 10)
+	b.SetMaxSize(20, 0)
+	c := newTestView(5, 5)
+	c.SetPrefSize(8, 6)
+	box := NewVBox()
+	box.Add(a)
+	box.Add(b)
+	box.Add(c)
+	box.SetSpacing(4)
+	checkLayout(t, "VBox", box, gb.Vec2{1000, 1000}, gb.Vec2{20, 44}, gb.Vec2{50, 60}, []layoutCase{
+		{a, gb.Vec2{0, 0}, gb.Vec2{50, 20}},
+		{b, gb.Vec2{0, 24}, gb.Vec2{20, 10}},
+		{c, gb.Vec2{0, 38}, gb.Vec2{50, 6}},
+	})
+}
+
+func TestStackLayout(t *testing.T) {
+
+	a := newTestView(10, 20)
+	b := newTestView(30, 10)
+	b.SetMargin(InsetsXY(2, 1))
+	stack := NewStack()
+	stack.Add(a)
+	stack.Add(b)
+	stack.SetPadding(InsetsAll(3))
+	checkLayout(t, "Stack", stack, gb.Vec2{1000, 1000}, gb.Vec2{40, 26}, gb.Vec2{40, 26}, []layoutCase{
+		{a, gb.Vec2{3, 3}, gb.Vec2{34, 20}},
+		{b, gb.Vec2{5, 4}, gb.Vec2{30, 18}},
+	})
+	stack.SetAlign(AlignCenter, AlignEnd)
+	checkLayout(t, "Stack", stack, gb.Vec2{1000, 1000}, gb.Vec2{40, 26}, gb.Vec2{40, 26}, []layoutCase{
+		{a, gb.Vec2{15, 3}, gb.Vec2{10, 20}},
+		{b, gb.Vec2{5, 12}, gb.Vec2{30, 10}},
+	})
+}
+
+func TestGridLayout(t *testing.T) {
+
+	sizes := []gb.Vec2{{10, 5}, {20, 8}, {15, 12}, {5, 3}, {7, 7}}
+	cases := []struct {
+		halign, valign Align
+		want           []layoutCase
+	}{
+		{AlignStretch, AlignStretch, []layoutCase{
+			{nil, gb.Vec2{0, 0}, gb.Vec2{15, 8}},
+			{nil, gb.Vec2{17, 0}, gb.Vec2{20, 8}},
+			{nil, gb.Vec2{0, 11}, gb.Vec2{15, 12}},
+			{nil, gb.Vec2{17, 11}, gb.Vec2{20, 12}},
+			{nil, gb.Vec2{0, 26}, gb.Vec2{15, 7}},
+		}},
+		{AlignCenter, AlignCenter, []layoutCase{
+			{nil, gb.Vec2{2.5, 1.5}, gb.Vec2{10, 5}},
+			{nil, gb.Vec2{17, 0}, gb.Vec2{20, 8}},
+			{nil, gb.Vec2{0, 11}, gb.Vec2{15, 12}},
+			{nil, gb.Vec2{24.5, 15.5}, gb.Vec2{5, 3}},
+			{nil, gb.Vec2{4, 26}, gb.Vec2{7, 7}},
+		}},
+		{AlignEnd, AlignStart, []layoutCase{
+			{nil, gb.Vec2{5, 0}, gb.Vec2{10, 5}},
+			{nil, gb.Vec2{17, 0}, gb.Vec2{20, 8}},
+			{nil, gb.Vec2{0, 11}, gb.Vec2{15, 12}},
+			{nil, gb.Vec2{32, 11}, gb.Vec2{5, 3}},
+			{nil, gb.Vec2{8, 26}, gb.Vec2{7, 7}},
+		}},
+	}
+	for _, c := range cases {
+		grid := NewGrid(2)
+		grid.SetSpacing(2, 3)
+		grid.SetAlign(c.halign, c.valign)
+		for i, size := range sizes {
+			child := newTestView(size.X, size.Y)
+			grid.Add(child)
+			c.want[i].iv = child
+		}
+		checkLayout(t, "Grid", grid, gb.Vec2{1000, 1000}, gb.Vec2{37, 33}, gb.Vec2{37, 33}, c.want)
+	}
+
+	// Padding and margins
+	a := newTestView(10, 10)
+	b := newTestView(10, 10)
+	b.SetMargin(InsetsAll(2))
+	grid := NewGrid(3)
+	grid.Add(a)
+	grid.Add(b)
+	grid.SetPadding(InsetsAll(4))
+	checkLayout(t, "Grid", grid, gb.Vec2{1000, 1000}, gb.Vec2{32, 22}, gb.Vec2{32, 22}, []layoutCase{
+		{a, gb.Vec2{4, 4}, gb.Vec2{10, 14}},
+		{b, gb.Vec2{16, 6}, gb.Vec2{10, 10}},
+	})
+}
+
+func TestGridArrangeWithoutMeasure(t *testing.T) {
+
+	a := newTestView(10, 10)
+	b := newTestView(20, 5)
+	grid := NewGrid(2).Add(a)
+	MeasureChild(nil, grid, gb.Vec2{1000, 1000})
+
+	// Children and columns changed after the measure
+	grid.Add(b)
+	grid.SetColumns(1)
+	ArrangeChild(nil, grid, gb.Vec2{}, gb.Vec2{20, 15})
+	if a.Pos() != (gb.Vec2{0, 0}) || b.Pos() != (gb.Vec2{0, 10}) || b.Size() != (gb.Vec2{20, 5}) {
+		t.Errorf("got a at %v and b at %v size %v", a.Pos(), b.Pos(), b.Size())
+	}
+}
@@ -0,0 +1,779 @@
+package view
+
+import (
+	"math"
+	"sort"
+
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// Number of items used for the desired height of a ListView
+const listDefaultRows = 10
+
+// Horizontal padding of list items and vertical padding of their default height
+const listItemPadding = 4
+
+// ListDataSource supplies the items of a ListView.
+// Items are not views: they are drawn directly by the data source
+// and only the items inside the visible scroll range are drawn.
+type ListDataSource interface {
+	Len() int                                    // Returns the number of items
+	RenderItem(w *window.Window, item *ListItem) // Draws the item in its rectangle
+}
+
+// ListItemHeights can be implemented by a ListDataSource with items of different heights.
+// Otherwise all items have the list fixed item height.
+type ListItemHeights interface {
+	ItemHeight(index int) float32 // Returns the height of the item
+}
+
+// ListItem describes an item of a ListView being drawn
+type ListItem struct {
+	List     *ListView    // List which contains the item
+	DL       *gb.DrawList // Draw list where the item must be drawn
+	Index    int          // Item index
+	Rect     gb.Rect      // Item rectangle in the draw list coordinates
+	Selected bool         // Item is selected
+	Hovered  bool         // Item is under the cursor
+	Current  bool         // Item is the current item for keyboard navigation
+}
+
+// SelectionMode specifies how the items of lists, trees and tables can be selected
+type SelectionMode int
+
+const (
+	SelectNone   SelectionMode = iota // Items cannot be selected
+	SelectSingle                      // At most one item is selected
+	SelectMulti                       // Any number of items can be selected with Control and Shift
+)
+
+// ListView is a vertical list of items supplied by a ListDataSource which is efficient for
+// very large data sets: its cost depends only on the number of visible items (for variable
+// item heights their cumulative offsets are also kept).
+// Items are selected by clicking, with Control toggling and Shift extending the selection in
+// multi selection mode, and by the keyboard: Up/Down, PageUp/PageDown and Home/End move the
+// current item (Shift extends the selection and Control keeps it), Space selects and
+// Control+Space toggles it, Control+A selects all and Enter activates the current item.
+type ListView struct {
+	ScrollView
+	list       *listContent         // Content view which draws the visible items
+	src        ListDataSource       // Data source
+	itemHeight float32              // Fixed item height (0 uses the font height)
+	autoHeight float32              // Item height from the font height of the last measure
+	offsets    []float32            // Cumulative item offsets for variable heights (len is items+1)
+	dirty      bool                 // Cumulative offsets must be rebuilt
+	mode       SelectionMode        // Selection mode
+	ranges     []listRange          // Selected item ranges, sorted and not adjacent
+	current    int                  // Current item index (-1 if none)
+	anchor     int                  // Item where range selections start
+	hovered    int                  // Item under the cursor (-1 if none)
+	onSelect   func(*ListView)      // Selection change callback
+	onActivate func(*ListView, int) // Item activation callback
+}
+
+// listRange is a range of selected list items
+type listRange struct {
+	first int // First item index
+	last  int // Last item index
+}
+
+// listContent is the content view of a ListView sized to all items, which draws only the visible ones
+type listContent struct {
+	View
+	lv *ListView
+}
+
+// NewListView creates and returns a new ListView with the specified data source, which can be nil
+func NewListView(src ListDataSource) *ListView {
+
+	lv := new(ListView)
+	lv.initListView(lv, src)
+	return lv
+}
+
+// initListView initializes the ListView base of the specified IView
+func (lv *ListView) initListView(iv IView, src ListDataSource) {
+
+	lv.list = &listContent{lv: lv}
+	lv.list.Init(lv.list)
+	lv.initScrollView(iv, lv.list)
+	lv.focusable = true
+	lv.policy = [2]ScrollPolicy{ScrollDisabled, ScrollAuto}
+	lv.mode = SelectSingle
+	lv.current = -1
+	lv.hovered = -1
+	lv.src = src
+	lv.dirty = true
+}
+
+// SetDataSource sets the data source, clearing the selection and scrolling to the top
+func (lv *ListView) SetDataSource(src ListDataSource) {
+
+	lv.src = src
+	lv.dirty = true
+	lv.current = -1
+	lv.anchor = 0
+	lv.hovered = -1
+	lv.offset = gb.Vec2{}
+	lv.ClearSelection()
+}
+
+// DataSource returns the data source
+func (lv *ListView) DataSource() ListDataSource {
+
+	return lv.src
+}
+
+// SetItemHeight sets the fixed height of the items.
+// The default (0) uses the height of the regular font plus padding.
+func (lv *ListView) SetItemHeight(height float32) {
+
+	lv.itemHeight = height
+	lv.dirty = true
+}
+
+// ItemHeight returns the fixed height of the items set by SetItemHeight()
+func (lv *ListView) ItemHeight() float32 {
+
+	return lv.itemHeight
+}
+
+// SetSelectionMode sets the selection mode, clearing the selection. The default is SelectSingle.
+func (lv *ListView) SetSelectionMode(mode SelectionMode) {
+
+	lv.mode = mode
+	lv.ClearSelection()
+}
+
+// SelectionMode returns the selection mode
+func (lv *ListView) SelectionMode() SelectionMode {
+
+	return lv.mode
+}
+
+// Select selects only the item with the specified index, making it the current item
+func (lv *ListView) Select(index int) {
+
+	if index < 0 || index >= lv.count() {
+		return
+	}
+	lv.current = index
+	lv.anchor = index
+	lv.selectRange(index, index, false)
+}
+
+// SetSelected sets the selection state of the item with the specified index.
+// In single selection mode selecting an item unselects the others.
+func (lv *ListView) SetSelected(index int, selected bool) {
+
+	if index < 0 || index >= lv.count() || lv.mode == SelectNone || lv.Selected(index) == selected {
+		return
+	}
+	if selected && lv.mode == SelectSingle {
+		lv.ranges = nil
+	}
+	if selected {
+		lv.addRange(index, index)
+	} else {
+		lv.removeRange(index, index)
+	}
+	lv.selectionChanged()
+}
+
+// Selected returns if the item with the specified index is selected
+func (lv *ListView) Selected(index int) bool {
+
+	i := sort.Search(len(lv.ranges), func(i int) bool { return lv.ranges[i].last >= index })
+	return i < len(lv.ranges) && lv.ranges[i].first <= index
+}
+
+// SelectedIndex returns the index of the first selected item or -1 if none
+func (lv *ListView) SelectedIndex() int {
+
+	if len(lv.ranges) == 0 {
+		return -1
+	}
+	return lv.ranges[0].first
+}
+
+// SelectedIndices returns the sorted indices of the selected items
+func (lv *ListView) SelectedIndices() []int {
+
+	var indices []int
+	for _, r := range lv.ranges {
+		for i := r.first; i <= r.last; i++ {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// SelectAll selects all items in multi selection mode
+func (lv *ListView) SelectAll() {
+
+	if lv.mode != SelectMulti || lv.count() == 0 {
+		return
+	}
+	lv.selectRange(0, lv.count()-1, false)
+}
+
+// ClearSelection unselects all items
+func (lv *ListView) ClearSelection() {
+
+	if len(lv.ranges) == 0 {
+		return
+	}
+	lv.ranges = nil
+	lv.selectionChanged()
+}
+
+// SetCurrentIndex sets the current item for keyboard navigation and scrolls to show it
+func (lv *ListView) SetCurrentIndex(index int) {
+
+	if index < 0 || index >= lv.count() {
+		return
+	}
+	lv.current = index
+	lv.ScrollToIndex(index)
+}
+
+// CurrentIndex returns the index of the current item or -1 if none
+func (lv *ListView) CurrentIndex() int {
+
+	return lv.current
+}
+
+// ScrollToIndex scrolls the minimum necessary to show the item with the specified index
+func (lv *ListView) ScrollToIndex(index int) {
+
+	if index < 0 || index >= lv.count() || lv.rowHeight() == 0 {
+		return
+	}
+	lv.updateOffsets()
+	lv.EnsureVisible(gb.Rect{Min: gb.Vec2{0, lv.itemTop(index)}, Max: gb.Vec2{0, lv.itemTop(index + 1)}})
+}
+
+// ItemAt returns the index of the item at the specified point in local coordinates or -1 if none
+func (lv *ListView) ItemAt(pos gb.Vec2) int {
+
+	if !lv.viewport.Contains(pos) {
+		return -1
+	}
+	y := pos.Y - lv.viewport.Min.Y + lv.offset.Y
+	lv.updateOffsets()
+	if y >= lv.itemTop(lv.count()) {
+		return -1
+	}
+	return lv.indexAtY(y)
+}
+
+// DataChanged must be called when the items of the data source changed in ways other than
+// ItemsInserted() and ItemsRemoved(). The first visible item is kept at the same position.
+func (lv *ListView) DataChanged() {
+
+	first, within := lv.scrollAnchor()
+	lv.dirty = true
+	n := lv.count()
+	lv.current = util.Min(lv.current, n-1)
+	lv.anchor = util.Max(util.Min(lv.anchor, n-1), 0)
+	lv.hovered = -1
+	lv.removeRange(n, math.MaxInt)
+	lv.restoreScroll(util.Min(first, n-1), within)
+}
+
+// ItemsInserted must be called after the specified number of items were inserted in the data source
+// at the specified index. The selection is updated and the visible items are kept at the same position.
+func (lv *ListView) ItemsInserted(index, count int) {
+
+	first, within := lv.scrollAnchor()
+	lv.dirty = true
+	shift := func(i int) int {
+		if i >= index {
+			return i + count
+		}
+		return i
+	}
+	var ranges []listRange
+	for _, r := range lv.ranges {
+		if r.first < index && r.last >= index {
+			ranges = append(ranges, listRange{r.first, index - 1}, listRange{index + count, r.last + count})
+			continue
+		}
+		ranges = append(ranges, listRange{shift(r.first), shift(r.last)})
+	}
+	lv.ranges = ranges
+	if lv.current >= 0 {
+		lv.current = shift(lv.current)
+	}
+	lv.anchor = shift(lv.anchor)
+	lv.hovered = -1
+	// Items inserted at the top of a list scrolled to the top are shown
+	if index < first || (index == first && lv.offset.Y > 0) {
+		first += count
+	}
+	lv.restoreScroll(first, within)
+}
+
+// ItemsRemoved must be called after the specified number of items were removed from the data source
+// at the specified index. The selection is updated and the visible items are kept at the same position.
+func (lv *ListView) ItemsRemoved(index, count int) {
+
+	first, within := lv.scrollAnchor()
+	lv.dirty = true
+	changed := false
+	var ranges []listRange
+	for _, r := range lv.ranges {
+		if r.last >= index && r.first < index+count {
+			changed = true
+		}
+		if r.first < index {
+			ranges = appendRange(ranges, listRange{r.first, util.Min(r.last, index-1)})
+		}
+		if r.last >= index+count {
+			ranges = appendRange(ranges, listRange{util.Max(r.first, index+count) - count, r.last - count})
+		}
+	}
+	lv.ranges = ranges
+	shift := func(i int) int {
+		switch {
+		case i >= index+count:
+			return i - count
+		case i >= index:
+			return util.Min(index, lv.count()-1)
+		}
+		return i
+	}
+	if lv.current >= 0 {
+		lv.current = shift(lv.current)
+	}
+	lv.anchor = util.Max(shift(lv.anchor), 0)
+	lv.hovered = -1
+	switch {
+	case first >= index+count:
+		first -= count
+	case first >= index:
+		first, within = index, 0
+	}
+	lv.restoreScroll(util.Min(first, lv.count()-1), within)
+	if changed {
+		lv.selectionChanged()
+	}
+}
+
+// OnSelectionChange sets the function called when the selection changes
+func (lv *ListView) OnSelectionChange(cb func(lv *ListView)) {
+
+	lv.onSelect = cb
+}
+
+// OnActivate sets the function called when an item is activated by the Enter key
+func (lv *ListView) OnActivate(cb func(lv *ListView, index int)) {
+
+	lv.onActivate = cb
+}
+
+// Measure satisfies the IView interface
+func (lv *ListView) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	lv.autoHeight = w.Font(window.FontRegular, 0).Height() + 2*listItemPadding
+	size := lv.ScrollView.Measure(w, avail)
+	if lv.prefSize.Y == 0 {
+		size.Y = util.Min(size.Y, lv.rowHeight()*listDefaultRows+lv.padding.Vertical())
+		size = lv.ClampSize(size)
+	}
+	return size
+}
+
+// OnEvent satisfies the IView interface
+func (lv *ListView) OnEvent(w *window.Window, ev *Event) bool {
+
+	switch ev.Type {
+	case EventMouseMove:
+		lv.hovered = lv.ItemAt(ev.Pos)
+	case EventMouseLeave:
+		lv.hovered = -1
+	case EventMouseDown:
+		if lv.disabled || ev.Button != gb.MouseButtonLeft {
+			break
+		}
+		if axis, _ := lv.barAt(ev.Pos); axis >= 0 {
+			break
+		}
+		index := lv.ItemAt(ev.Pos)
+		if index < 0 {
+			break
+		}
+		lv.clickItem(index, ev.Mods)
+		return true
+	case EventKeyDown:
+		if lv.disabled {
+			break
+		}
+		if lv.onKey(ev) {
+			return true
+		}
+	}
+	return lv.ScrollView.OnEvent(w, ev)
+}
+
+// Render satisfies the IView interface
+func (lv *ListView) Render(w *window.Window) {
+
+	if !lv.visible {
+		return
+	}
+	lv.ScrollView.Render(w)
+	if lv.HasFocus(w) && !lv.disabled {
+		dl := lv.BeginRender()
+		w.AddRect(dl, gb.Vec2{}, lv.size, lv.StyleColor(w, StyleColorFocus).RGBA(), 0, 0, 1)
+		lv.EndRender(w)
+	}
+}
+
+// Measure satisfies the IView interface
+func (c *listContent) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	c.lv.updateOffsets()
+	width := avail.X
+	if width == Unlimited {
+		width = 0
+	}
+	return gb.Vec2{width, c.lv.itemTop(c.lv.count())}
+}
+
+// Render satisfies the IView interface
+func (c *listContent) Render(w *window.Window) {
+
+	lv := c.lv
+	dl := c.BeginRender()
+	n := lv.count()
+	if n > 0 {
+		lv.updateOffsets()
+		top := lv.offset.Y
+		bottom := top + lv.viewport.Size().Y
+		item := ListItem{List: lv, DL: dl}
+		for i := lv.indexAtY(top); i < n; i++ {
+			y := lv.itemTop(i)
+			if y >= bottom {
+				break
+			}
+			item.Index = i
+			item.Rect = gb.Rect{Min: gb.Vec2{0, y}, Max: gb.Vec2{c.size.X, lv.itemTop(i + 1)}}
+			item.Selected = lv.Selected(i)
+			item.Hovered = i == lv.hovered
+			item.Current = i == lv.current && lv.HasFocus(w)
+			lv.renderItemBackground(w, &item)
+			lv.src.RenderItem(w, &item)
+		}
+	}
+	c.EndRender(w)
+}
+
+// DrawText draws the specified text in the item rectangle, vertically centered and with the list text color
+func (item *ListItem) DrawText(w *window.Window, text string) {
+
+	fa := w.Font(window.FontRegular, 0)
+	pos := gb.Vec2{item.Rect.Min.X + listItemPadding, item.Rect.Min.Y + (item.Rect.Size().Y-fa.Height())/2}
+	col := StyleColorText
+	if item.List.disabled {
+		col = StyleColorTextDisabled
+	}
+	w.AddText(item.DL, fa, &pos, item.List.StyleColor(w, col).RGBA(), window.TextVAlignTop, text)
+}
+
+// StringList is a ListDataSource and ComboDataSource with a text for each item
+type StringList []string
+
+// Len satisfies the ListDataSource interface
+func (l StringList) Len() int {
+
+	return len(l)
+}
+
+// Text satisfies the ComboDataSource interface
+func (l StringList) Text(index int) string {
+
+	return l[index]
+}
+
+// RenderItem satisfies the ListDataSource interface
+func (l StringList) RenderItem(w *window.Window, item *ListItem) {
+
+	item.DrawText(w, l[item.Index])
+}
+
+// renderItemBackground draws the background of the item for its state
+func (lv *ListView) renderItemBackground(w *window.Window, item *ListItem) {
+
+	switch {
+	case item.Selected:
+		w.AddRectFilled(item.DL, item.Rect.Min, item.Rect.Max, lv.StyleColor(w, StyleColorItemSelected).RGBA(), 0, 0)
+	case item.Hovered && !lv.disabled:
+		w.AddRectFilled(item.DL, item.Rect.Min, item.Rect.Max, lv.StyleColor(w, StyleColorItemHovered).RGBA(), 0, 0)
+	}
+	if item.Current {
+		w.AddRect(item.DL, item.Rect.Min, item.Rect.Max, lv.StyleColor(w, StyleColorFocus).RGBA(), 0, 0, 1)
+	}
+}
+
+// clickItem updates the current item and the selection for a click on the specified item
+func (lv *ListView) clickItem(index int, mods gb.ModKey) {
+
+	lv.current = index
+	switch {
+	case lv.mode == SelectMulti && mods&gb.ModShift != 0:
+		lv.selectRange(lv.anchor, index, mods&gb.ModControl != 0)
+	case lv.mode == SelectMulti && mods&gb.ModControl != 0:
+		lv.anchor = index
+		lv.SetSelected(index, !lv.Selected(index))
+	default:
+		lv.anchor = index
+		lv.selectRange(index, index, false)
+	}
+}
+
+// onKey processes a key down event and returns if it was handled
+func (lv *ListView) onKey(ev *Event) bool {
+
+	n := lv.count()
+	if n == 0 {
+		return false
+	}
+	shift := ev.Mods&gb.ModShift != 0
+	ctrl := ev.Mods&gb.ModControl != 0
+	target := lv.current
+	switch ev.Key {
+	case gb.KeyUp:
+		target--
+	case gb.KeyDown:
+		target++
+	case gb.KeyPageUp:
+		target = lv.indexAtY(util.Max(lv.itemTop(util.Max(lv.current, 0))-lv.viewport.Size().Y, 0))
+	case gb.KeyPageDown:
+		target = lv.indexAtY(lv.itemTop(util.Max(lv.current, 0)) + lv.viewport.Size().Y)
+	case gb.KeyHome:
+		target = 0
+	case gb.KeyEnd:
+		target = n - 1
+	case gb.KeySpace:
+		if lv.current < 0 {
+			return false
+		}
+		if ctrl && lv.mode == SelectMulti {
+			lv.SetSelected(lv.current, !lv.Selected(lv.current))
+		} else {
+			lv.selectRange(lv.current, lv.current, false)
+		}
+		lv.anchor = lv.current
+		return true
+	case gb.KeyA:
+		if !ctrl || lv.mode != SelectMulti {
+			return false
+		}
+		lv.SelectAll()
+		return true
+	case gb.KeyEnter, gb.KeyKPEnter:
+		if lv.current < 0 || lv.onActivate == nil {
+			return false
+		}
+		lv.onActivate(lv, lv.current)
+		return true
+	default:
+		return false
+	}
+	target = util.Clamp(target, 0, n-1)
+	lv.current = target
+	lv.ScrollToIndex(target)
+	switch {
+	case shift && lv.mode == SelectMulti:
+		lv.selectRange(lv.anchor, target, false)
+	case ctrl && lv.mode == SelectMulti:
+		// Moves the current item keeping the selection
+	default:
+		lv.anchor = target
+		lv.selectRange(target, target, false)
+	}
+	return true
+}
+
+// selectRange selects the items between the specified indices (in any order),
+// adding them to the current selection if requested or replacing it
+func (lv *ListView) selectRange(from, to int, add bool) {
+
+	if lv.mode == SelectNone {
+		return
+	}
+	if from > to {
+		from, to = to, from
+	}
+	if lv.mode == SelectSingle {
+		from = to
+		add = false
+	}
+	old := lv.ranges
+	lv.ranges = nil
+	if add {
+		lv.ranges = append(lv.ranges, old...)
+	}
+	lv.addRange(from, to)
+	if len(old) == len(lv.ranges) {
+		same := true
+		for i := range old {
+			if old[i] != lv.ranges[i] {
+				same = false
+				break
+			}
+		}
+		if same {
+			return
+		}
+	}
+	lv.selectionChanged()
+}
+
+// addRange adds the items between the specified indices to the selection ranges
+func (lv *ListView) addRange(first, last int) {
+
+	i := sort.Search(len(lv.ranges), func(i int) bool { return lv.ranges[i].last >= first-1 })
+	j := i
+	for j < len(lv.ranges) && lv.ranges[j].first <= last+1 {
+		first = util.Min(first, lv.ranges[j].first)
+		last = util.Max(last, lv.ranges[j].last)
+		j++
+	}
+	ranges := append(lv.ranges[:i:i], listRange{first, last})
+	lv.ranges = append(ranges, lv.ranges[j:]...)
+}
+
+// removeRange removes the items between the specified indices from the selection ranges
+func (lv *ListView) removeRange(first, last int) {
+
+	var ranges []listRange
+	for _, r := range lv.ranges {
+		if r.last < first || r.first > last {
+			ranges = append(ranges, r)
+			continue
+		}
+		if r.first < first {
+			ranges = append(ranges, listRange{r.first, first - 1})
+		}
+		if r.last > last {
+			ranges = append(ranges, listRange{last + 1, r.last})
+		}
+	}
+	lv.ranges = ranges
+}
+
+// appendRange appends a range to the sorted ranges, merging it with the last range if they are adjacent
+func appendRange(ranges []listRange, r listRange) []listRange {
+
+	if n := len(ranges); n > 0 && ranges[n-1].last+1 >= r.first {
+		ranges[n-1].last = util.Max(ranges[n-1].last, r.last)
+		return ranges
+	}
+	return append(ranges, r)
+}
+
+// selectionChanged calls the selection change callback
+func (lv *ListView) selectionChanged() {
+
+	if lv.onSelect != nil {
+		lv.onSelect(lv)
+	}
+}
+
+// count returns the number of items
+func (lv *ListView) count() int {
+
+	if lv.src == nil {
+		return 0
+	}
+	return lv.src.Len()
+}
+
+// rowHeight returns the fixed item height
+func (lv *ListView) rowHeight() float32 {
+
+	if lv.itemHeight > 0 {
+		return lv.itemHeight
+	}
+	return lv.autoHeight
+}
+
+// updateOffsets rebuilds the cumulative item offsets for variable item heights if necessary
+func (lv *ListView) updateOffsets() {
+
+	heights, ok := lv.src.(ListItemHeights)
+	if !ok {
+		lv.offsets = lv.offsets[:0]
+		return
+	}
+	n := lv.count()
+	if !lv.dirty && len(lv.offsets) == n+1 {
+		return
+	}
+	lv.offsets = append(lv.offsets[:0], 0)
+	var y float32
+	for i := 0; i < n; i++ {
+		y += heights.ItemHeight(i)
+		lv.offsets = append(lv.offsets, y)
+	}
+	lv.dirty = false
+}
+
+// itemTop returns the vertical position of the item with the specified index in content coordinates.
+// The index can be the number of items to get the total height.
+func (lv *ListView) itemTop(index int) float32 {
+
+	if len(lv.offsets) > 0 {
+		return lv.offsets[util.Clamp(index, 0, len(lv.offsets)-1)]
+	}
+	return float32(index) * lv.rowHeight()
+}
+
+// indexAtY returns the index of the item at the specified vertical position in content coordinates,
+// clamped to the valid indices
+func (lv *ListView) indexAtY(y float32) int {
+
+	n := lv.count()
+	if n == 0 {
+		return 0
+	}
+	var index int
+	if len(lv.offsets) > 0 {
+		index = sort.Search(n, func(i int) bool { return lv.offsets[i+1] > y })
+	} else if h := lv.rowHeight(); h > 0 {
+		index = int(y / h)
+	}
+	return util.Clamp(index, 0, n-1)
+}
+
+// scrollAnchor returns the index of the first visible item and the scroll offset inside it.
+// It is called after the data source changed, so it uses the offsets of the previous layout
+// without rebuilding them.
+func (lv *ListView) scrollAnchor() (int, float32) {
+
+	y := lv.offset.Y
+	if len(lv.offsets) > 0 {
+		n := len(lv.offsets) - 1
+		first := util.Min(sort.Search(n, func(i int) bool { return lv.offsets[i+1] > y }), util.Max(n-1, 0))
+		return first, y - lv.offsets[first]
+	}
+	h := lv.rowHeight()
+	if h <= 0 {
+		return 0, y
+	}
+	first := int(y / h)
+	return first, y - float32(first)*h
+}
+
+// restoreScroll scrolls to show the specified item at the specified offset after a data change.
+// The offset is clamped by the next layout.
+func (lv *ListView) restoreScroll(first int, within float32) {
+
+	lv.updateOffsets()
+	lv.velocity = gb.Vec2{}
+	lv.offset.Y = util.Max(lv.itemTop(util.Max(first, 0))+within, 0)
+}
@@ -0,0 +1,119 @@
+package view
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/leonsal/gux/window"
+)
+
+// testListSource is a list data source with items of variable heights which draws nothing
+type testListSource struct {
+	heights []float32
+}
+
+func (s *testListSource) Len() int                                    { return len(s.heights) }
+func (s *testListSource) ItemHeight(index int) float32                { return s.heights[index] }
+func (s *testListSource) RenderItem(w *window.Window, item *ListItem) {}
+
+// newTestList returns a multi selection list with the specified number of items with height 10
+func newTestList(count int) (*ListView, *testListSource) {
+
+	src := &testListSource{heights: make([]float32, count)}
+	for i := range src.heights {
+		src.heights[i] = 10
+	}
+	lv := NewListView(src)
+	lv.SetSelectionMode(SelectMulti)
+	return lv, src
+}
+
+func TestListSelection(t *testing.T) {
+
+	lv, _ := newTestList(1000)
+	lv.selectRange(2, 5, false)
+	lv.SetSelected(7, true)
+	lv.SetSelected(3, false)
+	lv.selectRange(9, 8, true)
+	if got, want := lv.SelectedIndices(), []int{2, 4, 5, 7, 8, 9}; !reflect.DeepEqual(got, want) {
+		t.Errorf("selected %v, want %v", got, want)
+	}
+	if got, want := lv.ranges, []listRange{{2, 2}, {4, 5}, {7, 9}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ranges %v, want %v", got, want)
+	}
+	if lv.SelectedIndex() != 2 || lv.Selected(3) || !lv.Selected(8) || lv.Selected(10) {
+		t.Errorf("wrong selection state")
+	}
+
+	// Selecting all items keeps a single range
+	changes := 0
+	lv.OnSelectionChange(func(*ListView) { changes++ })
+	lv.SelectAll()
+	lv.SelectAll()
+	if len(lv.ranges) != 1 || lv.ranges[0] != (listRange{0, 999}) || changes != 1 {
+		t.Errorf("select all: ranges %v with %d changes", lv.ranges, changes)
+	}
+	lv.SetSelectionMode(SelectSingle)
+	lv.selectRange(3, 6, true)
+	if got := lv.SelectedIndices(); !reflect.DeepEqual(got, []int{6}) {
+		t.Errorf("single selection: got %v", got)
+	}
+}
+
+func TestListItemsInsertedRemoved(t *testing.T) {
+
+	lv, src := newTestList(12)
+	lv.selectRange(2, 5, false)
+	lv.selectRange(8, 9, true)
+
+	src.heights = append(src.heights, 10, 10)
+	lv.ItemsInserted(4, 2)
+	if want := []listRange{{2, 3}, {6, 7}, {10, 11}}; !reflect.DeepEqual(lv.ranges, want) {
+		t.Errorf("inserted: ranges %v, want %v", lv.ranges, want)
+	}
+
+	changes := 0
+	lv.OnSelectionChange(func(*ListView) { changes++ })
+	src.heights = src.heights[:len(src.heights)-4]
+	lv.ItemsRemoved(3, 4)
+	if want := []listRange{{2, 3}, {6, 7}}; !reflect.DeepEqual(lv.ranges, want) || changes != 1 {
+		t.Errorf("removed: ranges %v with %d changes, want %v", lv.ranges, changes, want)
+	}
+
+	src.heights = src.heights[:7]
+	lv.DataChanged()
+	if want := []listRange{{2, 3}, {6, 6}}; !reflect.DeepEqual(lv.ranges, want) {
+		t.Errorf("data changed: ranges %v, want %v", lv.ranges, want)
+	}
+}
+
+func TestListScrollAnchor(t *testing.T) {
+
+	// The first visible item is item 5, scrolled 5 pixels into it
+	lv, src := newTestList(20)
+	lv.updateOffsets()
+	lv.offset.Y = 55
+
+	// Inserting taller items before it keeps it at the same position
+	src.heights = append([]float32{30, 30}, src.heights...)
+	lv.ItemsInserted(0, 2)
+	if lv.offset.Y != 115 {
+		t.Errorf("inserted: offset %v, want 115", lv.offset.Y)
+	}
+
+	// Removing them restores the original offset
+	src.heights = src.heights[2:]
+	lv.ItemsRemoved(0, 2)
+	if lv.offset.Y != 55 {
+		t.Errorf("removed: offset %v, want 55", lv.offset.Y)
+	}
+
+	// Changing the heights of the items before it keeps it at the same position
+	for i := 0; i < 5; i++ {
+		src.heights[i] = 20
+	}
+	lv.DataChanged()
+	if lv.offset.Y != 105 {
+		t.Errorf("data changed: offset %v, want 105", lv.offset.Y)
+	}
+}
@@ -0,0 +1,930 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// Horizontal space between menu items borders and their contents
+const menuItemPadding = 8
+
+// Vertical space between menu items borders and their text
+const menuItemVPadding = 4
+
+// Vertical space between popup menu borders and their items
+const menuPopupPadding = 4
+
+// Height of menu separators
+const menuSeparatorHeight = 7
+
+// Minimum space between the text and the shortcut of menu items
+const menuShortcutGap = 24
+
+// Modifier keys considered when matching keyboard shortcuts
+const menuShortcutMods = gb.ModShift | gb.ModControl | gb.ModAlt | gb.ModSuper
+
+// MenuItem is an item of a Menu or MenuBar.
+// Items can have a submenu, a check mark, a keyboard shortcut shown right aligned and
+// a mnemonic character, marked by '&' in its text, which selects the item when its key is
+// pressed while the menu is open (or with Alt for menu bar items).
+type MenuItem struct {
+	text      string          // Text without the mnemonic marker
+	mnemonic  int             // Byte offset in the text of the mnemonic character (-1 if none)
+	key       gb.Key          // Shortcut key (KeyUnknown if none)
+	mods      gb.ModKey       // Shortcut modifier keys
+	separator bool            // Item is a separator
+	checkable bool            // Item toggles its check mark when activated
+	checked   bool            // Check mark state
+	disabled  bool            // Disabled state
+	submenu   *Menu           // Submenu opened by the item
+	onClick   func(*MenuItem) // Activation callback
+}
+
+// Menu is a list of menu items shown in a popup above all other views,
+// opened from a MenuBar, a parent menu item or as a context menu.
+// The Up/Down keys select the items, Right opens a submenu, Left and Escape close
+// the menu, Enter and Space activate the selected item and mnemonic keys activate their items.
+type Menu struct {
+	items []*MenuItem // Menu items
+	popup *menuPopup  // Popup view while the menu is open
+}
+
+// MenuBar is a horizontal bar with items which open their menus when clicked.
+// While a menu is open, moving the cursor over other items opens their menus and the
+// Left/Right keys switch between menus. Alt plus an item mnemonic opens its menu and
+// the keyboard shortcuts of the items of all menus are processed when not handled by the focused view.
+type MenuBar struct {
+	View
+	items []*MenuItem // Top level items with their menus
+	lefts []float32   // Horizontal position of each item and the end of the last one
+	hover int         // Item under the cursor (-1 if none)
+	open  int         // Item with the open menu (-1 if none)
+}
+
+// menuPopup is the overlay view which shows an open Menu
+type menuPopup struct {
+	View
+	menu      *Menu      // Menu shown
+	bar       *MenuBar   // Menu bar which opened the menu chain (nil for context menus)
+	parent    *menuPopup // Popup of the parent menu (nil for the first menu of a chain)
+	child     *menuPopup // Popup of the open submenu (nil if none)
+	hover     int        // Highlighted item (-1 if none)
+	tops      []float32  // Top of each item and the bottom of the last one
+	textW     float32    // Width of the largest item text
+	shortcutW float32    // Width of the largest item shortcut text
+	prevFocus IView      // View focused before the menu chain opened
+	closed    bool       // Popup was closed
+}
+
+// NewMenuItem creates and returns a new menu item with the specified text.
+// The character after an '&' in the text is the item mnemonic and "&&" is a literal '&'.
+func NewMenuItem(text string) *MenuItem {
+
+	mi := &MenuItem{key: gb.KeyUnknown}
+	mi.SetText(text)
+	return mi
+}
+
+// SetText sets the item text. The character after an '&' is the item mnemonic.
+func (mi *MenuItem) SetText(text string) {
+
+	mi.mnemonic = -1
+	var sb strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] == '&' && i+1 < len(text) {
+			i++
+			if text[i] != '&' && mi.mnemonic < 0 {
+				mi.mnemonic = sb.Len()
+			}
+		}
+		sb.WriteByte(text[i])
+	}
+	mi.text = sb.String()
+}
+
+// Text returns the item text without the mnemonic marker
+func (mi *MenuItem) Text() string {
+
+	return mi.text
+}
+
+// Mnemonic returns the mnemonic character of the item or 0 if none
+func (mi *MenuItem) Mnemonic() rune {
+
+	if mi.mnemonic < 0 {
+		return 0
+	}
+	r, _ := utf8.DecodeRuneInString(mi.text[mi.mnemonic:])
+	return unicode.ToUpper(r)
+}
+
+// SetShortcut sets the keyboard shortcut which activates the item, shown at its right side.
+// Shortcuts of menu bar items are processed when the key is not handled by the focused view.
+func (mi *MenuItem) SetShortcut(key gb.Key, mods gb.ModKey) {
+
+	mi.key = key
+	mi.mods = mods
+}
+
+// Shortcut returns the shortcut key and modifiers of the item. The key is KeyUnknown if none.
+func (mi *MenuItem) Shortcut() (gb.Key, gb.ModKey) {
+
+	return mi.key, mi.mods
+}
+
+// ShortcutText returns the text of the item shortcut, such as "Ctrl+Shift+S", or an empty string if none
+func (mi *MenuItem) ShortcutText() string {
+
+	if mi.key == gb.KeyUnknown {
+		return ""
+	}
+	var sb strings.Builder
+	for _, mod := range []struct {
+		mod  gb.ModKey
+		name string
+	}{{gb.ModControl, "Ctrl+"}, {gb.ModAlt, "Alt+"}, {gb.ModShift, "Shift+"}, {gb.ModSuper, "Super+"}} {
+		if mi.mods&mod.mod != 0 {
+			sb.WriteString(mod.name)
+		}
+	}
+	sb.WriteString(keyName(mi.key))
+	return sb.String()
+}
+
+// SetCheckable sets if the item toggles its check mark when activated
+func (mi *MenuItem) SetCheckable(checkable bool) {
+
+	mi.checkable = checkable
+}
+
+// Checkable returns if the item toggles its check mark when activated
+func (mi *MenuItem) Checkable() bool {
+
+	return mi.checkable
+}
+
+// SetChecked sets the state of the item check mark
+func (mi *MenuItem) SetChecked(checked bool) {
+
+	mi.checked = checked
+}
+
+// Checked returns the state of the item check mark
+func (mi *MenuItem) Checked() bool {
+
+	return mi.checked
+}
+
+// SetEnabled sets if the item is enabled. Disabled items are shown with disabled colors and can not be activated.
+func (mi *MenuItem) SetEnabled(enabled bool) {
+
+	mi.disabled = !enabled
+}
+
+// Enabled returns if the item is enabled
+func (mi *MenuItem) Enabled() bool {
+
+	return !mi.disabled
+}
+
+// SetSubmenu sets the submenu opened by the item
+func (mi *MenuItem) SetSubmenu(m *Menu) {
+
+	mi.submenu = m
+}
+
+// Submenu returns the submenu opened by the item or nil
+func (mi *MenuItem) Submenu() *Menu {
+
+	return mi.submenu
+}
+
+// IsSeparator returns if the item is a separator
+func (mi *MenuItem) IsSeparator() bool {
+
+	return mi.separator
+}
+
+// OnClick sets the function called when the item is activated
+func (mi *MenuItem) OnClick(cb func(mi *MenuItem)) {
+
+	mi.onClick = cb
+}
+
+// trigger toggles the item check mark, if checkable, and calls its activation callback
+func (mi *MenuItem) trigger() {
+
+	if mi.checkable {
+		mi.checked = !mi.checked
+	}
+	if mi.onClick != nil {
+		mi.onClick(mi)
+	}
+}
+
+// NewMenu creates and returns a new empty Menu
+func NewMenu() *Menu {
+
+	return new(Menu)
+}
+
+// Add appends the specified items and returns this Menu
+func (m *Menu) Add(items ...*MenuItem) *Menu {
+
+	m.items = append(m.items, items...)
+	return m
+}
+
+// AddItem appends and returns a new item with the specified text and activation callback, which can be nil
+func (m *Menu) AddItem(text string, cb func(mi *MenuItem)) *MenuItem {
+
+	mi := NewMenuItem(text)
+	mi.onClick = cb
+	m.items = append(m.items, mi)
+	return mi
+}
+
+// AddSubmenu appends and returns a new item with the specified text which opens the specified submenu
+func (m *Menu) AddSubmenu(text string, submenu *Menu) *MenuItem {
+
+	mi := NewMenuItem(text)
+	mi.submenu = submenu
+	m.items = append(m.items, mi)
+	return mi
+}
+
+// AddSeparator appends a separator and returns this Menu
+func (m *Menu) AddSeparator() *Menu {
+
+	m.items = append(m.items, &MenuItem{key: gb.KeyUnknown, mnemonic: -1, separator: true})
+	return m
+}
+
+// Items returns the menu items
+func (m *Menu) Items() []*MenuItem {
+
+	return m.items
+}
+
+// Popup opens the menu as a context menu at the specified position in window coordinates.
+// The menu is moved as necessary to stay inside the window.
+func (m *Menu) Popup(w *window.Window, pos gb.Vec2) {
+
+	m.Close(w)
+	openMenuPopup(w, m, gb.Rect{Min: pos, Max: pos}, nil, nil, true)
+}
+
+// Close closes the menu, its submenus and the menus which opened it
+func (m *Menu) Close(w *window.Window) {
+
+	if m.popup != nil {
+		m.popup.closeAll(w)
+	}
+}
+
+// IsOpen returns if the menu is open
+func (m *Menu) IsOpen() bool {
+
+	return m.popup != nil
+}
+
+// SetContextMenu sets the menu opened at the cursor position when the right mouse button
+// is pressed over the view or its children and the event is not handled by them.
+// A nil menu removes the context menu.
+func (v *View) SetContextMenu(m *Menu) {
+
+	v.contextMenu = m
+}
+
+// ContextMenu returns the context menu of the view or nil
+func (v *View) ContextMenu() *Menu {
+
+	return v.contextMenu
+}
+
+// openContextMenu opens the context menu of the first enabled view with a context menu
+// starting from the specified view up to the top view
+func openContextMenu(w *window.Window, iv IView, pos gb.Vec2) {
+
+	for iv != nil {
+		v := iv.GetView()
+		if v.disabled {
+			return
+		}
+		if v.contextMenu != nil {
+			v.contextMenu.Popup(w, pos)
+			return
+		}
+		iv = v.parent
+	}
+}
+
+// NewMenuBar creates and returns a new empty MenuBar
+func NewMenuBar() *MenuBar {
+
+	b := new(MenuBar)
+	b.Init(b)
+	b.hover = -1
+	b.open = -1
+	return b
+}
+
+// Add appends an item with the specified text which opens the specified menu and returns this MenuBar.
+// The character after an '&' in the text is the item mnemonic.
+func (b *MenuBar) Add(text string, menu *Menu) *MenuBar {
+
+	mi := NewMenuItem(text)
+	mi.submenu = menu
+	b.items = append(b.items, mi)
+	return b
+}
+
+// Item returns the item with the specified index
+func (b *MenuBar) Item(index int) *MenuItem {
+
+	return b.items[index]
+}
+
+// ItemCount returns the number of items
+func (b *MenuBar) ItemCount() int {
+
+	return len(b.items)
+}
+
+// Close closes the open menu of the menu bar, if any
+func (b *MenuBar) Close(w *window.Window) {
+
+	if p := b.openPopup(); p != nil {
+		p.closeAll(w)
+	}
+}
+
+// Measure satisfies the IView interface
+func (b *MenuBar) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	fa := w.Font(window.FontRegular, 0)
+	b.lefts = b.lefts[:0]
+	x := b.padding.Left
+	for _, mi := range b.items {
+		b.lefts = append(b.lefts, x)
+		x += fa.MeasureString(mi.text) + 2*menuItemPadding
+	}
+	b.lefts = append(b.lefts, x)
+	size := gb.Vec2{x + b.padding.Right, fa.Height() + 2*menuItemVPadding + b.padding.Vertical()}
+	return b.ConstrainSize(size)
+}
+
+// OnEvent satisfies the IView interface
+func (b *MenuBar) OnEvent(w *window.Window, ev *Event) bool {
+
+	switch ev.Type {
+	case EventMouseMove:
+		b.hover = b.itemAt(ev.Pos)
+		if b.open >= 0 && b.hover >= 0 && b.hover != b.open && !b.disabled {
+			b.openMenu(w, b.hover, false)
+		}
+		return true
+	case EventMouseLeave:
+		b.hover = -1
+	case EventMouseDown:
+		if b.disabled || ev.Button != gb.MouseButtonLeft {
+			break
+		}
+		index := b.itemAt(ev.Pos)
+		if index < 0 {
+			break
+		}
+		if index == b.open {
+			b.Close(w)
+		} else {
+			b.openMenu(w, index, false)
+		}
+		return true
+	}
+	return false
+}
+
+// Render satisfies the IView interface
+func (b *MenuBar) Render(w *window.Window) {
+
+	if !b.visible {
+		return
+	}
+	dl := b.BeginRender()
+	fa := w.Font(window.FontRegular, 0)
+	w.AddRectFilled(dl, gb.Vec2{}, b.size, b.StyleColor(w, StyleColorMenuBar).RGBA(), 0, 0)
+	for i, mi := range b.items {
+		if i+1 >= len(b.lefts) {
+			break
+		}
+		r := b.itemRect(i)
+		if (i == b.open || i == b.hover) && !b.disabled && !mi.disabled {
+			w.AddRectFilled(dl, r.Min, r.Max, b.StyleColor(w, StyleColorMenuItemHovered).RGBA(), 0, 0)
+		}
+		col := StyleColorText
+		if b.disabled || mi.disabled {
+			col = StyleColorTextDisabled
+		}
+		pos := gb.Vec2{r.Min.X + menuItemPadding, r.Min.Y + menuItemVPadding}
+		drawMenuText(w, dl, fa, pos, mi, b.StyleColor(w, col).RGBA())
+	}
+	w.AddLine(dl, gb.Vec2{0, b.size.Y}, b.size, b.StyleColor(w, StyleColorBorder).RGBA(), 1)
+	b.EndRender(w)
+}
+
+// onShortcut satisfies the shortcutHandler interface.
+// Alt plus a mnemonic opens the menu of the item and other keys activate items with the same shortcut.
+func (b *MenuBar) onShortcut(w *window.Window, ev *Event) bool {
+
+	// Unmapped keys never match a shortcut
+	if ev.Key == gb.KeyUnknown {
+		return false
+	}
+	mods := ev.Mods & menuShortcutMods
+	if mods == gb.ModAlt {
+		if r := keyRune(ev.Key); r != 0 {
+			for i, mi := range b.items {
+				if mi.Mnemonic() == r && !mi.disabled {
+					b.openMenu(w, i, true)
+					return true
+				}
+			}
+		}
+	}
+	for _, mi := range b.items {
+		if !mi.disabled && mi.submenu != nil && mi.submenu.triggerShortcut(w, ev.Key, mods) {
+			return true
+		}
+	}
+	return false
+}
+
+// openMenu opens the menu of the item with the specified index, closing the current open menu.
+// When opened by the keyboard the first menu item is highlighted.
+func (b *MenuBar) openMenu(w *window.Window, index int, keyboard bool) {
+
+	mi := b.items[index]
+	if mi.disabled || mi.submenu == nil {
+		return
+	}
+	prevFocus := Focused(w)
+	if p := b.openPopup(); p != nil {
+		prevFocus = p.prevFocus
+		p.closeFrom(w)
+	}
+	b.open = index
+	p := openMenuPopup(w, mi.submenu, b.WindowRect(b.itemRect(index)), b, nil, true)
+	p.prevFocus = prevFocus
+	if keyboard {
+		p.hover = p.next(-1, 1)
+	}
+}
+
+// step opens the menu of the next (dir > 0) or previous enabled item, wrapping around
+func (b *MenuBar) step(w *window.Window, dir int) {
+
+	n := len(b.items)
+	for i, index := 0, b.open; i < n; i++ {
+		index = (index + dir + n) % n
+		if !b.items[index].disabled && b.items[index].submenu != nil {
+			b.openMenu(w, index, true)
+			return
+		}
+	}
+}
+
+// openPopup returns the popup of the open menu or nil
+func (b *MenuBar) openPopup() *menuPopup {
+
+	if b.open < 0 || b.open >= len(b.items) {
+		return nil
+	}
+	return b.items[b.open].submenu.popup
+}
+
+// itemRect returns the rectangle of the item with the specified index in local coordinates
+func (b *MenuBar) itemRect(index int) gb.Rect {
+
+	return gb.Rect{
+		Min: gb.Vec2{b.lefts[index], b.padding.Top},
+		Max: gb.Vec2{b.lefts[index+1], b.size.Y - b.padding.Bottom},
+	}
+}
+
+// itemAt returns the index of the item at the specified local point or -1
+func (b *MenuBar) itemAt(pos gb.Vec2) int {
+
+	for i := 0; i+1 < len(b.lefts) && i < len(b.items); i++ {
+		if b.itemRect(i).Contains(pos) {
+			return i
+		}
+	}
+	return -1
+}
+
+// triggerShortcut activates the first enabled item of the menu or of its submenus with the
+// specified shortcut and returns if found
+func (m *Menu) triggerShortcut(w *window.Window, key gb.Key, mods gb.ModKey) bool {
+
+	for _, mi := range m.items {
+		if mi.disabled || mi.separator {
+			continue
+		}
+		if mi.submenu != nil {
+			if mi.submenu.triggerShortcut(w, key, mods) {
+				return true
+			}
+			continue
+		}
+		// Items without shortcut have an unknown key which must not match unmapped keys
+		if mi.key != gb.KeyUnknown && mi.key == key && mi.mods&menuShortcutMods == mods {
+			if m.popup != nil {
+				m.popup.closeAll(w)
+			}
+			mi.trigger()
+			return true
+		}
+	}
+	return false
+}
+
+// openMenuPopup opens a popup for the specified menu positioned at the anchor rectangle in window
+// coordinates: below it for the first menu of a chain and at its right side for submenus
+func openMenuPopup(w *window.Window, m *Menu, anchor gb.Rect, bar *MenuBar, parent *menuPopup, focus bool) *menuPopup {
+
+	p := &menuPopup{menu: m, bar: bar, parent: parent, hover: -1}
+	p.Init(p)
+	p.focusable = true
+	m.popup = p
+	ShowOverlay(w, p)
+	if parent != nil {
+		parent.child = p
+		// Aligns the first submenu item with the parent item
+		anchor.Min.Y -= menuPopupPadding
+		SetOverlayAnchor(w, p, anchor, PlaceRight)
+	} else {
+		p.prevFocus = Focused(w)
+		SetOverlayAnchor(w, p, anchor, PlaceBelow)
+	}
+	if focus {
+		SetFocus(w, p)
+	}
+	return p
+}
+
+// Measure satisfies the IView interface
+func (p *menuPopup) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	fa := w.Font(window.FontRegular, 0)
+	p.textW = 0
+	p.shortcutW = 0
+	p.tops = p.tops[:0]
+	y := float32(menuPopupPadding)
+	for _, mi := range p.menu.items {
+		p.tops = append(p.tops, y)
+		if mi.separator {
+			y += menuSeparatorHeight
+			continue
+		}
+		y += fa.Height() + 2*menuItemVPadding
+		p.textW = util.Max(p.textW, fa.MeasureString(mi.text))
+		p.shortcutW = util.Max(p.shortcutW, fa.MeasureString(mi.ShortcutText()))
+	}
+	p.tops = append(p.tops, y)
+	width := 2*menuItemPadding + 2*fa.Height() + p.textW
+	if p.shortcutW > 0 {
+		width += menuShortcutGap + p.shortcutW
+	}
+	return gb.Vec2{width, y + menuPopupPadding}
+}
+
+// OnEvent satisfies the IView interface
+func (p *menuPopup) OnEvent(w *window.Window, ev *Event) bool {
+
+	switch ev.Type {
+	case EventMouseMove:
+		if !p.HasFocus(w) {
+			SetFocus(w, p)
+		}
+		index := p.itemAt(ev.Pos)
+		if index == p.hover || (index < 0 && p.child != nil) {
+			return true
+		}
+		p.hover = index
+		if index >= 0 && p.menu.items[index].submenu != nil && !p.menu.items[index].disabled {
+			p.openChild(w, index, false)
+		} else {
+			p.closeChild(w)
+		}
+	case EventMouseLeave:
+		if p.child == nil {
+			p.hover = -1
+		}
+	case EventMouseUp:
+		if index := p.itemAt(ev.Pos); index >= 0 {
+			p.activate(w, index, false)
+		}
+	case EventFocusOut:
+		if p.closed || p.root().chainContains(Focused(w)) {
+			break
+		}
+		// Keeps the menu open when the focus is lost by clicking on the menu bar, which handles the click
+		if bar := p.root().bar; bar != nil && Hovered(w) == IView(bar) {
+			break
+		}
+		p.closeAll(w)
+	case EventKeyDown:
+		p.onKey(w, ev)
+	}
+	return true
+}
+
+// Render satisfies the IView interface
+func (p *menuPopup) Render(w *window.Window) {
+
+	dl := p.BeginRender()
+	fa := w.Font(window.FontRegular, 0)
+	rounding := p.StyleFrameRounding(w)
+	border := p.StyleColor(w, StyleColorBorder).RGBA()
+	w.AddRectFilled(dl, gb.Vec2{}, p.size, p.StyleColor(w, StyleColorMenu).RGBA(), rounding, window.DrawFlags_RoundCornersAll)
+	w.AddRect(dl, gb.Vec2{}, p.size, border, rounding, window.DrawFlags_RoundCornersAll, 1)
+	checkW := fa.Height()
+	for i, mi := range p.menu.items {
+		if i+1 >= len(p.tops) {
+			break
+		}
+		r := p.itemRect(i)
+		if mi.separator {
+			y := (r.Min.Y + r.Max.Y) / 2
+			w.AddLine(dl, gb.Vec2{menuItemPadding, y}, gb.Vec2{p.size.X - menuItemPadding, y}, border, 1)
+			continue
+		}
+		if i == p.hover && !mi.disabled {
+			w.AddRectFilled(dl, r.Min, r.Max, p.StyleColor(w, StyleColorMenuItemHovered).RGBA(), 0, 0)
+		}
+		col := StyleColorText
+		if mi.disabled {
+			col = StyleColorTextDisabled
+		}
+		textColor := p.StyleColor(w, col).RGBA()
+		top := r.Min.Y + menuItemVPadding
+		if mi.checkable && mi.checked {
+			drawCheckMark(w, dl, gb.Vec2{menuItemPadding, top}, checkW, textColor)
+		}
+		drawMenuText(w, dl, fa, gb.Vec2{menuItemPadding + checkW, top}, mi, textColor)
+		if shortcut := mi.ShortcutText(); shortcut != "" {
+			pos := gb.Vec2{p.size.X - menuItemPadding - checkW - fa.MeasureString(shortcut), top}
+			w.AddText(dl, fa, &pos, textColor, window.TextVAlignTop, shortcut)
+		}
+		if mi.submenu != nil {
+			center := gb.Vec2{p.size.X - menuItemPadding - checkW/2, (r.Min.Y + r.Max.Y) / 2}
+			drawArrow(w, dl, center, checkW*0.5, arrowRight, textColor)
+		}
+	}
+	p.EndRender(w)
+}
+
+// onKey processes a key down event
+func (p *menuPopup) onKey(w *window.Window, ev *Event) {
+
+	switch ev.Key {
+	case gb.KeyUp:
+		p.hover = p.next(p.hover, -1)
+	case gb.KeyDown:
+		p.hover = p.next(p.hover, 1)
+	case gb.KeyHome:
+		p.hover = p.next(-1, 1)
+	case gb.KeyEnd:
+		p.hover = p.next(len(p.menu.items), -1)
+	case gb.KeyRight:
+		if p.hover >= 0 && p.menu.items[p.hover].submenu != nil {
+			p.activate(w, p.hover, true)
+		} else if p.root().bar != nil {
+			p.root().bar.step(w, 1)
+		}
+	case gb.KeyLeft:
+		if p.parent != nil {
+			p.parent.closeChild(w)
+		} else if p.bar != nil {
+			p.bar.step(w, -1)
+		}
+	case gb.KeyEnter, gb.KeyKPEnter, gb.KeySpace:
+		if p.hover >= 0 {
+			p.activate(w, p.hover, true)
+		}
+	case gb.KeyEscape:
+		if p.parent != nil {
+			p.parent.closeChild(w)
+		} else {
+			p.closeAll(w)
+		}
+	default:
+		r := keyRune(ev.Key)
+		if r == 0 {
+			break
+		}
+		for i, mi := range p.menu.items {
+			if mi.Mnemonic() == r && !mi.disabled {
+				p.hover = i
+				p.activate(w, i, true)
+				break
+			}
+		}
+	}
+}
+
+// activate activates the item with the specified index, opening its submenu,
+// or closing all menus of the chain and triggering the item
+func (p *menuPopup) activate(w *window.Window, index int, keyboard bool) {
+
+	mi := p.menu.items[index]
+	if mi.separator || mi.disabled {
+		return
+	}
+	if mi.submenu != nil {
+		p.openChild(w, index, true)
+		if keyboard && p.child != nil {
+			p.child.hover = p.child.next(-1, 1)
+		}
+		return
+	}
+	p.closeAll(w)
+	mi.trigger()
+}
+
+// openChild opens the submenu of the item with the specified index, closing any other open submenu
+func (p *menuPopup) openChild(w *window.Window, index int, focus bool) {
+
+	submenu := p.menu.items[index].submenu
+	if p.child != nil && p.child.menu == submenu {
+		if focus {
+			SetFocus(w, p.child)
+		}
+		return
+	}
+	p.closeChild(w)
+	if submenu.popup != nil {
+		submenu.popup.closeAll(w)
+	}
+	openMenuPopup(w, submenu, p.WindowRect(p.itemRect(index)), p.bar, p, focus)
+}
+
+// closeChild closes the open submenu, moving the focus to this popup if it was in the submenu
+func (p *menuPopup) closeChild(w *window.Window) {
+
+	if p.child == nil {
+		return
+	}
+	focused := p.child.chainContains(Focused(w))
+	p.child.closeFrom(w)
+	if focused {
+		SetFocus(w, p)
+	}
+}
+
+// closeAll closes all the popups of the chain, restoring the focus if it was in the chain
+func (p *menuPopup) closeAll(w *window.Window) {
+
+	root := p.root()
+	focus := Focused(w)
+	restore := focus == nil || root.chainContains(focus)
+	root.closeFrom(w)
+	if root.bar != nil {
+		root.bar.open = -1
+	}
+	if restore {
+		SetFocus(w, root.prevFocus)
+	}
+}
+
+// closeFrom closes this popup and its submenus
+func (p *menuPopup) closeFrom(w *window.Window) {
+
+	if p.child != nil {
+		p.child.closeFrom(w)
+	}
+	p.closed = true
+	p.menu.popup = nil
+	if p.parent != nil {
+		p.parent.child = nil
+	}
+	CloseOverlay(w, p)
+}
+
+// root returns the first popup of the chain
+func (p *menuPopup) root() *menuPopup {
+
+	for p.parent != nil {
+		p = p.parent
+	}
+	return p
+}
+
+// chainContains returns if the specified view is this popup or one of its open submenus
+func (p *menuPopup) chainContains(iv IView) bool {
+
+	for ; p != nil; p = p.child {
+		if iv == IView(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// next returns the index of the next (dir > 0) or previous enabled item from the specified one,
+// wrapping around, or -1 if none
+func (p *menuPopup) next(from, dir int) int {
+
+	n := len(p.menu.items)
+	for i, index := 0, from; i < n; i++ {
+		index = (index + dir + n) % n
+		if mi := p.menu.items[index]; !mi.separator && !mi.disabled {
+			return index
+		}
+	}
+	return -1
+}
+
+// itemRect returns the rectangle of the item with the specified index in local coordinates
+func (p *menuPopup) itemRect(index int) gb.Rect {
+
+	return gb.Rect{Min: gb.Vec2{0, p.tops[index]}, Max: gb.Vec2{p.size.X, p.tops[index+1]}}
+}
+
+// itemAt returns the index of the item at the specified local point or -1 if none or a separator
+func (p *menuPopup) itemAt(pos gb.Vec2) int {
+
+	for i := 0; i+1 < len(p.tops) && i < len(p.menu.items); i++ {
+		if p.itemRect(i).Contains(pos) {
+			if p.menu.items[i].separator {
+				return -1
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+// drawMenuText draws the text of a menu item at the specified position underlining its mnemonic
+func drawMenuText(w *window.Window, dl *gb.DrawList, fa *window.FontAtlas, pos gb.Vec2, mi *MenuItem, col gb.RGBA) {
+
+	start := pos
+	w.AddText(dl, fa, &pos, col, window.TextVAlignTop, mi.text)
+	if mi.mnemonic < 0 {
+		return
+	}
+	_, size := utf8.DecodeRuneInString(mi.text[mi.mnemonic:])
+	x0 := start.X + fa.MeasureString(mi.text[:mi.mnemonic])
+	x1 := x0 + fa.MeasureString(mi.text[mi.mnemonic:mi.mnemonic+size])
+	y := start.Y + fa.Ascent() + 1
+	w.AddLine(dl, gb.Vec2{x0, y}, gb.Vec2{x1, y}, col, 1)
+}
+
+// keyNames maps the keys without a printable character to their names
+var keyNames = map[gb.Key]string{
+	gb.KeySpace:     "Space",
+	gb.KeyEscape:    "Esc",
+	gb.KeyEnter:     "Enter",
+	gb.KeyTab:       "Tab",
+	gb.KeyBackspace: "Backspace",
+	gb.KeyInsert:    "Ins",
+	gb.KeyDelete:    "Del",
+	gb.KeyRight:     "Right",
+	gb.KeyLeft:      "Left",
+	gb.KeyDown:      "Down",
+	gb.KeyUp:        "Up",
+	gb.KeyPageUp:    "PgUp",
+	gb.KeyPageDown:  "PgDn",
+	gb.KeyHome:      "Home",
+	gb.KeyEnd:       "End",
+	gb.KeyMinus:     "-",
+	gb.KeyComma:     ",",
+	gb.KeyPeriod:    ".",
+}
+
+// keyName returns the name of the specified key shown in menu shortcuts
+func keyName(key gb.Key) string {
+
+	if r := keyRune(key); r != 0 {
+		return string(r)
+	}
+	if key >= gb.KeyF1 && key <= gb.KeyF12 {
+		return fmt.Sprintf("F%d", key-gb.KeyF1+1)
+	}
+	return keyNames[key]
+}
+
+// keyRune returns the upper case character of a letter or digit key or 0 for other keys
+func keyRune(key gb.Key) rune {
+
+	if (key >= gb.KeyA && key <= gb.KeyZ) || (key >= gb.Key0 && key <= gb.Key9) {
+		return rune(key)
+	}
+	return 0
+}
@@ -0,0 +1,225 @@
+package view
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/leonsal/gux/gb"
+)
+
+// Number of steps in the range used when a numeric view has no step
+const numericDefaultSteps = 100
+
+// numericValue is the base of the views which edit a numeric value inside a range.
+// The value is always kept inside the range and, if a step is set, snapped to a multiple of the step.
+type numericValue struct {
+	value       float64 // Current value
+	min         float64 // Minimum value
+	max         float64 // Maximum value
+	step        float64 // Value step (0 is continuous)
+	logarithmic bool    // Positions are mapped to values logarithmically
+	format      string  // Format used to show the value
+	notify      func()  // Function called when the value changes
+}
+
+// initNumeric initializes the numericValue with the specified range and change notification function
+func (n *numericValue) initNumeric(min, max float64, notify func()) {
+
+	n.min = min
+	n.max = max
+	n.value = min
+	if math.IsInf(min, -1) {
+		n.value = math.Max(0, min)
+	}
+	n.format = "%.2f"
+	n.notify = notify
+}
+
+// SetRange sets the minimum and maximum values, clamping the current value.
+// Infinite limits are allowed for an unbounded range.
+func (n *numericValue) SetRange(min, max float64) {
+
+	n.min = min
+	n.max = math.Max(min, max)
+	n.SetValue(n.value)
+}
+
+// Range returns the minimum and maximum values
+func (n *numericValue) Range() (float64, float64) {
+
+	return n.min, n.max
+}
+
+// SetStep sets the step of the value. Values are snapped to multiples of the step
+// from the range minimum, so a step of 1 gives integer values for integer ranges.
+// A zero step allows any value.
+func (n *numericValue) SetStep(step float64) {
+
+	n.step = math.Abs(step)
+	n.SetValue(n.value)
+}
+
+// Step returns the step of the value
+func (n *numericValue) Step() float64 {
+
+	return n.step
+}
+
+// SetLogarithmic sets if positions are mapped to values logarithmically,
+// which is useful for ranges spanning several orders of magnitude.
+// It is only used if the range minimum is positive and the maximum is finite.
+func (n *numericValue) SetLogarithmic(log bool) {
+
+	n.logarithmic = log
+}
+
+// Logarithmic returns if positions are mapped to values logarithmically
+func (n *numericValue) Logarithmic() bool {
+
+	return n.logarithmic
+}
+
+// SetFormat sets the fmt format used to show the value. The default is "%.2f".
+func (n *numericValue) SetFormat(format string) {
+
+	n.format = format
+}
+
+// Format returns the format used to show the value
+func (n *numericValue) Format() string {
+
+	return n.format
+}
+
+// SetValue sets the value, clamped to the range and snapped to the step,
+// calling the change callback if it changed.
+func (n *numericValue) SetValue(v float64) {
+
+	if math.IsNaN(v) {
+		return
+	}
+	if n.step > 0 {
+		origin := n.min
+		if math.IsInf(origin, 0) {
+			origin = 0
+		}
+		v = origin + math.Round((v-origin)/n.step)*n.step
+	}
+	v = math.Max(n.min, math.Min(v, n.max))
+	if v == n.value {
+		return
+	}
+	n.value = v
+	if n.notify != nil {
+		n.notify()
+	}
+}
+
+// Value returns the current value
+func (n *numericValue) Value() float64 {
+
+	return n.value
+}
+
+// IntValue returns the current value rounded to the nearest integer
+func (n *numericValue) IntValue() int {
+
+	return int(math.Round(n.value))
+}
+
+// formatValue returns the current value formatted for display
+func (n *numericValue) formatValue() string {
+
+	return fmt.Sprintf(n.format, n.value)
+}
+
+// bounded returns if the range has finite limits
+func (n *numericValue) bounded() bool {
+
+	return !math.IsInf(n.min, 0) && !math.IsInf(n.max, 0)
+}
+
+// useLog returns if the value is mapped logarithmically
+func (n *numericValue) useLog() bool {
+
+	return n.logarithmic && n.min > 0 && !math.IsInf(n.max, 0)
+}
+
+// fraction returns the position of the current value in the range from 0 to 1
+func (n *numericValue) fraction() float64 {
+
+	if !n.bounded() || n.max == n.min {
+		return 0
+	}
+	if n.useLog() {
+		return math.Log(n.value/n.min) / math.Log(n.max/n.min)
+	}
+	return (n.value - n.min) / (n.max - n.min)
+}
+
+// setFraction sets the value from a position in the range from 0 to 1
+func (n *numericValue) setFraction(f float64) {
+
+	if !n.bounded() {
+		return
+	}
+	f = math.Max(0, math.Min(f, 1))
+	if n.useLog() {
+		n.SetValue(n.min * math.Pow(n.max/n.min, f))
+		return
+	}
+	n.SetValue(n.min + f*(n.max-n.min))
+}
+
+// stepBy changes the value by the specified number of steps.
+// Without a step each step is a hundredth of a bounded range or 1 for unbounded ranges.
+// Fractional steps are rounded up to one step if a step is set.
+func (n *numericValue) stepBy(steps float64) {
+
+	if n.step == 0 && n.useLog() {
+		n.setFraction(n.fraction() + steps/numericDefaultSteps)
+		return
+	}
+	inc := n.step
+	if inc == 0 {
+		inc = 1
+		if n.bounded() && n.max > n.min {
+			inc = (n.max - n.min) / numericDefaultSteps
+		}
+	}
+	delta := steps * inc
+	if n.step > 0 && math.Abs(delta) < n.step {
+		delta = math.Copysign(n.step, delta)
+	}
+	n.SetValue(n.value + delta)
+}
+
+// stepMultiplier returns the multiplier of value steps for the specified modifier keys:
+// Shift for fine steps and Control for coarse steps.
+func stepMultiplier(mods gb.ModKey) float64 {
+
+	switch {
+	case mods&gb.ModShift != 0:
+		return 0.1
+	case mods&gb.ModControl != 0:
+		return 10
+	default:
+		return 1
+	}
+}
+
+// numericKeySteps returns the number of steps for a key which changes a numeric value or 0
+func numericKeySteps(ev *Event) float64 {
+
+	switch ev.Key {
+	case gb.KeyRight, gb.KeyUp:
+		return stepMultiplier(ev.Mods)
+	case gb.KeyLeft, gb.KeyDown:
+		return -stepMultiplier(ev.Mods)
+	case gb.KeyPageUp:
+		return 10
+	case gb.KeyPageDown:
+		return -10
+	}
+	return 0
+}
@@ -0,0 +1,138 @@
+package view
+
+import (
+	"math"
+	"testing"
+)
+
+// newTestNumeric returns a numericValue with the specified range and step which counts its change notifications
+func newTestNumeric(min, max, step float64, log bool) (*numericValue, *int) {
+
+	n := new(numericValue)
+	changes := new(int)
+	n.initNumeric(min, max, func() { *changes++ })
+	n.SetStep(step)
+	n.SetLogarithmic(log)
+	return n, changes
+}
+
+func TestNumericSetValue(t *testing.T) {
+
+	inf := math.Inf(1)
+	cases := []struct {
+		min, max, step float64
+		value          float64
+		want           float64
+	}{
+		{0, 10, 0, 5.3, 5.3},
+		{0, 10, 0, -1, 0},
+		{0, 10, 0, 11, 10},
+		{0, 10, 1, 5.4, 5},
+		{0, 10, 1, 5.6, 6},
+		{0, 10, 0.25, 3.3, 3.25},
+		{1, 10, 2, 4.2, 5},
+		{1, 10, 2, 9.8, 9},
+		{-inf, inf, 0, -1e9, -1e9},
+		{-inf, inf, 5, 12, 10},
+		{0, inf, 0, -3, 0},
+		{0, 10, 0, math.NaN(), 0},
+	}
+	for _, c := range cases {
+		n, _ := newTestNumeric(c.min, c.max, c.step, false)
+		n.SetValue(c.value)
+		if math.Abs(n.Value()-c.want) > 1e-9 {
+			t.Errorf("range %v-%v step %v: SetValue(%v) got %v, want %v", c.min, c.max, c.step, c.value, n.Value(), c.want)
+		}
+	}
+}
+
+func TestNumericNotify(t *testing.T) {
+
+	n, changes := newTestNumeric(0, 10, 1, false)
+	n.SetValue(3)
+	n.SetValue(3.2)
+	n.SetValue(20)
+	n.SetValue(10)
+	if *changes != 2 {
+		t.Errorf("got %d notifications, want 2", *changes)
+	}
+
+	// Changing the range clamps the value
+	n.SetRange(0, 5)
+	if n.Value() != 5 || *changes != 3 {
+		t.Errorf("after SetRange: value %v with %d notifications", n.Value(), *changes)
+	}
+}
+
+func TestNumericStepBy(t *testing.T) {
+
+	inf := math.Inf(1)
+	cases := []struct {
+		min, max, step float64
+		log            bool
+		value, steps   float64
+		want           float64
+	}{
+		{0, 10, 1, false, 5, 1, 6},
+		{0, 10, 1, false, 5, -2, 3},
+		{0, 10, 1, false, 5, 0.1, 6},
+		{0, 10, 1, false, 5, -0.1, 4},
+		{0, 10, 1, false, 9, 10, 10},
+		{0, 200, 0, false, 50, 1, 52},
+		{0, 200, 0, false, 50, 0.1, 50.2},
+		{-inf, inf, 0, false, 3, 1, 4},
+		{-inf, inf, 0.5, false, 3, -1, 2.5},
+		{1, 10000, 0, true, 1, 25, 10},
+		{1, 10000, 0, true, 100, -50, 1},
+		{10, 10000, 10, true, 100, 1, 110},
+	}
+	for _, c := range cases {
+		n, _ := newTestNumeric(c.min, c.max, c.step, c.log)
+		n.SetValue(c.value)
+		n.stepBy(c.steps)
+		if math.Abs(n.Value()-c.want) > 1e-9 {
+			t.Errorf("range %v-%v step %v log %v: stepBy(%v) from %v got %v, want %v",
+				c.min, c.max, c.step, c.log, c.steps, c.value, n.Value(), c.want)
+		}
+	}
+}
+
+func TestNumericFraction(t *testing.T) {
+
+	inf := math.Inf(1)
+	cases := []struct {
+		min, max float64
+		log      bool
+		value    float64
+		fraction float64
+	}{
+		{0, 10, false, 0, 0},
+		{0, 10, false, 2.5, 0.25},
+		{0, 10, false, 10, 1},
+		{-10, 10, false, 0, 0.5},
+		{1, 1000, true, 1, 0},
+		{1, 1000, true, 10, 1.0 / 3},
+		{1, 1000, true, 100, 2.0 / 3},
+		{1, 1000, true, 1000, 1},
+		{0, 100, true, 10, 0.1},
+		{0, inf, false, 10, 0},
+		{5, 5, false, 5, 0},
+	}
+	for _, c := range cases {
+		n, _ := newTestNumeric(c.min, c.max, 0, c.log)
+		n.SetValue(c.value)
+		if got := n.fraction(); math.Abs(got-c.fraction) > 1e-9 {
+			t.Errorf("range %v-%v log %v: fraction of %v got %v, want %v", c.min, c.max, c.log, c.value, got, c.fraction)
+		}
+
+		// Bounded ranges map the fraction back to the value
+		if !n.bounded() || c.max == c.min {
+			continue
+		}
+		n.SetValue(c.min)
+		n.setFraction(c.fraction)
+		if math.Abs(n.Value()-c.value) > 1e-9 {
+			t.Errorf("range %v-%v log %v: setFraction(%v) got %v, want %v", c.min, c.max, c.log, c.fraction, n.Value(), c.value)
+		}
+	}
+}
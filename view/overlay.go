@@ -0,0 +1,348 @@
+package view
+
+import (
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// OverlayFlags configures how an overlay view is shown
+type OverlayFlags int
+
+const (
+	OverlayModal          OverlayFlags = 1 << iota // Dims the views below and blocks their mouse and keyboard events
+	OverlayCloseOnOutside                          // Closes the overlay when a mouse button is pressed outside of it
+	OverlayCentered                                // Centers the overlay in the window
+	OverlayPassThrough                             // Overlay does not receive mouse events, which go to the views below
+)
+
+// Placement is the side of the anchor rectangle where an anchored overlay is placed
+type Placement int
+
+const (
+	PlaceBelow Placement = iota // Below the anchor, aligned to its left side
+	PlaceAbove                  // Above the anchor, aligned to its left side
+	PlaceRight                  // At the right of the anchor, aligned to its top
+	PlaceLeft                   // At the left of the anchor, aligned to its top
+)
+
+// overlay contains the state of a view in the overlay stack of a window
+type overlay struct {
+	view      IView        // Overlay view
+	flags     OverlayFlags // Overlay configuration
+	anchored  bool         // Overlay is positioned relative to anchor
+	anchor    gb.Rect      // Anchor rectangle in window coordinates
+	placement Placement    // Preferred side of the anchor
+	prevFocus IView        // View focused when a modal overlay was shown
+	onClose   func()       // Close callback
+}
+
+// overlayLayer contains the stack of views of a window shown above its top view, such as popups and dialogs
+type overlayLayer struct {
+	stack []*overlay // Overlays from bottom to top
+}
+
+// windowOverlays maps Windows to their overlay layer
+var windowOverlays = map[*window.Window]*overlayLayer{}
+
+// getOverlayLayer returns the overlay layer of the window, creating it if necessary
+func getOverlayLayer(w *window.Window) *overlayLayer {
+
+	l, ok := windowOverlays[w]
+	if !ok {
+		l = new(overlayLayer)
+		windowOverlays[w] = l
+		w.OnDestroy(func(w *window.Window) { delete(windowOverlays, w) })
+	}
+	return l
+}
+
+// ShowOverlay shows the specified view at its position, in window coordinates,
+// above the top view of the window and its other overlays.
+// Overlays are sized to their desired size, rendered after the window top view
+// and receive the mouse events before the views below them.
+// If the view is already an overlay it is moved to the top.
+func ShowOverlay(w *window.Window, iv IView) {
+
+	ShowOverlayEx(w, iv, 0)
+}
+
+// ShowOverlayEx shows the specified view above the top view of the window and its other overlays
+// with the specified flags. The keyboard focus is moved to the first focusable view of
+// modal overlays and restored when they are closed.
+func ShowOverlayEx(w *window.Window, iv IView, flags OverlayFlags) {
+
+	CloseOverlay(w, iv)
+	l := getOverlayLayer(w)
+	ov := &overlay{view: iv, flags: flags}
+	l.stack = append(l.stack, ov)
+	if flags&OverlayModal != 0 {
+		ov.prevFocus = Focused(w)
+		SetFocus(w, firstFocusable(iv))
+	}
+}
+
+// ShowPopup shows the specified view as an overlay closed when a mouse button is pressed outside
+// of it and placed at the specified side of the anchor rectangle, in window coordinates.
+// The popup is placed at the opposite side when it does not fit in the window and then moved
+// as necessary to stay inside the window.
+func ShowPopup(w *window.Window, iv IView, anchor gb.Rect, placement Placement) {
+
+	ShowOverlayEx(w, iv, OverlayCloseOnOutside)
+	SetOverlayAnchor(w, iv, anchor, placement)
+}
+
+// ShowModal shows the specified view as a modal dialog centered in the window
+func ShowModal(w *window.Window, iv IView) {
+
+	ShowOverlayEx(w, iv, OverlayModal|OverlayCentered)
+}
+
+// SetOverlayAnchor positions the specified overlay view at the specified side of the anchor
+// rectangle, in window coordinates, flipping to the opposite side when it does not fit in the window
+func SetOverlayAnchor(w *window.Window, iv IView, anchor gb.Rect, placement Placement) {
+
+	if ov := getOverlayLayer(w).find(iv); ov != nil {
+		ov.anchored = true
+		ov.anchor = anchor
+		ov.placement = placement
+	}
+}
+
+// OnOverlayClose sets the function called when the specified overlay view is closed
+// by CloseOverlay() or by pressing a mouse button outside of it
+func OnOverlayClose(w *window.Window, iv IView, cb func()) {
+
+	if ov := getOverlayLayer(w).find(iv); ov != nil {
+		ov.onClose = cb
+	}
+}
+
+// CloseOverlay removes the specified view from the overlays of the window.
+// Returns false if the view was not an overlay.
+func CloseOverlay(w *window.Window, iv IView) bool {
+
+	l := getOverlayLayer(w)
+	for i, ov := range l.stack {
+		if ov.view != iv {
+			continue
+		}
+		l.stack = append(l.stack[:i], l.stack[i+1:]...)
+		if ov.flags&OverlayModal != 0 && isDescendant(Focused(w), iv) {
+			SetFocus(w, ov.prevFocus)
+		}
+		if ov.onClose != nil {
+			ov.onClose()
+		}
+		return true
+	}
+	return false
+}
+
+// IsOverlay returns if the specified view is an overlay of the window
+func IsOverlay(w *window.Window, iv IView) bool {
+
+	return getOverlayLayer(w).find(iv) != nil
+}
+
+// Overlays returns the overlay views of the window from bottom to top
+func Overlays(w *window.Window) []IView {
+
+	l := getOverlayLayer(w)
+	views := make([]IView, len(l.stack))
+	for i, ov := range l.stack {
+		views[i] = ov.view
+	}
+	return views
+}
+
+// RenderOverlays renders the overlay views of the window, dimming the views below modal overlays.
+// It must be called after rendering the window top view.
+func RenderOverlays(w *window.Window) {
+
+	l := getOverlayLayer(w)
+	modal := l.topModal()
+	for i, ov := range l.stack {
+		v := ov.view.GetView()
+		if !v.visible {
+			continue
+		}
+		if i == modal {
+			w.AddRectFilled(w.DrawList(), gb.Vec2{}, w.Size(), v.StyleColor(w, StyleColorModalDim).RGBA(), 0, 0)
+		}
+		ov.view.Render(w)
+	}
+}
+
+// PlaceRect returns the position of a rectangle with the specified size placed at the specified
+// side of the anchor rectangle, flipped to the opposite side if it does not fit inside the bounds
+// rectangle but fits there, and then moved as necessary to stay inside the bounds.
+func PlaceRect(anchor gb.Rect, size gb.Vec2, bounds gb.Rect, placement Placement) gb.Vec2 {
+
+	var pos gb.Vec2
+	switch placement {
+	case PlaceBelow, PlaceAbove:
+		pos.X = anchor.Min.X
+		below := anchor.Max.Y
+		above := anchor.Min.Y - size.Y
+		fitsBelow := below+size.Y <= bounds.Max.Y
+		fitsAbove := above >= bounds.Min.Y
+		if (placement == PlaceBelow && (fitsBelow || !fitsAbove)) || (placement == PlaceAbove && !fitsAbove && fitsBelow) {
+			pos.Y = below
+		} else {
+			pos.Y = above
+		}
+	default:
+		pos.Y = anchor.Min.Y
+		right := anchor.Max.X
+		left := anchor.Min.X - size.X
+		fitsRight := right+size.X <= bounds.Max.X
+		fitsLeft := left >= bounds.Min.X
+		if (placement == PlaceRight && (fitsRight || !fitsLeft)) || (placement == PlaceLeft && !fitsLeft && fitsRight) {
+			pos.X = right
+		} else {
+			pos.X = left
+		}
+	}
+	pos.X = util.Max(util.Min(pos.X, bounds.Max.X-size.X), bounds.Min.X)
+	pos.Y = util.Max(util.Min(pos.Y, bounds.Max.Y-size.Y), bounds.Min.Y)
+	return pos
+}
+
+// layoutOverlays measures and arranges the overlay views of the window
+func layoutOverlays(w *window.Window) {
+
+	var ident gb.Mat3
+	ident.Identity()
+	wsize := w.Size()
+	bounds := gb.Rect{Max: wsize}
+	for _, ov := range getOverlayLayer(w).stack {
+		v := ov.view.GetView()
+		if !v.visible {
+			continue
+		}
+		size := MeasureChild(w, ov.view, wsize)
+		pos := v.pos
+		switch {
+		case ov.anchored:
+			pos = PlaceRect(ov.anchor, size, bounds, ov.placement)
+		case ov.flags&OverlayCentered != 0:
+			pos = gb.Vec2{(wsize.X - size.X) / 2, (wsize.Y - size.Y) / 2}
+		}
+		ArrangeChild(w, ov.view, pos, size)
+		ov.view.SetTransform(&ident)
+	}
+}
+
+// closeOutside closes the overlays which close on outside presses, from the top down to the first
+// overlay containing the specified point in window coordinates or the first modal overlay
+func closeOutside(w *window.Window, p gb.Vec2) {
+
+	l := getOverlayLayer(w)
+	for i := len(l.stack) - 1; i >= 0 && i < len(l.stack); i-- {
+		ov := l.stack[i]
+		if ov.flags&OverlayPassThrough != 0 {
+			continue
+		}
+		if ov.view.GetView().visible && hitTest(ov.view, p) != nil {
+			return
+		}
+		if ov.flags&OverlayCloseOnOutside != 0 {
+			CloseOverlay(w, ov.view)
+		}
+		if ov.flags&OverlayModal != 0 {
+			return
+		}
+	}
+}
+
+// hitTestWindow returns the deepest view under the specified point in window coordinates
+// testing the overlays of the window from top to bottom before its top view.
+// Returns nil if the point is blocked by a modal overlay.
+func hitTestWindow(w *window.Window, root IView, p gb.Vec2) IView {
+
+	l := getOverlayLayer(w)
+	for i := len(l.stack) - 1; i >= 0; i-- {
+		if l.stack[i].flags&OverlayPassThrough != 0 {
+			continue
+		}
+		if hit := hitTest(l.stack[i].view, p); hit != nil {
+			return hit
+		}
+		if l.stack[i].flags&OverlayModal != 0 {
+			return nil
+		}
+	}
+	if root == nil {
+		return nil
+	}
+	return hitTest(root, p)
+}
+
+// modalBlocks returns if the specified view is below the top modal overlay of the window
+func modalBlocks(w *window.Window, iv IView) bool {
+
+	l := getOverlayLayer(w)
+	modal := l.topModal()
+	if modal < 0 {
+		return false
+	}
+	for _, ov := range l.stack[modal:] {
+		if isDescendant(iv, ov.view) {
+			return false
+		}
+	}
+	return true
+}
+
+// topModal returns the index of the top modal overlay or -1 if none
+func (l *overlayLayer) topModal() int {
+
+	for i := len(l.stack) - 1; i >= 0; i-- {
+		if l.stack[i].flags&OverlayModal != 0 && l.stack[i].view.GetView().visible {
+			return i
+		}
+	}
+	return -1
+}
+
+// find returns the overlay with the specified view or nil
+func (l *overlayLayer) find(iv IView) *overlay {
+
+	for _, ov := range l.stack {
+		if ov.view == iv {
+			return ov
+		}
+	}
+	return nil
+}
+
+// isDescendant returns if the specified view is the ancestor view or one of its descendants
+func isDescendant(iv, ancestor IView) bool {
+
+	for ; iv != nil; iv = iv.GetView().parent {
+		if iv == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// firstFocusable returns the first visible, enabled and focusable view of the tree
+// with the specified top view in depth first order or nil if none found
+func firstFocusable(iv IView) IView {
+
+	v := iv.GetView()
+	if !v.visible || v.disabled {
+		return nil
+	}
+	if v.focusable {
+		return iv
+	}
+	for _, c := range v.children {
+		if f := firstFocusable(c); f != nil {
+			return f
+		}
+	}
+	return nil
+}
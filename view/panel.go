@@ -0,0 +1,49 @@
+package view
+
+import (
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/window"
+)
+
+// Default padding of panels
+const panelPadding = 8
+
+// Panel is a Stack which draws a background with a border behind its children.
+// It is the surface of popups and dialogs shown as overlays.
+type Panel struct {
+	Stack
+	bg StyleColorType // Background color
+}
+
+// NewPanel creates and returns a new empty Panel
+func NewPanel() *Panel {
+
+	p := new(Panel)
+	p.Init(p)
+	p.bg = StyleColorPanel
+	p.halign = AlignStretch
+	p.valign = AlignStretch
+	p.padding = Insets{panelPadding, panelPadding, panelPadding, panelPadding}
+	return p
+}
+
+// Add appends the specified child views and returns this Panel
+func (p *Panel) Add(children ...IView) *Panel {
+
+	p.View.Add(children...)
+	return p
+}
+
+// Render satisfies the IView interface
+func (p *Panel) Render(w *window.Window) {
+
+	if !p.visible {
+		return
+	}
+	dl := p.BeginRender()
+	rounding := p.StyleFrameRounding(w)
+	w.AddRectFilled(dl, gb.Vec2{}, p.size, p.StyleColor(w, p.bg).RGBA(), rounding, window.DrawFlags_RoundCornersAll)
+	w.AddRect(dl, gb.Vec2{}, p.size, p.StyleColor(w, StyleColorBorder).RGBA(), rounding, window.DrawFlags_RoundCornersAll, 1)
+	p.EndRender(w)
+	p.RenderChildren(w)
+}
@@ -0,0 +1,116 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// Number of font heights of the desired progress bar length
+const progressDefaultLength = 10
+
+// ProgressBar is a view which shows the progress of an operation as a bar filled from
+// the left, or from the bottom for vertical bars, optionally with the percentage as text.
+type ProgressBar struct {
+	View
+	orientation Orientation          // Bar orientation
+	ff          window.FontStyleType // Font of the percentage text
+	value       float32              // Progress between 0 and 1
+	showText    bool                 // Shows the percentage text
+}
+
+// NewProgressBar creates and returns a new ProgressBar with the specified orientation
+func NewProgressBar(orientation Orientation) *ProgressBar {
+
+	p := new(ProgressBar)
+	p.Init(p)
+	p.orientation = orientation
+	p.ff = window.FontRegular
+	p.padding = InsetsXY(4, 2)
+	return p
+}
+
+// Orientation returns the progress bar orientation
+func (p *ProgressBar) Orientation() Orientation {
+
+	return p.orientation
+}
+
+// SetValue sets the progress, clamped between 0 and 1
+func (p *ProgressBar) SetValue(value float32) {
+
+	p.value = util.Clamp(value, 0, 1)
+}
+
+// Value returns the progress between 0 and 1
+func (p *ProgressBar) Value() float32 {
+
+	return p.value
+}
+
+// SetShowText sets if the progress percentage is shown as text centered in the bar
+func (p *ProgressBar) SetShowText(show bool) {
+
+	p.showText = show
+}
+
+// ShowText returns if the progress percentage is shown as text
+func (p *ProgressBar) ShowText() bool {
+
+	return p.showText
+}
+
+// Measure satisfies the IView interface
+func (p *ProgressBar) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	fa := w.Font(p.ff, 0)
+	h := fa.Height()
+	size := gb.Vec2{h * progressDefaultLength, h}
+	if p.orientation == Vertical {
+		size = gb.Vec2{util.Max(h, fa.MeasureString("100%")), h * progressDefaultLength}
+	}
+	size.Add(p.padding.Size())
+	return p.ConstrainSize(size)
+}
+
+// Render satisfies the IView interface
+func (p *ProgressBar) Render(w *window.Window) {
+
+	if !p.visible {
+		return
+	}
+	dl := p.BeginRender()
+	rounding := p.StyleFrameRounding(w)
+	w.AddRectFilled(dl, gb.Vec2{}, p.size, p.StyleColor(w, StyleColorFrame).RGBA(), rounding, window.DrawFlags_RoundCornersAll)
+
+	// Draws the filled part clipped to the bar so its rounded corners are kept when partially filled
+	fill := p.StyleColor(w, StyleColorProgress).RGBA()
+	if p.disabled {
+		fill = p.StyleColor(w, StyleColorButtonDisabled).RGBA()
+	}
+	filled := gb.Rect{Max: gb.Vec2{p.size.X * p.value, p.size.Y}}
+	if p.orientation == Vertical {
+		filled = gb.Rect{Min: gb.Vec2{0, p.size.Y * (1 - p.value)}, Max: p.size}
+	}
+	if p.value > 0 {
+		w.PushClipRect(p.WindowRect(filled))
+		w.AddRectFilled(dl, gb.Vec2{}, p.size, fill, rounding, window.DrawFlags_RoundCornersAll)
+		w.PopClipRect()
+	}
+	w.AddRect(dl, gb.Vec2{}, p.size, p.StyleColor(w, StyleColorBorder).RGBA(), rounding, window.DrawFlags_RoundCornersAll, 1)
+
+	// Draws the percentage text centered in the bar
+	if p.showText {
+		fa := w.Font(p.ff, 0)
+		text := fmt.Sprintf("%d%%", int(p.value*100))
+		pos := gb.Vec2{(p.size.X - fa.MeasureString(text)) / 2, (p.size.Y - fa.Height()) / 2}
+		col := StyleColorText
+		if p.disabled {
+			col = StyleColorTextDisabled
+		}
+		w.AddText(dl, fa, &pos, p.StyleColor(w, col).RGBA(), window.TextVAlignTop, text)
+	}
+	p.EndRender(w)
+}
@@ -0,0 +1,194 @@
+package view
+
+import (
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/window"
+)
+
+// RadioButton is a boolean input view with a circular indicator and a label.
+// Radio buttons added to the same RadioGroup are mutually exclusive.
+type RadioButton struct {
+	toggleView
+	checked  bool               // Current state
+	group    *RadioGroup        // Optional group
+	onChange func(*RadioButton) // Change callback
+}
+
+// RadioGroup keeps at most one of its radio buttons checked.
+// The radio buttons can be in any position of the view tree.
+type RadioGroup struct {
+	buttons  []*RadioButton    // Radio buttons in this group
+	onChange func(*RadioGroup) // Change callback
+}
+
+// NewRadioButton creates and returns a new unchecked RadioButton with the specified label
+func NewRadioButton(text string) *RadioButton {
+
+	rb := new(RadioButton)
+	rb.initToggle(rb, text, func() { rb.SetChecked(true) })
+	return rb
+}
+
+// SetChecked sets the radio button state, calling the change callbacks if it changed.
+// Checking a radio button of a group unchecks the other radio buttons of the group.
+func (rb *RadioButton) SetChecked(checked bool) {
+
+	if checked == rb.checked {
+		return
+	}
+	if checked && rb.group != nil {
+		for _, other := range rb.group.buttons {
+			if other != rb {
+				other.SetChecked(false)
+			}
+		}
+	}
+	rb.checked = checked
+	if rb.onChange != nil {
+		rb.onChange(rb)
+	}
+	if checked && rb.group != nil && rb.group.onChange != nil {
+		rb.group.onChange(rb.group)
+	}
+}
+
+// Checked returns if the radio button is checked
+func (rb *RadioButton) Checked() bool {
+
+	return rb.checked
+}
+
+// Group returns the group of this radio button or nil
+func (rb *RadioButton) Group() *RadioGroup {
+
+	return rb.group
+}
+
+// OnChange sets the function called when the radio button state changes
+func (rb *RadioButton) OnChange(cb func(rb *RadioButton)) {
+
+	rb.onChange = cb
+}
+
+// Measure satisfies the IView interface
+func (rb *RadioButton) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	side := indicatorSide(w.Font(rb.ff, 0))
+	return rb.measureToggle(w, gb.Vec2{side, side})
+}
+
+// Render satisfies the IView interface
+func (rb *RadioButton) Render(w *window.Window) {
+
+	if !rb.visible {
+		return
+	}
+	dl := rb.BeginRender()
+	side := indicatorSide(w.Font(rb.ff, 0))
+	r := rb.indicatorRect(gb.Vec2{side, side})
+	center := gb.Vec2{r.Min.X + side/2, r.Min.Y + side/2}
+	radius := side / 2
+	segments := 32
+
+	w.AddCircleFilled(dl, center, radius, rb.frameColor(w), segments)
+	w.AddCircle(dl, center, radius, rb.StyleColor(w, StyleColorBorder).RGBA(), segments, 1)
+	if rb.checked {
+		w.AddCircleFilled(dl, center, radius*0.5, rb.markColor(w), segments)
+	}
+	if rb.HasFocus(w) && !rb.disabled {
+		w.AddCircle(dl, center, radius, rb.StyleColor(w, StyleColorFocus).RGBA(), segments, 2)
+	}
+	rb.renderLabel(w, dl, side)
+	rb.EndRender(w)
+}
+
+// NewRadioGroup creates and returns a new RadioGroup with the specified radio buttons
+func NewRadioGroup(buttons ...*RadioButton) *RadioGroup {
+
+	g := new(RadioGroup)
+	g.Add(buttons...)
+	return g
+}
+
+// Add adds the specified radio buttons to this group, removing them from their previous groups.
+// If more than one of the buttons is checked only the last checked one is kept checked.
+func (g *RadioGroup) Add(buttons ...*RadioButton) *RadioGroup {
+
+	for _, rb := range buttons {
+		if rb.group != nil {
+			rb.group.Remove(rb)
+		}
+		rb.group = g
+		g.buttons = append(g.buttons, rb)
+		if rb.checked {
+			for _, other := range g.buttons {
+				if other != rb {
+					other.SetChecked(false)
+				}
+			}
+		}
+	}
+	return g
+}
+
+// Remove removes the specified radio button from this group.
+// Returns false if the radio button was not found.
+func (g *RadioGroup) Remove(rb *RadioButton) bool {
+
+	for i, b := range g.buttons {
+		if b == rb {
+			copy(g.buttons[i:], g.buttons[i+1:])
+			g.buttons[len(g.buttons)-1] = nil
+			g.buttons = g.buttons[:len(g.buttons)-1]
+			rb.group = nil
+			return true
+		}
+	}
+	return false
+}
+
+// Buttons returns the radio buttons of this group
+func (g *RadioGroup) Buttons() []*RadioButton {
+
+	return g.buttons
+}
+
+// Selected returns the checked radio button of this group or nil
+func (g *RadioGroup) Selected() *RadioButton {
+
+	for _, rb := range g.buttons {
+		if rb.checked {
+			return rb
+		}
+	}
+	return nil
+}
+
+// SelectedIndex returns the index of the checked radio button of this group or -1
+func (g *RadioGroup) SelectedIndex() int {
+
+	for i, rb := range g.buttons {
+		if rb.checked {
+			return i
+		}
+	}
+	return -1
+}
+
+// Select checks the radio button with the specified index, or unchecks all if the index is invalid
+func (g *RadioGroup) Select(index int) {
+
+	if index >= 0 && index < len(g.buttons) {
+		g.buttons[index].SetChecked(true)
+		return
+	}
+	for _, rb := range g.buttons {
+		rb.SetChecked(false)
+	}
+}
+
+// OnChange sets the function called when a radio button of this group is checked
+func (g *RadioGroup) OnChange(cb func(g *RadioGroup)) {
+
+	g.onChange = cb
+}
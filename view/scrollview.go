@@ -0,0 +1,559 @@
+package view
+
+import (
+	"math"
+	"time"
+
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// Number of font heights scrolled by each mouse wheel step
+const scrollWheelLines = 3
+
+// Minimum length of scrollbar thumbs
+const scrollThumbMinLength = 20
+
+// Deceleration rate of kinetic scrolling per second
+const scrollFriction = 6
+
+// Speed in pixels per second below which kinetic scrolling stops
+const scrollMinSpeed = 10
+
+// ScrollPolicy specifies how a ScrollView scrolls its content along an axis
+type ScrollPolicy int
+
+const (
+	ScrollAuto     ScrollPolicy = iota // Scrolls showing the scrollbar only if the content is larger than the view
+	ScrollAlways                       // Scrolls always showing the scrollbar
+	ScrollHidden                       // Scrolls without showing the scrollbar
+	ScrollDisabled                     // Does not scroll: the content is sized to the view along this axis
+)
+
+// ScrollView shows a part of a content view which can be larger than itself.
+// The content is clipped to the view and scrolled by the mouse wheel (Shift scrolls horizontally)
+// and by dragging the scrollbars or clicking on their tracks.
+// Wheel events which would not scroll the view are passed to its parents, so scroll views can be nested.
+// With kinetic scrolling enabled the wheel scrolling is smooth and the content can be dragged
+// with the left mouse button and keeps moving after it is released.
+type ScrollView struct {
+	View
+	content    IView             // Content view (maybe nil)
+	policy     [2]ScrollPolicy   // Scroll policy for each axis
+	offset     gb.Vec2           // Current scroll offset
+	viewport   gb.Rect           // Rectangle in local coordinates where the content is shown
+	csize      gb.Vec2           // Size of the content including its margins
+	showBar    [2]bool           // Scrollbars are visible
+	kinetic    bool              // Kinetic scrolling enabled
+	velocity   gb.Vec2           // Kinetic scrolling velocity in pixels per second
+	panning    bool              // Content is being dragged
+	panPos     gb.Vec2           // Last cursor position while panning in window coordinates
+	panTime    time.Time         // Time of the last panning move
+	dragAxis   int               // Axis of the scrollbar being dragged (-1 if none)
+	dragStart  float32           // Cursor position along the axis when the scrollbar drag started
+	dragOffset float32           // Scroll offset along the axis when the scrollbar drag started
+	hoverAxis  int               // Axis of the scrollbar thumb under the cursor (-1 if none)
+	header     float32           // Height reserved above the viewport by derived views for a fixed header
+	onScroll   func(*ScrollView) // Scroll offset change callback
+}
+
+// NewScrollView creates and returns a new ScrollView with the specified content view, which can be nil
+func NewScrollView(content IView) *ScrollView {
+
+	s := new(ScrollView)
+	s.initScrollView(s, content)
+	return s
+}
+
+// initScrollView initializes the ScrollView base of the specified IView
+func (s *ScrollView) initScrollView(iv IView, content IView) {
+
+	s.Init(iv)
+	s.clipChild = true
+	s.dragAxis = -1
+	s.hoverAxis = -1
+	s.SetContent(content)
+}
+
+// SetContent sets the content view replacing the previous one
+func (s *ScrollView) SetContent(content IView) {
+
+	s.RemoveAll()
+	s.content = content
+	s.offset = gb.Vec2{}
+	s.velocity = gb.Vec2{}
+	if content != nil {
+		s.View.Add(content)
+	}
+}
+
+// Content returns the content view
+func (s *ScrollView) Content() IView {
+
+	return s.content
+}
+
+// SetPolicy sets the scroll policies of the horizontal and vertical axes.
+// The default is ScrollAuto for both.
+func (s *ScrollView) SetPolicy(horizontal, vertical ScrollPolicy) {
+
+	s.policy = [2]ScrollPolicy{horizontal, vertical}
+}
+
+// Policy returns the scroll policies of the horizontal and vertical axes
+func (s *ScrollView) Policy() (ScrollPolicy, ScrollPolicy) {
+
+	return s.policy[0], s.policy[1]
+}
+
+// SetKinetic sets if kinetic scrolling is enabled
+func (s *ScrollView) SetKinetic(kinetic bool) {
+
+	s.kinetic = kinetic
+	if !kinetic {
+		s.velocity = gb.Vec2{}
+	}
+}
+
+// Kinetic returns if kinetic scrolling is enabled
+func (s *ScrollView) Kinetic() bool {
+
+	return s.kinetic
+}
+
+// SetOffset sets the scroll offset, which is clamped to the valid range in the next render
+func (s *ScrollView) SetOffset(x, y float32) {
+
+	s.velocity = gb.Vec2{}
+	prev := s.offset
+	s.offset = gb.Vec2{x, y}
+	// Before the first layout the offset is clamped by Arrange()
+	if !s.viewport.Empty() {
+		s.clampOffset()
+	}
+	if s.offset != prev && s.onScroll != nil {
+		s.onScroll(s)
+	}
+}
+
+// Offset returns the current scroll offset
+func (s *ScrollView) Offset() gb.Vec2 {
+
+	return s.offset
+}
+
+// MaxOffset returns the maximum scroll offset for the last layout
+func (s *ScrollView) MaxOffset() gb.Vec2 {
+
+	vsize := s.viewport.Size()
+	return gb.Vec2{util.Max(s.csize.X-vsize.X, 0), util.Max(s.csize.Y-vsize.Y, 0)}
+}
+
+// Viewport returns the rectangle in local coordinates where the content is shown
+func (s *ScrollView) Viewport() gb.Rect {
+
+	return s.viewport
+}
+
+// EnsureVisible scrolls the minimum necessary to show the specified rectangle
+// in content coordinates, showing its top left corner if it does not fit.
+func (s *ScrollView) EnsureVisible(r gb.Rect) {
+
+	vsize := s.viewport.Size()
+	offset := s.offset
+	for axis := 0; axis < 2; axis++ {
+		min, max, size := vecAxis(r.Min, axis), vecAxis(r.Max, axis), vecAxis(vsize, axis)
+		off := vecAxis(offset, axis)
+		if max > off+size {
+			off = max - size
+		}
+		if min < off {
+			off = min
+		}
+		setVecAxis(&offset, axis, off)
+	}
+	s.SetOffset(offset.X, offset.Y)
+}
+
+// OnScroll sets the function called when the scroll offset changes
+func (s *ScrollView) OnScroll(cb func(s *ScrollView)) {
+
+	s.onScroll = cb
+}
+
+// Measure satisfies the IView interface
+func (s *ScrollView) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	size := s.padding.Size()
+	size.Y += s.header
+	if s.content != nil {
+		inner := gb.Vec2{avail.X - s.padding.Horizontal(), avail.Y - s.padding.Vertical()}
+		for axis := 0; axis < 2; axis++ {
+			if s.policy[axis] != ScrollDisabled {
+				setVecAxis(&inner, axis, Unlimited)
+			}
+		}
+		d := MeasureChild(w, s.content, inner)
+		m := s.content.GetView().margin
+		size.X += d.X + m.Horizontal()
+		size.Y += d.Y + m.Vertical()
+	}
+	return s.ConstrainSize(size)
+}
+
+// Arrange satisfies the IView interface
+func (s *ScrollView) Arrange(w *window.Window, pos gb.Vec2, size gb.Vec2) {
+
+	s.View.Arrange(w, pos, size)
+	content := s.ContentRect()
+	content.Min.Y = util.Min(content.Min.Y+s.header, content.Max.Y)
+	s.viewport = content
+	s.showBar = [2]bool{}
+	s.csize = gb.Vec2{}
+	if s.content == nil {
+		s.childClip = s.viewport
+		return
+	}
+	cv := s.content.GetView()
+	margins := cv.margin.Size()
+	bar := s.StyleScrollbarSize(w)
+
+	// Decides which scrollbars are shown. Showing one scrollbar reduces the viewport
+	// and may require the other one, so it is checked twice.
+	desired := gb.Vec2{cv.desired.X + margins.X, cv.desired.Y + margins.Y}
+	for pass := 0; pass < 2; pass++ {
+		vsize := s.viewport.Size()
+		for axis := 0; axis < 2; axis++ {
+			switch s.policy[axis] {
+			case ScrollAlways:
+				s.showBar[axis] = true
+			case ScrollAuto:
+				s.showBar[axis] = vecAxis(desired, axis) > vecAxis(vsize, axis)
+			}
+		}
+		s.viewport = content
+		if s.showBar[1] {
+			s.viewport.Max.X = util.Max(s.viewport.Max.X-bar, s.viewport.Min.X)
+		}
+		if s.showBar[0] {
+			s.viewport.Max.Y = util.Max(s.viewport.Max.Y-bar, s.viewport.Min.Y)
+		}
+	}
+	vsize := s.viewport.Size()
+
+	// Measures the content again if an axis does not scroll and the viewport is smaller than the view
+	avail := gb.Vec2{Unlimited, Unlimited}
+	remeasure := false
+	for axis := 0; axis < 2; axis++ {
+		if s.policy[axis] == ScrollDisabled {
+			setVecAxis(&avail, axis, vecAxis(vsize, axis))
+			remeasure = remeasure || vecAxis(vsize, axis) != vecAxis(content.Size(), axis)
+		}
+	}
+	if remeasure {
+		MeasureChild(w, s.content, avail)
+		desired = gb.Vec2{cv.desired.X + margins.X, cv.desired.Y + margins.Y}
+	}
+
+	// The content fills at least the viewport along each axis
+	s.csize = desired
+	for axis := 0; axis < 2; axis++ {
+		if s.policy[axis] == ScrollDisabled || vecAxis(s.csize, axis) < vecAxis(vsize, axis) {
+			setVecAxis(&s.csize, axis, vecAxis(vsize, axis))
+		}
+	}
+	s.childClip = s.viewport
+	s.clampOffset()
+	ArrangeChild(w, s.content, s.contentPos(), gb.Vec2{s.csize.X - margins.X, s.csize.Y - margins.Y})
+}
+
+// OnEvent satisfies the IView interface
+func (s *ScrollView) OnEvent(w *window.Window, ev *Event) bool {
+
+	switch ev.Type {
+	case EventScroll:
+		return s.onWheel(w, ev)
+	case EventMouseMove:
+		if s.dragAxis >= 0 {
+			s.dragBar(ev.Pos)
+			return true
+		}
+		if s.panning {
+			s.pan(w, ev.WinPos)
+			return true
+		}
+		s.hoverAxis = -1
+		if axis, thumb := s.barAt(ev.Pos); axis >= 0 && thumb {
+			s.hoverAxis = axis
+		}
+	case EventMouseLeave:
+		s.hoverAxis = -1
+	case EventMouseDown:
+		if ev.Button != gb.MouseButtonLeft || s.disabled {
+			return false
+		}
+		s.velocity = gb.Vec2{}
+		axis, thumb := s.barAt(ev.Pos)
+		if axis >= 0 {
+			if thumb {
+				s.dragAxis = axis
+				s.dragStart = vecAxis(ev.Pos, axis)
+				s.dragOffset = vecAxis(s.offset, axis)
+			} else {
+				s.pageTowards(axis, ev.Pos)
+			}
+			return true
+		}
+		if s.kinetic && s.viewport.Contains(ev.Pos) {
+			s.panning = true
+			s.panPos = ev.WinPos
+			s.panTime = w.FrameTime()
+			return true
+		}
+	case EventMouseUp:
+		if ev.Button != gb.MouseButtonLeft {
+			return false
+		}
+		if s.dragAxis >= 0 {
+			s.dragAxis = -1
+			return true
+		}
+		if s.panning {
+			s.panning = false
+			// Keeps the velocity only if the content was moving when released
+			if time.Since(s.panTime).Seconds() > 0.1 {
+				s.velocity = gb.Vec2{}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Render satisfies the IView interface
+func (s *ScrollView) Render(w *window.Window) {
+
+	if !s.visible {
+		return
+	}
+
+	// Moves the content with the kinetic scrolling velocity, requesting frames until it stops
+	if s.velocity != (gb.Vec2{}) && !s.panning {
+		dt := util.Min(w.FrameDelta(), 0.1)
+		prev := s.offset
+		s.setOffset(gb.Vec2{s.offset.X + s.velocity.X*dt, s.offset.Y + s.velocity.Y*dt})
+		decay := float32(math.Exp(-scrollFriction * float64(dt)))
+		s.velocity = gb.Vec2{s.velocity.X * decay, s.velocity.Y * decay}
+		if s.offset == prev || s.velocity.Length() < scrollMinSpeed {
+			s.velocity = gb.Vec2{}
+		} else {
+			w.RequestFrame(0)
+		}
+	}
+
+	// Renders the content clipped to the viewport
+	if s.content != nil && s.content.GetView().visible {
+		cpos := s.contentPos()
+		s.content.SetPos(cpos.X, cpos.Y)
+		w.PushClipRect(s.WindowRect(s.viewport))
+		s.RenderChildren(w)
+		w.PopClipRect()
+	}
+
+	// Draws the scrollbars over the content
+	dl := s.BeginRender()
+	for axis := 0; axis < 2; axis++ {
+		if !s.showBar[axis] {
+			continue
+		}
+		track, thumb := s.barGeometry(axis)
+		w.AddRectFilled(dl, track.Min, track.Max, s.StyleColor(w, StyleColorScrollbar).RGBA(), 0, 0)
+		col := StyleColorScrollbarThumb
+		if s.dragAxis == axis {
+			col = StyleColorScrollbarThumbActive
+		} else if s.hoverAxis == axis {
+			col = StyleColorScrollbarThumbHovered
+		}
+		rounding := util.Min(thumb.Size().X, thumb.Size().Y) / 2
+		w.AddRectFilled(dl, thumb.Min, thumb.Max, s.StyleColor(w, col).RGBA(), rounding, window.DrawFlags_RoundCornersAll)
+	}
+	s.EndRender(w)
+}
+
+// onWheel processes a mouse wheel event returning false if the view cannot scroll in its direction
+func (s *ScrollView) onWheel(w *window.Window, ev *Event) bool {
+
+	delta := ev.Scroll
+	if ev.Mods&gb.ModShift != 0 {
+		delta = gb.Vec2{delta.Y, delta.X}
+	}
+	// Checks if the view can scroll in the wheel direction along any axis
+	maxOffset := s.MaxOffset()
+	canScroll := false
+	for axis := 0; axis < 2; axis++ {
+		d := vecAxis(delta, axis)
+		off := vecAxis(s.offset, axis)
+		if s.policy[axis] == ScrollDisabled || d == 0 {
+			setVecAxis(&delta, axis, 0)
+			continue
+		}
+		if (d > 0 && off > 0) || (d < 0 && off < vecAxis(maxOffset, axis)) {
+			canScroll = true
+		}
+	}
+	if !canScroll {
+		return false
+	}
+	step := w.Font(window.FontRegular, 0).Height() * scrollWheelLines
+	if s.kinetic {
+		// Adds the velocity which travels one step until it stops
+		s.velocity.X -= delta.X * step * scrollFriction
+		s.velocity.Y -= delta.Y * step * scrollFriction
+		w.RequestFrame(0)
+		return true
+	}
+	s.setOffset(gb.Vec2{s.offset.X - delta.X*step, s.offset.Y - delta.Y*step})
+	return true
+}
+
+// pan moves the content following the cursor, updating the kinetic scrolling velocity
+func (s *ScrollView) pan(w *window.Window, pos gb.Vec2) {
+
+	delta := gb.Vec2{s.panPos.X - pos.X, s.panPos.Y - pos.Y}
+	for axis := 0; axis < 2; axis++ {
+		if s.policy[axis] == ScrollDisabled {
+			setVecAxis(&delta, axis, 0)
+		}
+	}
+	s.panPos = pos
+	now := time.Now()
+	if dt := float32(now.Sub(s.panTime).Seconds()); dt > 0 {
+		s.velocity = gb.Vec2{delta.X / dt, delta.Y / dt}
+	}
+	s.panTime = now
+	s.setOffset(gb.Vec2{s.offset.X + delta.X, s.offset.Y + delta.Y})
+}
+
+// dragBar scrolls the content following the cursor while a scrollbar thumb is dragged
+func (s *ScrollView) dragBar(pos gb.Vec2) {
+
+	axis := s.dragAxis
+	trackLen := vecAxis(s.viewport.Size(), axis)
+	thumbLen := s.thumbLength(axis)
+	maxOff := vecAxis(s.MaxOffset(), axis)
+	if trackLen <= thumbLen {
+		return
+	}
+	off := s.offset
+	setVecAxis(&off, axis, s.dragOffset+(vecAxis(pos, axis)-s.dragStart)*maxOff/(trackLen-thumbLen))
+	s.setOffset(off)
+}
+
+// pageTowards scrolls one viewport size along the specified axis towards the specified point
+func (s *ScrollView) pageTowards(axis int, pos gb.Vec2) {
+
+	_, thumb := s.barGeometry(axis)
+	page := vecAxis(s.viewport.Size(), axis)
+	off := s.offset
+	if vecAxis(pos, axis) < vecAxis(thumb.Min, axis) {
+		page = -page
+	}
+	setVecAxis(&off, axis, vecAxis(off, axis)+page)
+	s.setOffset(off)
+}
+
+// barAt returns the axis of the scrollbar at the specified local point (-1 if none)
+// and if the point is over its thumb
+func (s *ScrollView) barAt(pos gb.Vec2) (int, bool) {
+
+	for axis := 0; axis < 2; axis++ {
+		if !s.showBar[axis] {
+			continue
+		}
+		track, thumb := s.barGeometry(axis)
+		if track.Contains(pos) {
+			return axis, thumb.Contains(pos)
+		}
+	}
+	return -1, false
+}
+
+// barGeometry returns the track and thumb rectangles of the scrollbar of the specified axis
+func (s *ScrollView) barGeometry(axis int) (gb.Rect, gb.Rect) {
+
+	content := s.ContentRect()
+	var track gb.Rect
+	if axis == 0 {
+		track = gb.Rect{Min: gb.Vec2{s.viewport.Min.X, s.viewport.Max.Y}, Max: gb.Vec2{s.viewport.Max.X, content.Max.Y}}
+	} else {
+		track = gb.Rect{Min: gb.Vec2{s.viewport.Max.X, s.viewport.Min.Y}, Max: gb.Vec2{content.Max.X, s.viewport.Max.Y}}
+	}
+	trackLen := vecAxis(track.Size(), axis)
+	thumbLen := s.thumbLength(axis)
+	maxOff := vecAxis(s.MaxOffset(), axis)
+	start := vecAxis(track.Min, axis)
+	if maxOff > 0 {
+		start += (trackLen - thumbLen) * vecAxis(s.offset, axis) / maxOff
+	}
+	thumb := track
+	setVecAxis(&thumb.Min, axis, start)
+	setVecAxis(&thumb.Max, axis, start+thumbLen)
+	return track, thumb
+}
+
+// thumbLength returns the length of the scrollbar thumb of the specified axis
+func (s *ScrollView) thumbLength(axis int) float32 {
+
+	trackLen := vecAxis(s.viewport.Size(), axis)
+	csize := vecAxis(s.csize, axis)
+	if csize <= trackLen {
+		return trackLen
+	}
+	return util.Min(util.Max(trackLen*trackLen/csize, scrollThumbMinLength), trackLen)
+}
+
+// setOffset sets the clamped scroll offset calling the scroll callback if it changed
+func (s *ScrollView) setOffset(offset gb.Vec2) {
+
+	prev := s.offset
+	s.offset = offset
+	s.clampOffset()
+	if s.offset != prev && s.onScroll != nil {
+		s.onScroll(s)
+	}
+}
+
+// clampOffset clamps the scroll offset to the valid range
+func (s *ScrollView) clampOffset() {
+
+	maxOffset := s.MaxOffset()
+	s.offset.X = util.Clamp(s.offset.X, 0, maxOffset.X)
+	s.offset.Y = util.Clamp(s.offset.Y, 0, maxOffset.Y)
+}
+
+// contentPos returns the position of the content view for the current scroll offset
+func (s *ScrollView) contentPos() gb.Vec2 {
+
+	m := s.content.GetView().margin
+	return gb.Vec2{s.viewport.Min.X + m.Left - s.offset.X, s.viewport.Min.Y + m.Top - s.offset.Y}
+}
+
+// vecAxis returns the component of the vector for the specified axis (0 horizontal, 1 vertical)
+func vecAxis(v gb.Vec2, axis int) float32 {
+
+	if axis == 0 {
+		return v.X
+	}
+	return v.Y
+}
+
+// setVecAxis sets the component of the vector for the specified axis (0 horizontal, 1 vertical)
+func setVecAxis(v *gb.Vec2, axis int, value float32) {
+
+	if axis == 0 {
+		v.X = value
+	} else {
+		v.Y = value
+	}
+}
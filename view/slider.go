@@ -0,0 +1,199 @@
+package view
+
+import (
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/window"
+)
+
+// Number of font heights of the desired slider length
+const sliderDefaultLength = 10
+
+// Slider is a view which selects a value in a bounded range by dragging a thumb along a track.
+// Vertical sliders have their minimum value at the bottom.
+// When focused the value is changed by the arrow keys (Shift for fine and Control for coarse steps),
+// PageUp/PageDown and Home/End.
+type Slider struct {
+	View
+	numericValue
+	orientation Orientation          // Slider orientation
+	ff          window.FontStyleType // Font used to size the slider
+	hovered     bool                 // Cursor is over the view
+	dragging    bool                 // Thumb is being dragged
+	onChange    func(*Slider)        // Value change callback
+}
+
+// NewSlider creates and returns a new Slider with the specified orientation and range
+func NewSlider(orientation Orientation, min, max float64) *Slider {
+
+	s := new(Slider)
+	s.Init(s)
+	s.initNumeric(min, max, func() {
+		if s.onChange != nil {
+			s.onChange(s)
+		}
+	})
+	s.orientation = orientation
+	s.ff = window.FontRegular
+	s.focusable = true
+	return s
+}
+
+// Orientation returns the slider orientation
+func (s *Slider) Orientation() Orientation {
+
+	return s.orientation
+}
+
+// Dragging returns if the slider thumb is being dragged
+func (s *Slider) Dragging() bool {
+
+	return s.dragging
+}
+
+// OnChange sets the function called when the slider value changes
+func (s *Slider) OnChange(cb func(s *Slider)) {
+
+	s.onChange = cb
+}
+
+// Measure satisfies the IView interface
+func (s *Slider) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	h := w.Font(s.ff, 0).Height()
+	size := gb.Vec2{h * sliderDefaultLength, h}
+	if s.orientation == Vertical {
+		size = gb.Vec2{h, h * sliderDefaultLength}
+	}
+	size.Add(s.padding.Size())
+	return s.ConstrainSize(size)
+}
+
+// OnEvent satisfies the IView interface
+func (s *Slider) OnEvent(w *window.Window, ev *Event) bool {
+
+	switch ev.Type {
+	case EventMouseEnter:
+		s.hovered = true
+	case EventMouseLeave:
+		s.hovered = false
+	case EventMouseDown:
+		if s.disabled || ev.Button != gb.MouseButtonLeft {
+			return false
+		}
+		s.dragging = true
+		s.setFromPos(w, ev.Pos)
+		return true
+	case EventMouseMove:
+		if s.dragging {
+			s.setFromPos(w, ev.Pos)
+			return true
+		}
+	case EventMouseUp:
+		if s.dragging && ev.Button == gb.MouseButtonLeft {
+			s.dragging = false
+			return true
+		}
+	case EventKeyDown:
+		if s.disabled {
+			return false
+		}
+		switch ev.Key {
+		case gb.KeyHome:
+			s.SetValue(s.min)
+		case gb.KeyEnd:
+			s.SetValue(s.max)
+		default:
+			steps := numericKeySteps(ev)
+			if steps == 0 {
+				return false
+			}
+			s.stepBy(steps)
+		}
+		return true
+	}
+	return false
+}
+
+// Render satisfies the IView interface
+func (s *Slider) Render(w *window.Window) {
+
+	if !s.visible {
+		return
+	}
+	dl := s.BeginRender()
+	start, end, cross, radius := s.trackGeometry(w)
+	thumb := s.axisPoint(start+float32(s.fraction())*(end-start), cross)
+	thickness := radius * 0.5
+
+	// Draws the track and its filled part up to the thumb
+	var p0, p1 gb.Vec2
+	if s.orientation == Horizontal {
+		p0 = gb.Vec2{start - thickness/2, cross - thickness/2}
+		p1 = gb.Vec2{end + thickness/2, cross + thickness/2}
+	} else {
+		p0 = gb.Vec2{cross - thickness/2, end - thickness/2}
+		p1 = gb.Vec2{cross + thickness/2, start + thickness/2}
+	}
+	w.AddRectFilled(dl, p0, p1, s.StyleColor(w, StyleColorFrameActive).RGBA(), thickness/2, window.DrawFlags_RoundCornersAll)
+	fill := s.StyleColor(w, StyleColorCheckMark).RGBA()
+	if s.disabled {
+		fill = s.StyleColor(w, StyleColorButtonDisabled).RGBA()
+	}
+	if s.orientation == Horizontal {
+		p1.X = thumb.X
+	} else {
+		p0.Y = thumb.Y
+	}
+	w.AddRectFilled(dl, p0, p1, fill, thickness/2, window.DrawFlags_RoundCornersAll)
+
+	// Draws the thumb
+	r := radius
+	if (s.hovered || s.dragging) && !s.disabled {
+		r += 1
+	}
+	w.AddCircleFilled(dl, thumb, r, s.StyleColor(w, StyleColorKnob).RGBA(), 32)
+	border := s.StyleColor(w, StyleColorBorder).RGBA()
+	borderThickness := float32(1)
+	if s.HasFocus(w) && !s.disabled {
+		border = s.StyleColor(w, StyleColorFocus).RGBA()
+		borderThickness = 2
+	}
+	w.AddCircle(dl, thumb, r, border, 32, borderThickness)
+	s.EndRender(w)
+}
+
+// trackGeometry returns the start and end positions of the thumb center along the slider axis
+// (the start corresponds to the minimum value), the position of the track along the cross axis
+// and the thumb radius.
+func (s *Slider) trackGeometry(w *window.Window) (float32, float32, float32, float32) {
+
+	content := s.ContentRect()
+	radius := w.Font(s.ff, 0).Height() * 0.45
+	if s.orientation == Horizontal {
+		return content.Min.X + radius, content.Max.X - radius, (content.Min.Y + content.Max.Y) / 2, radius
+	}
+	return content.Max.Y - radius, content.Min.Y + radius, (content.Min.X + content.Max.X) / 2, radius
+}
+
+// axisPoint returns the local point for the specified positions along the slider axis and its cross axis
+func (s *Slider) axisPoint(main, cross float32) gb.Vec2 {
+
+	if s.orientation == Horizontal {
+		return gb.Vec2{main, cross}
+	}
+	return gb.Vec2{cross, main}
+}
+
+// setFromPos sets the value from the specified local point
+func (s *Slider) setFromPos(w *window.Window, p gb.Vec2) {
+
+	start, end, _, _ := s.trackGeometry(w)
+	pos := p.X
+	if s.orientation == Vertical {
+		pos = p.Y
+	}
+	if end == start {
+		return
+	}
+	s.setFraction(float64((pos - start) / (end - start)))
+}
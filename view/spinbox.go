@@ -0,0 +1,239 @@
+package view
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/window"
+)
+
+// Time in seconds a SpinBox arrow must be held down before the value starts repeating
+const spinRepeatDelay = 0.4
+
+// Time in seconds between value repetitions while a SpinBox arrow is held down
+const spinRepeatInterval = 0.06
+
+// Number of average characters used for the desired width of the SpinBox text
+const spinBoxDefaultChars = 8
+
+// Space between the SpinBox text and its arrow buttons
+const spinBoxTextPadding = 6
+
+// SpinBox is a numeric input view with a text entry and up/down arrow buttons at its right side.
+// The typed text is applied when Enter is pressed or the view loses the focus and reverted by Escape.
+// The Up/Down and PageUp/PageDown keys and the arrow buttons step the value,
+// with Shift for fine and Control for coarse steps, repeating while an arrow button is held down.
+type SpinBox struct {
+	TextEdit
+	numericValue
+	pressed    int            // Arrow button being pressed (1 up, -1 down, 0 none)
+	arrowHover int            // Arrow button under the cursor (1 up, -1 down, 0 none)
+	stepMult   float64        // Step multiplier of the arrow button being pressed
+	repeatAt   time.Time      // Time of the next value repetition
+	onChange   func(*SpinBox) // Value change callback
+}
+
+// NewSpinBox creates and returns a new SpinBox with the specified range
+func NewSpinBox(min, max float64) *SpinBox {
+
+	s := new(SpinBox)
+	s.initTextEdit(s, "")
+	s.initNumeric(min, max, func() {
+		s.TextEdit.SetText(s.formatValue())
+		if s.onChange != nil {
+			s.onChange(s)
+		}
+	})
+	s.TextEdit.SetText(s.formatValue())
+	return s
+}
+
+// SetFormat sets the fmt format used to show the value. The default is "%.2f".
+// The text may have a suffix after the number, such as units, which is ignored when parsing.
+func (s *SpinBox) SetFormat(format string) {
+
+	s.numericValue.SetFormat(format)
+	s.TextEdit.SetText(s.formatValue())
+}
+
+// OnChange sets the function called when the value changes
+func (s *SpinBox) OnChange(cb func(s *SpinBox)) {
+
+	s.onChange = cb
+}
+
+// Measure satisfies the IView interface
+func (s *SpinBox) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	// The right padding reserves the space of the arrow buttons, which depends on the font
+	s.padding.Right = spinBoxTextPadding + s.arrowWidth(w)
+	fa := w.Font(s.ff, 0)
+	size := gb.Vec2{fa.MeasureString("0") * spinBoxDefaultChars, fa.Height()}
+	size.Add(s.padding.Size())
+	return s.ConstrainSize(size)
+}
+
+// OnEvent satisfies the IView interface
+func (s *SpinBox) OnEvent(w *window.Window, ev *Event) bool {
+
+	switch ev.Type {
+	case EventMouseEnter:
+		s.TextEdit.OnEvent(w, ev)
+		s.arrowHover = s.arrowAt(w, ev.Pos)
+		if s.arrowHover != 0 {
+			w.SetCursor(gb.CursorDefault)
+		}
+		return false
+	case EventMouseMove:
+		hover := s.arrowAt(w, ev.Pos)
+		if hover != s.arrowHover {
+			s.arrowHover = hover
+			if hover != 0 {
+				w.SetCursor(gb.CursorDefault)
+			} else {
+				w.SetCursor(gb.CursorIBeam)
+			}
+		}
+		if s.pressed != 0 {
+			return true
+		}
+	case EventMouseLeave:
+		s.arrowHover = 0
+	case EventMouseDown:
+		dir := s.arrowAt(w, ev.Pos)
+		if dir == 0 || s.disabled || ev.Button != gb.MouseButtonLeft {
+			break
+		}
+		s.commit()
+		s.pressed = dir
+		s.stepMult = stepMultiplier(ev.Mods)
+		s.stepBy(float64(dir) * s.stepMult)
+		s.repeatAt = w.FrameTime().Add(time.Duration(spinRepeatDelay * float64(time.Second)))
+		return true
+	case EventMouseUp:
+		if s.pressed != 0 && ev.Button == gb.MouseButtonLeft {
+			s.pressed = 0
+			return true
+		}
+	case EventFocusOut:
+		s.commit()
+	case EventKeyDown:
+		if s.disabled {
+			break
+		}
+		switch ev.Key {
+		case gb.KeyEnter, gb.KeyKPEnter:
+			// Continues to the TextEdit to call its submit callback
+			s.commit()
+		case gb.KeyEscape:
+			s.TextEdit.SetText(s.formatValue())
+			return true
+		case gb.KeyUp, gb.KeyDown, gb.KeyPageUp, gb.KeyPageDown:
+			s.commit()
+			s.stepBy(numericKeySteps(ev))
+			return true
+		}
+	}
+	return s.TextEdit.OnEvent(w, ev)
+}
+
+// Render satisfies the IView interface
+func (s *SpinBox) Render(w *window.Window) {
+
+	if !s.visible {
+		return
+	}
+
+	// Repeats the value step while the pressed arrow button is held down under the cursor
+	if s.pressed != 0 {
+		now := w.FrameTime()
+		if s.arrowAt(w, s.ToLocal(CursorPos(w))) == s.pressed && !now.Before(s.repeatAt) {
+			s.stepBy(float64(s.pressed) * s.stepMult)
+			s.repeatAt = now.Add(time.Duration(spinRepeatInterval * float64(time.Second)))
+		}
+		w.RequestFrame(float32(s.repeatAt.Sub(now).Seconds()))
+	}
+
+	// Renders the text entry and then the arrow buttons over its right side
+	s.TextEdit.Render(w)
+	dl := s.BeginRender()
+	aw := s.arrowWidth(w)
+	x0 := s.size.X - aw
+	mid := s.size.Y / 2
+	rounding := s.StyleFrameRounding(w)
+	for _, dir := range []int{1, -1} {
+		min := gb.Vec2{x0, 0}
+		max := gb.Vec2{s.size.X, mid}
+		flags := window.DrawFlags_RoundCornersTopRight
+		if dir < 0 {
+			min.Y, max.Y = mid, s.size.Y
+			flags = window.DrawFlags_RoundCornersBottomRight
+		}
+		bg := StyleColorButton
+		switch {
+		case s.disabled:
+			bg = StyleColorButtonDisabled
+		case s.pressed == dir && s.arrowHover == dir:
+			bg = StyleColorButtonPressed
+		case s.arrowHover == dir:
+			bg = StyleColorButtonHovered
+		}
+		w.AddRectFilled(dl, min, max, s.StyleColor(w, bg).RGBA(), rounding, flags)
+		arrow := arrowUp
+		if dir < 0 {
+			arrow = arrowDown
+		}
+		center := gb.Vec2{(min.X + max.X) / 2, (min.Y + max.Y) / 2}
+		drawArrow(w, dl, center, aw*0.4, arrow, s.StyleColor(w, StyleColorText).RGBA())
+	}
+	border := s.StyleColor(w, StyleColorBorder).RGBA()
+	w.AddLine(dl, gb.Vec2{x0, 0}, gb.Vec2{x0, s.size.Y}, border, 1)
+	w.AddLine(dl, gb.Vec2{x0, mid}, gb.Vec2{s.size.X, mid}, border, 1)
+	s.EndRender(w)
+}
+
+// commit sets the value from the text, restoring the text of the current value if invalid
+func (s *SpinBox) commit() {
+
+	if v, ok := parseNumber(s.TextEdit.Text()); ok {
+		s.SetValue(v)
+	}
+	s.TextEdit.SetText(s.formatValue())
+}
+
+// arrowWidth returns the width of the arrow buttons
+func (s *SpinBox) arrowWidth(w *window.Window) float32 {
+
+	return w.Font(s.ff, 0).Height()
+}
+
+// arrowAt returns the arrow button at the specified local point (1 up, -1 down, 0 none)
+func (s *SpinBox) arrowAt(w *window.Window, p gb.Vec2) int {
+
+	if !(gb.Rect{Max: s.size}).Contains(p) || p.X < s.size.X-s.arrowWidth(w) {
+		return 0
+	}
+	if p.Y < s.size.Y/2 {
+		return 1
+	}
+	return -1
+}
+
+// parseNumber parses the number at the start of the specified text ignoring any suffix
+func parseNumber(text string) (float64, bool) {
+
+	text = strings.TrimSpace(text)
+	end := 0
+	for end < len(text) && strings.IndexByte("0123456789+-.eE", text[end]) >= 0 {
+		end++
+	}
+	// Shortens the prefix until it is valid as in "2e" or "3-"
+	for ; end > 0; end-- {
+		if v, err := strconv.ParseFloat(text[:end], 64); err == nil {
+			return v, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,37 @@
+package view
+
+import (
+	"testing"
+)
+
+func TestParseNumber(t *testing.T) {
+
+	cases := []struct {
+		text string
+		want float64
+		ok   bool
+	}{
+		{"12", 12, true},
+		{"  -3.5 ", -3.5, true},
+		{"+7", 7, true},
+		{"1e3", 1000, true},
+		{"2.5E-1", 0.25, true},
+		{".5", 0.5, true},
+		{"42 ms", 42, true},
+		{"10%", 10, true},
+		{"2e", 2, true},
+		{"3-", 3, true},
+		{"1.2.3", 1.2, true},
+		{"", 0, false},
+		{"abc", 0, false},
+		{"-", 0, false},
+		{"e5", 0, false},
+		{"$5", 0, false},
+	}
+	for _, c := range cases {
+		got, ok := parseNumber(c.text)
+		if ok != c.ok || got != c.want {
+			t.Errorf("parseNumber(%q): got %v %v, want %v %v", c.text, got, ok, c.want, c.ok)
+		}
+	}
+}
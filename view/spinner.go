@@ -0,0 +1,84 @@
+package view
+
+import (
+	"math"
+
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/window"
+)
+
+// Number of font heights of the desired spinner diameter
+const spinnerDefaultSize = 1.5
+
+// Spinner rotation speed in turns per second
+const spinnerSpeed = 0.8
+
+// Time in seconds between the frames requested by a visible spinner
+const spinnerFrameInterval = 1.0 / 60
+
+// Number of segments of the spinner arcs
+const spinnerSegments = 32
+
+// Spinner is an animated view which indicates activity of unknown duration with a rotating
+// arc which grows and shrinks. It requests new frames only while it is rendered and not clipped out,
+// so hiding the spinner or one of its ancestors stops the animation.
+type Spinner struct {
+	View
+	ff window.FontStyleType // Font used to size the spinner
+}
+
+// NewSpinner creates and returns a new Spinner
+func NewSpinner() *Spinner {
+
+	s := new(Spinner)
+	s.Init(s)
+	s.ff = window.FontRegular
+	return s
+}
+
+// Measure satisfies the IView interface
+func (s *Spinner) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	d := w.Font(s.ff, 0).Height() * spinnerDefaultSize
+	size := gb.Vec2{d, d}
+	size.Add(s.padding.Size())
+	return s.ConstrainSize(size)
+}
+
+// Render satisfies the IView interface
+func (s *Spinner) Render(w *window.Window) {
+
+	if !s.visible {
+		return
+	}
+	content := s.ContentRect()
+	csize := content.Size()
+	diameter := float32(math.Min(float64(csize.X), float64(csize.Y)))
+	if diameter <= 0 {
+		return
+	}
+	thickness := diameter / 8
+	radius := (diameter - thickness) / 2
+	center := gb.Vec2{content.Min.X + csize.X/2, content.Min.Y + csize.Y/2}
+
+	// Draws the track and the arc, which rotates while its length oscillates
+	dl := s.BeginRender()
+	w.AddCircle(dl, center, radius+0.5, s.StyleColor(w, StyleColorFrameActive).RGBA(), spinnerSegments, thickness)
+	col := StyleColorProgress
+	if s.disabled {
+		col = StyleColorButtonDisabled
+	}
+	t := float64(w.FrameTime().UnixNano()) / 1e9
+	start := float32(math.Mod(t*spinnerSpeed, 1) * 2 * math.Pi)
+	sweep := float32(math.Pi * (0.9 + 0.6*math.Sin(t*math.Pi)))
+	w.PathArcTo(dl, center, radius, start, start+sweep, spinnerSegments)
+	w.PathStroke(dl, s.StyleColor(w, col).RGBA(), 0, thickness)
+	s.EndRender(w)
+
+	// Requests the next animation frame only while the spinner is not clipped out,
+	// such as when scrolled out of a ScrollView
+	visible := s.WindowRect(gb.Rect{Max: s.size}).Intersect(w.ClipRect()).Size()
+	if visible.X > 0 && visible.Y > 0 && !s.disabled {
+		w.RequestFrame(spinnerFrameInterval)
+	}
+}
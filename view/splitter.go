@@ -0,0 +1,531 @@
+package view
+
+import (
+	"time"
+
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// Default size of the Splitter dividers in the main axis
+const splitterDividerSize = 6
+
+// Maximum time in seconds between two presses on a Splitter divider to be a double click
+const splitterDoubleClickTime = 0.4
+
+// splitPane contains the layout state of a Splitter child
+type splitPane struct {
+	size      float32 // Size in the main axis (negative until the first layout)
+	min       float32 // Minimum size
+	max       float32 // Maximum size (0 is unlimited)
+	collapsed bool    // Pane is collapsed to zero size
+	restore   float32 // Size restored when the pane is expanded
+}
+
+// Splitter is a container which arranges its visible children side by side, or from top to bottom
+// for vertical splitters, filling its size with draggable dividers between them.
+// Dragging a divider resizes the two panes around it within their size limits and a double
+// click on a divider collapses the smaller of its panes, or expands the pane if it was collapsed.
+// When the splitter is resized the size difference is distributed to the panes proportionally
+// to their sizes.
+type Splitter struct {
+	Group
+	orientation Orientation          // Horizontal places panes from left to right and Vertical from top to bottom
+	dividerSize float32              // Size of the dividers in the main axis
+	panes       map[IView]*splitPane // Layout state of children
+	dividers    []float32            // Start of each divider in the main axis from the last layout
+	hover       int                  // Divider under the cursor (-1 if none)
+	drag        int                  // Divider being dragged (-1 if none)
+	dragStart   float32              // Cursor position in the main axis when the drag started
+	dragSizes   [2]float32           // Sizes of the panes around the dragged divider when the drag started
+	lastPress   time.Time            // Time of the last press on a divider
+	lastDivider int                  // Divider of the last press (-1 if none)
+	onResize    func(*Splitter)      // Pane resize callback
+}
+
+// NewSplitter creates and returns a new empty Splitter with the specified orientation
+func NewSplitter(orientation Orientation) *Splitter {
+
+	s := new(Splitter)
+	s.Init(s)
+	s.orientation = orientation
+	s.dividerSize = splitterDividerSize
+	s.panes = make(map[IView]*splitPane)
+	s.hover = -1
+	s.drag = -1
+	s.lastDivider = -1
+	return s
+}
+
+// Add appends the specified child views and returns this Splitter
+func (s *Splitter) Add(children ...IView) *Splitter {
+
+	s.View.Add(children...)
+	return s
+}
+
+// Orientation returns the splitter orientation
+func (s *Splitter) Orientation() Orientation {
+
+	return s.orientation
+}
+
+// SetDividerSize sets the size of the dividers in the main axis. The default is 6.
+func (s *Splitter) SetDividerSize(size float32) {
+
+	s.dividerSize = util.Max(size, 1)
+}
+
+// DividerSize returns the size of the dividers in the main axis
+func (s *Splitter) DividerSize() float32 {
+
+	return s.dividerSize
+}
+
+// SetPaneLimits sets the minimum and maximum sizes in the main axis of the pane with the specified child.
+// A maximum of zero is unlimited.
+// The pane settings of a child are discarded in the next layout after it is removed from the splitter.
+func (s *Splitter) SetPaneLimits(child IView, min, max float32) {
+
+	p := s.pane(child)
+	p.min = util.Max(min, 0)
+	p.max = util.Max(max, 0)
+	if p.size >= 0 {
+		p.size = p.clamp(p.size)
+	}
+}
+
+// PaneLimits returns the minimum and maximum sizes in the main axis of the pane with the specified child
+// Returns zeros if the child has no pane settings.
+func (s *Splitter) PaneLimits(child IView) (float32, float32) {
+
+	p, ok := s.panes[child]
+	if !ok {
+		return 0, 0
+	}
+	return p.min, p.max
+}
+
+// SetPaneSize sets the size in the main axis of the pane with the specified child, within its limits.
+// The other panes are adjusted in the next layout to fill the splitter.
+func (s *Splitter) SetPaneSize(child IView, size float32) {
+
+	p := s.pane(child)
+	p.size = p.clamp(size)
+}
+
+// PaneSize returns the size in the main axis of the pane with the specified child from the last layout
+// Returns zero if the child was not laid out.
+func (s *Splitter) PaneSize(child IView) float32 {
+
+	p, ok := s.panes[child]
+	if !ok {
+		return 0
+	}
+	return util.Max(p.size, 0)
+}
+
+// SetCollapsed collapses or expands the pane with the specified child.
+// The space of a collapsed pane is given to the next pane, or to the previous one for the last pane.
+func (s *Splitter) SetCollapsed(child IView, collapsed bool) {
+
+	panes := s.visiblePanes()
+	for i, c := range panes {
+		if c != child {
+			continue
+		}
+		neighbor := i + 1
+		if neighbor >= len(panes) {
+			neighbor = i - 1
+		}
+		var other *splitPane
+		if neighbor >= 0 {
+			other = s.pane(panes[neighbor])
+		}
+		s.setCollapsed(s.pane(child), other, collapsed)
+		return
+	}
+	s.pane(child).collapsed = collapsed
+}
+
+// Collapsed returns if the pane with the specified child is collapsed
+func (s *Splitter) Collapsed(child IView) bool {
+
+	p, ok := s.panes[child]
+	return ok && p.collapsed
+}
+
+// OnResize sets the function called when the panes are resized by dragging or collapsing
+func (s *Splitter) OnResize(cb func(s *Splitter)) {
+
+	s.onResize = cb
+}
+
+// Measure satisfies the IView interface.
+// The desired size is the sum of the pane sizes, or of the desired sizes of their children
+// before the first layout, plus the dividers.
+func (s *Splitter) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	vertical := s.orientation == Vertical
+	var main, cross float32
+	panes := s.visiblePanes()
+	for i, c := range panes {
+		cv := c.GetView()
+		d := MeasureChild(w, c, avail)
+		d.Add(cv.margin.Size())
+		p := s.pane(c)
+		switch {
+		case p.collapsed:
+		case p.size >= 0:
+			main += p.size
+		default:
+			main += p.clamp(axisMain(d, vertical))
+		}
+		if i > 0 {
+			main += s.dividerSize
+		}
+		cross = util.Max(cross, axisCross(d, vertical))
+	}
+	size := axisVec2(main, cross, vertical)
+	size.Add(s.padding.Size())
+	return s.ConstrainSize(size)
+}
+
+// Arrange satisfies the IView interface
+func (s *Splitter) Arrange(w *window.Window, pos gb.Vec2, size gb.Vec2) {
+
+	s.View.Arrange(w, pos, size)
+	s.dividers = s.dividers[:0]
+
+	// Discards the layout state of the views which are no longer children
+	for c := range s.panes {
+		if c.GetView().parent != s.iview {
+			delete(s.panes, c)
+		}
+	}
+
+	panes := s.visiblePanes()
+	if len(panes) == 0 {
+		return
+	}
+	vertical := s.orientation == Vertical
+	content := s.ContentRect()
+	csize := content.Size()
+	s.fit(panes, axisMain(csize, vertical)-float32(len(panes)-1)*s.dividerSize)
+	cursor := axisMain(content.Min, vertical)
+	cross := axisCross(content.Min, vertical)
+	for i, c := range panes {
+		if i > 0 {
+			s.dividers = append(s.dividers, cursor)
+			cursor += s.dividerSize
+		}
+		cv := c.GetView()
+		p := s.pane(c)
+		cpos := axisVec2(cursor, cross, vertical)
+		cpos.Add(gb.Vec2{cv.margin.Left, cv.margin.Top})
+		csz := gb.Vec2Sub(axisVec2(p.size, axisCross(csize, vertical), vertical), cv.margin.Size())
+		ArrangeChild(w, c, cpos, gb.Vec2{util.Max(csz.X, 0), util.Max(csz.Y, 0)})
+		cursor += p.size
+	}
+}
+
+// OnEvent satisfies the IView interface
+func (s *Splitter) OnEvent(w *window.Window, ev *Event) bool {
+
+	switch ev.Type {
+	case EventMouseMove:
+		if s.drag >= 0 {
+			s.dragTo(axisMain(ev.Pos, s.orientation == Vertical))
+			return true
+		}
+		s.setHover(w, s.dividerAt(ev.Pos))
+	case EventMouseLeave:
+		if s.drag < 0 {
+			s.setHover(w, -1)
+		}
+	case EventMouseDown:
+		index := s.dividerAt(ev.Pos)
+		if index < 0 || s.disabled || ev.Button != gb.MouseButtonLeft {
+			return false
+		}
+		now := w.FrameTime()
+		if index == s.lastDivider && now.Sub(s.lastPress).Seconds() < splitterDoubleClickTime {
+			s.lastDivider = -1
+			s.toggleCollapse(index)
+			return true
+		}
+		s.lastDivider = index
+		s.lastPress = now
+		s.startDrag(index, axisMain(ev.Pos, s.orientation == Vertical))
+		return true
+	case EventMouseUp:
+		if s.drag >= 0 && ev.Button == gb.MouseButtonLeft {
+			s.drag = -1
+			s.setHover(w, s.dividerAt(ev.Pos))
+			return true
+		}
+	}
+	return false
+}
+
+// Render satisfies the IView interface
+func (s *Splitter) Render(w *window.Window) {
+
+	if !s.visible {
+		return
+	}
+	// The cursor may have moved from a divider to a pane, which does not send events to the splitter
+	if s.hover >= 0 && s.drag < 0 && Hovered(w) != IView(s) {
+		s.setHover(w, -1)
+	}
+
+	// Renders each pane clipped to its rectangle
+	for _, c := range s.children {
+		cv := c.GetView()
+		if !cv.visible || s.pane(c).collapsed {
+			continue
+		}
+		w.PushClipRect(s.WindowRect(gb.Rect{Min: cv.pos, Max: gb.Vec2Add(cv.pos, cv.size)}))
+		c.SetTransform(&s.transform)
+		c.Render(w)
+		w.PopClipRect()
+	}
+
+	// Draws the dividers with a line at their centers
+	dl := s.BeginRender()
+	vertical := s.orientation == Vertical
+	content := s.ContentRect()
+	border := s.StyleColor(w, StyleColorBorder).RGBA()
+	for i := range s.dividers {
+		r := s.dividerRect(i)
+		switch {
+		case i == s.drag:
+			w.AddRectFilled(dl, r.Min, r.Max, s.StyleColor(w, StyleColorButtonPressed).RGBA(), 0, 0)
+		case i == s.hover && !s.disabled:
+			w.AddRectFilled(dl, r.Min, r.Max, s.StyleColor(w, StyleColorButtonHovered).RGBA(), 0, 0)
+		}
+		mid := s.dividers[i] + s.dividerSize/2
+		p0 := axisVec2(mid, axisCross(content.Min, vertical), vertical)
+		p1 := axisVec2(mid, axisCross(content.Max, vertical), vertical)
+		w.AddLine(dl, p0, p1, border, 1)
+	}
+	s.EndRender(w)
+}
+
+// pane returns the layout state of the specified child, creating it if necessary
+func (s *Splitter) pane(child IView) *splitPane {
+
+	p, ok := s.panes[child]
+	if !ok {
+		p = &splitPane{size: -1}
+		s.panes[child] = p
+	}
+	return p
+}
+
+// visiblePanes returns the visible children of the splitter
+func (s *Splitter) visiblePanes() []IView {
+
+	panes := make([]IView, 0, len(s.children))
+	for _, c := range s.children {
+		if c.GetView().visible {
+			panes = append(panes, c)
+		}
+	}
+	return panes
+}
+
+// fit sets the sizes of the specified panes to fill the available space in the main axis.
+// Panes without a size share the space left by the others and the remaining difference is
+// distributed to the panes which can still grow or shrink proportionally to their sizes.
+func (s *Splitter) fit(panes []IView, avail float32) {
+
+	var fixed float32
+	var unset []*splitPane
+	for _, c := range panes {
+		p := s.pane(c)
+		switch {
+		case p.collapsed:
+		case p.size < 0:
+			unset = append(unset, p)
+		default:
+			fixed += p.size
+		}
+	}
+	for _, p := range unset {
+		p.size = p.clamp(util.Max(avail-fixed, 0) / float32(len(unset)))
+	}
+	for iter := 0; iter < len(panes); iter++ {
+		var total float32
+		for _, c := range panes {
+			if p := s.pane(c); !p.collapsed {
+				total += p.size
+			}
+		}
+		diff := avail - total
+		if util.Abs(diff) < 0.5 {
+			return
+		}
+		var weights float32
+		var flexible []*splitPane
+		for _, c := range panes {
+			p := s.pane(c)
+			if p.collapsed || (diff > 0 && p.max > 0 && p.size >= p.max) || (diff < 0 && p.size <= p.min) {
+				continue
+			}
+			flexible = append(flexible, p)
+			weights += p.size + 1
+		}
+		if len(flexible) == 0 {
+			return
+		}
+		for _, p := range flexible {
+			p.size = p.clamp(p.size + diff*(p.size+1)/weights)
+		}
+	}
+}
+
+// startDrag starts dragging the specified divider from the specified cursor position in the main axis,
+// expanding its panes if collapsed
+func (s *Splitter) startDrag(index int, pos float32) {
+
+	panes := s.visiblePanes()
+	a, b := s.pane(panes[index]), s.pane(panes[index+1])
+	for _, p := range []*splitPane{a, b} {
+		if p.collapsed {
+			p.collapsed = false
+			p.size = 0
+		}
+	}
+	s.drag = index
+	s.dragStart = pos
+	s.dragSizes = [2]float32{a.size, b.size}
+}
+
+// dragTo resizes the panes of the dragged divider for the specified cursor position in the main axis
+// keeping the sum of their sizes and their size limits
+func (s *Splitter) dragTo(pos float32) {
+
+	panes := s.visiblePanes()
+	if s.drag+1 >= len(panes) {
+		return
+	}
+	a, b := s.pane(panes[s.drag]), s.pane(panes[s.drag+1])
+	total := s.dragSizes[0] + s.dragSizes[1]
+	lo := util.Max(a.min, total-b.limit(total))
+	hi := util.Min(a.limit(total), total-b.min)
+	size := s.dragSizes[0]
+	if lo <= hi {
+		size = util.Clamp(size+pos-s.dragStart, lo, hi)
+	}
+	if size == a.size {
+		return
+	}
+	a.size = size
+	b.size = total - size
+	if s.onResize != nil {
+		s.onResize(s)
+	}
+}
+
+// toggleCollapse expands the collapsed pane of the specified divider or
+// collapses its smaller pane giving its space to the other one
+func (s *Splitter) toggleCollapse(index int) {
+
+	panes := s.visiblePanes()
+	a, b := s.pane(panes[index]), s.pane(panes[index+1])
+	switch {
+	case a.collapsed:
+		s.setCollapsed(a, b, false)
+	case b.collapsed:
+		s.setCollapsed(b, a, false)
+	case a.size <= b.size:
+		s.setCollapsed(a, b, true)
+	default:
+		s.setCollapsed(b, a, true)
+	}
+}
+
+// setCollapsed collapses or expands the specified pane, moving its space from or to the other pane, if any
+func (s *Splitter) setCollapsed(p, other *splitPane, collapsed bool) {
+
+	if p.collapsed == collapsed {
+		return
+	}
+	p.collapsed = collapsed
+	if collapsed {
+		p.restore = util.Max(p.size, 0)
+		if other != nil && other.size >= 0 {
+			other.size = other.clamp(other.size + p.restore)
+		}
+		p.size = 0
+	} else {
+		p.size = p.clamp(p.restore)
+		if other != nil && other.size >= 0 {
+			other.size = other.clamp(other.size - p.size)
+		}
+	}
+	if s.onResize != nil {
+		s.onResize(s)
+	}
+}
+
+// setHover sets the divider under the cursor and the resize cursor shown over dividers
+func (s *Splitter) setHover(w *window.Window, index int) {
+
+	if index == s.hover {
+		return
+	}
+	s.hover = index
+	switch {
+	case index < 0 || s.disabled:
+		w.SetCursor(gb.CursorDefault)
+	case s.orientation == Vertical:
+		w.SetCursor(gb.CursorVResize)
+	default:
+		w.SetCursor(gb.CursorHResize)
+	}
+}
+
+// dividerRect returns the rectangle of the specified divider in local coordinates
+func (s *Splitter) dividerRect(index int) gb.Rect {
+
+	vertical := s.orientation == Vertical
+	content := s.ContentRect()
+	start := s.dividers[index]
+	return gb.Rect{
+		Min: axisVec2(start, axisCross(content.Min, vertical), vertical),
+		Max: axisVec2(start+s.dividerSize, axisCross(content.Max, vertical), vertical),
+	}
+}
+
+// dividerAt returns the divider at the specified local point or -1 if none
+func (s *Splitter) dividerAt(p gb.Vec2) int {
+
+	for i := range s.dividers {
+		if s.dividerRect(i).Contains(p) {
+			return i
+		}
+	}
+	return -1
+}
+
+// clamp returns the specified size limited to the pane minimum and maximum sizes
+func (p *splitPane) clamp(size float32) float32 {
+
+	size = util.Max(size, p.min)
+	if p.max > 0 {
+		size = util.Min(size, p.max)
+	}
+	return size
+}
+
+// limit returns the pane maximum size or the specified size if the maximum is unlimited
+func (p *splitPane) limit(unlimited float32) float32 {
+
+	if p.max > 0 {
+		return p.max
+	}
+	return unlimited
+}
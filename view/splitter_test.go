@@ -0,0 +1,42 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/leonsal/gux/gb"
+)
+
+func TestSplitterPaneState(t *testing.T) {
+
+	a := newTestView(10, 10)
+	b := newTestView(10, 10)
+	s := NewSplitter(Horizontal)
+	s.Add(a, b)
+	s.SetDividerSize(4)
+	s.SetPaneLimits(a, 20, 0)
+	s.SetPaneSize(a, 30)
+	ArrangeChild(nil, s, gb.Vec2{}, gb.Vec2{100, 50})
+	if s.PaneSize(a) != 30 || s.PaneSize(b) != 66 {
+		t.Errorf("pane sizes %v %v, want 30 66", s.PaneSize(a), s.PaneSize(b))
+	}
+
+	// Getters for views which are not children return zeros without creating panes
+	other := newTestView(10, 10)
+	min, max := s.PaneLimits(other)
+	if min != 0 || max != 0 || s.PaneSize(other) != 0 || s.Collapsed(other) {
+		t.Errorf("non child pane state %v %v %v %v", min, max, s.PaneSize(other), s.Collapsed(other))
+	}
+	if len(s.panes) != 2 {
+		t.Errorf("got %d panes after getters, want 2", len(s.panes))
+	}
+
+	// The state of removed children is discarded in the next layout
+	s.Remove(a)
+	ArrangeChild(nil, s, gb.Vec2{}, gb.Vec2{100, 50})
+	if _, ok := s.panes[a]; ok || len(s.panes) != 1 {
+		t.Errorf("got %d panes after removing a child, want 1", len(s.panes))
+	}
+	if s.PaneSize(b) != 100 {
+		t.Errorf("remaining pane size %v, want 100", s.PaneSize(b))
+	}
+}
@@ -0,0 +1,94 @@
+package view
+
+import (
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// Stack is a container which arranges all its children on top of each other
+// in its content area. Children are rendered in the order they were added.
+type Stack struct {
+	Group
+	halign Align // Horizontal alignment of children
+	valign Align // Vertical alignment of children
+}
+
+// NewStack creates and returns a new empty Stack
+func NewStack() *Stack {
+
+	s := new(Stack)
+	s.Init(s)
+	s.halign = AlignStretch
+	s.valign = AlignStretch
+	return s
+}
+
+// Add appends the specified child views and returns this Stack
+func (s *Stack) Add(children ...IView) *Stack {
+
+	s.View.Add(children...)
+	return s
+}
+
+// SetAlign sets the horizontal and vertical alignment of the stack children.
+// The default is AlignStretch for both.
+func (s *Stack) SetAlign(halign, valign Align) {
+
+	s.halign = halign
+	s.valign = valign
+}
+
+// Align returns the current horizontal and vertical alignment of the stack children
+func (s *Stack) Align() (Align, Align) {
+
+	return s.halign, s.valign
+}
+
+// Measure satisfies the IView interface
+func (s *Stack) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	content := gb.Vec2{
+		util.Max(avail.X-s.padding.Horizontal(), 0),
+		util.Max(avail.Y-s.padding.Vertical(), 0),
+	}
+	var size gb.Vec2
+	for _, c := range s.children {
+		cv := c.GetView()
+		if !cv.visible {
+			continue
+		}
+		d := MeasureChild(w, c, content)
+		size.X = util.Max(size.X, d.X+cv.margin.Horizontal())
+		size.Y = util.Max(size.Y, d.Y+cv.margin.Vertical())
+	}
+	size.Add(s.padding.Size())
+	return s.ConstrainSize(size)
+}
+
+// Arrange satisfies the IView interface
+func (s *Stack) Arrange(w *window.Window, pos gb.Vec2, size gb.Vec2) {
+
+	s.View.Arrange(w, pos, size)
+	content := s.ContentRect()
+	for _, c := range s.children {
+		cv := c.GetView()
+		if !cv.visible {
+			continue
+		}
+		space := gb.Vec2Sub(content.Max, content.Min)
+		space.Sub(cv.margin.Size())
+		csize := cv.desired
+		if s.halign == AlignStretch {
+			csize.X = space.X
+		}
+		if s.valign == AlignStretch {
+			csize.Y = space.Y
+		}
+		cpos := gb.Vec2{
+			content.Min.X + cv.margin.Left + alignOffset(s.halign, space.X, csize.X),
+			content.Min.Y + cv.margin.Top + alignOffset(s.valign, space.Y, csize.Y),
+		}
+		ArrangeChild(w, c, cpos, csize)
+	}
+}
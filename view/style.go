@@ -15,6 +15,9 @@ const (
 	StyleWindowRounding
 	StyleWindowBorderSize
 	StyleWindowMinSize
+	StyleFrameRounding
+	StyleScrollbarSize
+	StyleTooltipDelay
 	StyleUser
 )
 
@@ -28,6 +31,70 @@ const (
 	StyleColorTextDisabled
 	// Native window clear color
 	StyleColorWinClear
+	// Button background color
+	StyleColorButton
+	// Button background color when hovered
+	StyleColorButtonHovered
+	// Button background color when pressed
+	StyleColorButtonPressed
+	// Button background color when disabled
+	StyleColorButtonDisabled
+	// Border color of the view with the keyboard focus
+	StyleColorFocus
+	// Background color of frames such as check boxes and switch tracks
+	StyleColorFrame
+	// Background color of frames when hovered
+	StyleColorFrameHovered
+	// Background color of frames when pressed
+	StyleColorFrameActive
+	// Border color of frames
+	StyleColorBorder
+	// Color of check marks, selected radio buttons and switches turned on
+	StyleColorCheckMark
+	// Color of switch and slider knobs
+	StyleColorKnob
+	// Background color of selected text
+	StyleColorTextSelectedBg
+	// Background color of scrollbar tracks
+	StyleColorScrollbar
+	// Color of scrollbar thumbs
+	StyleColorScrollbarThumb
+	// Color of scrollbar thumbs when hovered
+	StyleColorScrollbarThumbHovered
+	// Color of scrollbar thumbs when dragged
+	StyleColorScrollbarThumbActive
+	// Background color of selected items of lists, trees and tables
+	StyleColorItemSelected
+	// Background color of items of lists, trees and tables when hovered
+	StyleColorItemHovered
+	// Background color of table headers
+	StyleColorTableHeader
+	// Background color of alternate table rows
+	StyleColorTableRowAlt
+	// Background color of tab bars
+	StyleColorTabBar
+	// Background color of tabs
+	StyleColorTab
+	// Background color of tabs when hovered
+	StyleColorTabHovered
+	// Background color of the current tab
+	StyleColorTabActive
+	// Color of tab close buttons when hovered
+	StyleColorTabCloseHovered
+	// Background color of menu bars
+	StyleColorMenuBar
+	// Background color of popup menus
+	StyleColorMenu
+	// Background color of highlighted menu items
+	StyleColorMenuItemHovered
+	// Background color of panels
+	StyleColorPanel
+	// Background color of tooltips
+	StyleColorTooltip
+	// Color drawn over the views below modal overlays
+	StyleColorModalDim
+	// Color of the filled part of progress bars and of spinner arcs
+	StyleColorProgress
 	// User views can use from this color configuration number
 	StyleColorUser
 )
@@ -82,6 +149,63 @@ func (v *View) DelStyleAlpha() {
 	v.deleteStyle(StyleAlpha)
 }
 
+// StyleFrameRounding returns the current radius of the corners of framed views such as buttons
+func (v *View) StyleFrameRounding(w *window.Window) float32 {
+
+	r, _ := getStyle(w, v, StyleFrameRounding).(float32)
+	return r
+}
+
+// SetStyleFrameRounding sets specific radius of the corners of the view frame
+func (v *View) SetStyleFrameRounding(rounding float32) {
+
+	v.setStyle(StyleFrameRounding, rounding)
+}
+
+// DelStyleFrameRounding deletes the specific radius of the corners of the view frame
+func (v *View) DelStyleFrameRounding() {
+
+	v.deleteStyle(StyleFrameRounding)
+}
+
+// StyleScrollbarSize returns the current thickness of scrollbars
+func (v *View) StyleScrollbarSize(w *window.Window) float32 {
+
+	size, _ := getStyle(w, v, StyleScrollbarSize).(float32)
+	return size
+}
+
+// SetStyleScrollbarSize sets specific thickness of the view scrollbars
+func (v *View) SetStyleScrollbarSize(size float32) {
+
+	v.setStyle(StyleScrollbarSize, size)
+}
+
+// DelStyleScrollbarSize deletes the specific thickness of the view scrollbars
+func (v *View) DelStyleScrollbarSize() {
+
+	v.deleteStyle(StyleScrollbarSize)
+}
+
+// StyleTooltipDelay returns the current time in seconds the cursor must rest over the view to show its tooltip
+func (v *View) StyleTooltipDelay(w *window.Window) float32 {
+
+	delay, _ := getStyle(w, v, StyleTooltipDelay).(float32)
+	return delay
+}
+
+// SetStyleTooltipDelay sets specific time in seconds the cursor must rest over the view to show its tooltip
+func (v *View) SetStyleTooltipDelay(delay float32) {
+
+	v.setStyle(StyleTooltipDelay, delay)
+}
+
+// DelStyleTooltipDelay deletes the specific tooltip delay of the view
+func (v *View) DelStyleTooltipDelay() {
+
+	v.deleteStyle(StyleTooltipDelay)
+}
+
 // StyleColor returns the current style color for the view, window and color configuration
 func (v *View) StyleColor(w *window.Window, scolor StyleColorType) color.Color {
 
@@ -132,7 +256,7 @@ func (v *View) setStyle(style StyleType, value any) {
 
 func (v *View) deleteStyle(style StyleType) {
 
-	if v.style == nil {
+	if v.style != nil {
 		delete(v.style, style)
 	}
 }
@@ -3,12 +3,54 @@ package view
 import "github.com/leonsal/gux/color"
 
 var StyleMapDefault = StyleMap{
-	StyleAlpha:         1.0,
-	StyleDisabledAlpha: 0.5,
+	StyleAlpha:         float32(1.0),
+	StyleDisabledAlpha: float32(0.5),
+	StyleFrameRounding: float32(4.0),
+	StyleScrollbarSize: float32(10.0),
+	StyleTooltipDelay:  float32(0.6),
 }
 
 var StyleColorMapDefault = StyleColorMap{
 	StyleColorText:         color.Black,
 	StyleColorTextDisabled: color.Gray,
 	StyleColorWinClear:     color.White,
+
+	StyleColorButton:         color.Gainsboro,
+	StyleColorButtonHovered:  color.Lightsteelblue,
+	StyleColorButtonPressed:  color.Steelblue,
+	StyleColorButtonDisabled: color.Whitesmoke,
+	StyleColorFocus:          color.Dodgerblue,
+
+	StyleColorFrame:        color.White,
+	StyleColorFrameHovered: color.Aliceblue,
+	StyleColorFrameActive:  color.Lightsteelblue,
+	StyleColorBorder:       color.Darkgray,
+	StyleColorCheckMark:    color.Dodgerblue,
+	StyleColorKnob:         color.White,
+
+	StyleColorTextSelectedBg: color.Lightblue,
+
+	StyleColorScrollbar:             color.Whitesmoke,
+	StyleColorScrollbarThumb:        color.Silver,
+	StyleColorScrollbarThumbHovered: color.Darkgray,
+	StyleColorScrollbarThumbActive:  color.Gray,
+
+	StyleColorItemSelected: color.Lightsteelblue,
+	StyleColorItemHovered:  color.Aliceblue,
+	StyleColorTableHeader:  color.Gainsboro,
+	StyleColorTableRowAlt:  color.Whitesmoke,
+
+	StyleColorTabBar:          color.Darkgray,
+	StyleColorTab:             color.Lightgray,
+	StyleColorTabHovered:      color.Gainsboro,
+	StyleColorTabActive:       color.White,
+	StyleColorTabCloseHovered: color.Silver,
+
+	StyleColorMenuBar:         color.Whitesmoke,
+	StyleColorMenu:            color.White,
+	StyleColorMenuItemHovered: color.Lightsteelblue,
+	StyleColorPanel:           color.White,
+	StyleColorTooltip:         color.Lightyellow,
+	StyleColorModalDim:        color.Color{0, 0, 0, 0.35},
+	StyleColorProgress:        color.Dodgerblue,
 }
@@ -0,0 +1,117 @@
+package view
+
+import (
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// switchAnimDuration is the time in seconds of the switch knob animation
+const switchAnimDuration = 0.15
+
+// Switch is a toggle view with a knob which slides over a track and a label
+type Switch struct {
+	toggleView
+	on       bool          // Current state
+	knob     float32       // Current knob position from 0 (off) to 1 (on)
+	onChange func(*Switch) // Change callback
+}
+
+// NewSwitch creates and returns a new Switch turned off with the specified label
+func NewSwitch(text string) *Switch {
+
+	s := new(Switch)
+	s.initToggle(s, text, func() { s.SetOn(!s.on) })
+	return s
+}
+
+// SetOn sets the switch state, calling the change callback if it changed.
+// The knob slides to its new position in the next frames.
+func (s *Switch) SetOn(on bool) {
+
+	if on == s.on {
+		return
+	}
+	s.on = on
+	if s.onChange != nil {
+		s.onChange(s)
+	}
+}
+
+// On returns if the switch is turned on
+func (s *Switch) On() bool {
+
+	return s.on
+}
+
+// OnChange sets the function called when the switch state changes
+func (s *Switch) OnChange(cb func(s *Switch)) {
+
+	s.onChange = cb
+}
+
+// Measure satisfies the IView interface
+func (s *Switch) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	return s.measureToggle(w, switchTrackSize(w.Font(s.ff, 0)))
+}
+
+// Render satisfies the IView interface
+func (s *Switch) Render(w *window.Window) {
+
+	if !s.visible {
+		return
+	}
+
+	// Moves the knob towards its target position, requesting frames until it is reached
+	target := float32(0)
+	if s.on {
+		target = 1
+	}
+	if s.knob != target {
+		step := w.FrameDelta() / switchAnimDuration
+		if s.knob < target {
+			s.knob = util.Min(s.knob+step, target)
+		} else {
+			s.knob = util.Max(s.knob-step, target)
+		}
+		w.RequestFrame(0)
+	}
+
+	dl := s.BeginRender()
+	track := switchTrackSize(w.Font(s.ff, 0))
+	r := s.indicatorRect(track)
+	rounding := track.Y / 2
+
+	// Draws the track with its color interpolated by the knob position
+	var trackColor gb.RGBA
+	if s.disabled {
+		trackColor = s.StyleColor(w, StyleColorButtonDisabled).RGBA()
+	} else {
+		off := s.StyleColor(w, StyleColorFrameActive)
+		on := s.StyleColor(w, StyleColorCheckMark)
+		trackColor = off.Lerp(on, s.knob).RGBA()
+	}
+	w.AddRectFilled(dl, r.Min, r.Max, trackColor, rounding, window.DrawFlags_RoundCornersAll)
+	if s.HasFocus(w) && !s.disabled {
+		w.AddRect(dl, r.Min, r.Max, s.StyleColor(w, StyleColorFocus).RGBA(), rounding, window.DrawFlags_RoundCornersAll, 2)
+	}
+
+	// Draws the knob
+	radius := rounding - 2
+	if s.Pressed() {
+		radius -= 1
+	}
+	center := gb.Vec2{r.Min.X + rounding + s.knob*(track.X-track.Y), r.Min.Y + rounding}
+	w.AddCircleFilled(dl, center, radius, s.StyleColor(w, StyleColorKnob).RGBA(), 32)
+
+	s.renderLabel(w, dl, track.X)
+	s.EndRender(w)
+}
+
+// switchTrackSize returns the size of the switch track for the specified font
+func switchTrackSize(fa *window.FontAtlas) gb.Vec2 {
+
+	h := indicatorSide(fa)
+	return gb.Vec2{h * 1.8, h}
+}
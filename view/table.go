@@ -0,0 +1,1160 @@
+package view
+
+import (
+	"sort"
+
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// Default width of table columns
+const tableDefaultColumnWidth = 100
+
+// Default minimum width of table columns
+const tableMinColumnWidth = 20
+
+// Distance from a column border in the header where the column can be resized
+const tableResizeMargin = 4
+
+// Distance the cursor must move after pressing a header cell to start moving the column
+const tableDragThreshold = 5
+
+// Number of rows used for the desired height of a Table
+const tableDefaultRows = 10
+
+// TableDataSource supplies the cells of a Table.
+// Cells are not views: they are drawn directly by the data source
+// and only the cells inside the visible scroll range are drawn.
+type TableDataSource interface {
+	Rows() int                                    // Returns the number of rows
+	RenderCell(w *window.Window, cell *TableCell) // Draws the cell in its rectangle
+}
+
+// TableSorter can be implemented by a TableDataSource to sort its rows when
+// the header of a sortable column is clicked.
+type TableSorter interface {
+	Sort(column int, ascending bool) // Sorts the rows by the column
+}
+
+// TableColumn contains the configuration of a Table column
+type TableColumn struct {
+	Title     string  // Header text
+	Width     float32 // Current width
+	MinWidth  float32 // Minimum width when resized by the user
+	Resizable bool    // Column can be resized by dragging its header border
+	Sortable  bool    // Clicking the column header sorts the rows
+	Align     Align   // Horizontal alignment of the header and of texts drawn by TableCell.DrawText()
+}
+
+// TableCellPos is the position of a table cell as its row and column indices
+type TableCellPos struct {
+	Row    int
+	Column int
+}
+
+// TableCell describes a Table cell being drawn
+type TableCell struct {
+	Table    *Table       // Table which contains the cell
+	DL       *gb.DrawList // Draw list where the cell must be drawn, clipped to the cell
+	Row      int          // Row index
+	Column   int          // Column index (independent of the display order)
+	Rect     gb.Rect      // Cell rectangle in the draw list coordinates
+	Selected bool         // Cell is selected
+	Hovered  bool         // Cell is under the cursor
+	Current  bool         // Cell is the current cell for keyboard navigation
+}
+
+// Table is a data grid with column headers and cells supplied by a TableDataSource which is
+// virtualized in both directions: only the visible rows and columns are drawn.
+// The header row stays visible when scrolling vertically.
+// Columns can be resized by dragging their header borders, moved by dragging their headers and
+// sorted by clicking on their headers. Cells (or whole rows) are selected by clicking, with Control
+// toggling and Shift extending the selection in multi selection mode, and by the keyboard:
+// the arrow keys, PageUp/PageDown and Home/End (Control for the first and last rows) move the
+// current cell (Shift extends the selection and Control keeps it), Space selects and Control+Space
+// toggles it, Control+A selects all and Enter activates the current cell.
+type Table struct {
+	ScrollView
+	cells       *tableContent           // Content view which draws the visible cells
+	src         TableDataSource         // Data source
+	columns     []*TableColumn          // Columns
+	order       []int                   // Column indices in display order
+	reorderable bool                    // Columns can be moved by the user
+	rowH        float32                 // Row height (0 uses the font height)
+	autoHeight  float32                 // Row height from the font height of the last measure
+	mode        SelectionMode           // Selection mode
+	rowSelect   bool                    // Selection unit is a row
+	ranges      []tableRange            // Disjoint ranges of selected cells
+	all         bool                    // All cells are selected
+	current     TableCellPos            // Current cell (row -1 if none)
+	anchor      TableCellPos            // Cell where range selections start
+	hovered     TableCellPos            // Cell under the cursor (row -1 if none)
+	sortColumn  int                     // Sorted column (-1 if none)
+	sortAsc     bool                    // Sort order
+	headerHover int                     // Display position of the header cell under the cursor (-1 if none)
+	resizeHover bool                    // Cursor is over a resizable column border
+	resizeCol   int                     // Column being resized (-1 if none)
+	resizeX     float32                 // Cursor position when the resize started
+	resizeWidth float32                 // Column width when the resize started
+	pressPos    int                     // Display position of the pressed header cell (-1 if none)
+	pressX      float32                 // Cursor position in content coordinates when the header was pressed
+	moving      bool                    // Pressed column is being moved
+	moveX       float32                 // Cursor position in content coordinates while moving a column
+	onSelect    func(*Table)            // Selection change callback
+	onActivate  func(*Table, int, int)  // Cell activation callback
+	onSort      func(*Table, int, bool) // Sort change callback
+}
+
+// tableRange is a rectangle of selected cells formed by the rows between first and last
+// and the specified columns, which are nil with row selection
+type tableRange struct {
+	first   int   // First row
+	last    int   // Last row
+	columns []int // Sorted column indices
+}
+
+// tableContent is the content view of a Table sized to all cells, which draws only the visible ones
+type tableContent struct {
+	View
+	t *Table
+}
+
+// NewTable creates and returns a new Table with the specified data source, which can be nil.
+// Columns are added by AddColumn().
+func NewTable(src TableDataSource) *Table {
+
+	t := new(Table)
+	t.cells = &tableContent{t: t}
+	t.cells.Init(t.cells)
+	t.initScrollView(t, t.cells)
+	t.focusable = true
+	t.src = src
+	t.reorderable = true
+	t.mode = SelectSingle
+	t.current = TableCellPos{-1, -1}
+	t.hovered = TableCellPos{-1, -1}
+	t.sortColumn = -1
+	t.headerHover = -1
+	t.resizeCol = -1
+	t.pressPos = -1
+	return t
+}
+
+// SetDataSource sets the data source, clearing the selection and scrolling to the top
+func (t *Table) SetDataSource(src TableDataSource) {
+
+	t.src = src
+	t.current = TableCellPos{-1, -1}
+	t.hovered = TableCellPos{-1, -1}
+	t.offset = gb.Vec2{}
+	t.ClearSelection()
+}
+
+// DataSource returns the data source
+func (t *Table) DataSource() TableDataSource {
+
+	return t.src
+}
+
+// AddColumn appends a resizable column with the specified title and width and returns it for
+// further configuration. A zero width uses the default width.
+func (t *Table) AddColumn(title string, width float32) *TableColumn {
+
+	if width <= 0 {
+		width = tableDefaultColumnWidth
+	}
+	c := &TableColumn{Title: title, Width: width, MinWidth: tableMinColumnWidth, Resizable: true}
+	t.order = append(t.order, len(t.columns))
+	t.columns = append(t.columns, c)
+	return c
+}
+
+// Column returns the column with the specified index
+func (t *Table) Column(index int) *TableColumn {
+
+	return t.columns[index]
+}
+
+// ColumnCount returns the number of columns
+func (t *Table) ColumnCount() int {
+
+	return len(t.columns)
+}
+
+// SetColumnOrder sets the display order of the columns as a permutation of their indices.
+// Invalid orders are ignored.
+func (t *Table) SetColumnOrder(order []int) {
+
+	if len(order) != len(t.columns) {
+		return
+	}
+	seen := make([]bool, len(order))
+	for _, index := range order {
+		if index < 0 || index >= len(order) || seen[index] {
+			return
+		}
+		seen[index] = true
+	}
+	t.order = append(t.order[:0], order...)
+}
+
+// ColumnOrder returns the column indices in display order
+func (t *Table) ColumnOrder() []int {
+
+	return append([]int(nil), t.order...)
+}
+
+// SetReorderable sets if the columns can be moved by dragging their headers. The default is true.
+func (t *Table) SetReorderable(reorderable bool) {
+
+	t.reorderable = reorderable
+}
+
+// Reorderable returns if the columns can be moved by dragging their headers
+func (t *Table) Reorderable() bool {
+
+	return t.reorderable
+}
+
+// SetRowHeight sets the height of the rows and of the header.
+// The default (0) uses the height of the regular font plus padding.
+func (t *Table) SetRowHeight(height float32) {
+
+	t.rowH = height
+}
+
+// RowHeight returns the row height set by SetRowHeight()
+func (t *Table) RowHeight() float32 {
+
+	return t.rowH
+}
+
+// SetSelectionMode sets the selection mode, clearing the selection. The default is SelectSingle.
+func (t *Table) SetSelectionMode(mode SelectionMode) {
+
+	t.mode = mode
+	t.ClearSelection()
+}
+
+// SelectionMode returns the selection mode
+func (t *Table) SelectionMode() SelectionMode {
+
+	return t.mode
+}
+
+// SetRowSelection sets if whole rows are selected instead of cells, clearing the selection
+func (t *Table) SetRowSelection(rowSelect bool) {
+
+	t.rowSelect = rowSelect
+	t.ClearSelection()
+}
+
+// RowSelection returns if whole rows are selected instead of cells
+func (t *Table) RowSelection() bool {
+
+	return t.rowSelect
+}
+
+// Select selects only the specified cell (or its row), making it the current cell
+func (t *Table) Select(row, column int) {
+
+	if !t.validCell(row, column) {
+		return
+	}
+	t.current = TableCellPos{row, column}
+	t.anchor = t.current
+	t.selectRange(t.current, t.current, false)
+}
+
+// SetSelected sets the selection state of the specified cell (or its row).
+// In single selection mode selecting a cell unselects the others.
+func (t *Table) SetSelected(row, column int, selected bool) {
+
+	if !t.validCell(row, column) || t.mode == SelectNone || t.Selected(row, column) == selected {
+		return
+	}
+	if t.all {
+		t.expandAll()
+	}
+	if selected && t.mode == SelectSingle {
+		t.ranges = nil
+	}
+	r := t.newRange(row, row, []int{column})
+	if selected {
+		t.addRange(r)
+	} else {
+		t.removeRange(r)
+	}
+	t.selectionChanged()
+}
+
+// Selected returns if the specified cell is selected
+func (t *Table) Selected(row, column int) bool {
+
+	if t.all {
+		return true
+	}
+	for i := range t.ranges {
+		if t.ranges[i].contains(row, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectedCells returns the selected cells sorted by row and column.
+// With row selection the column of the cells is -1.
+func (t *Table) SelectedCells() []TableCellPos {
+
+	var cells []TableCellPos
+	for _, r := range t.selectedRanges() {
+		for row := r.first; row <= r.last; row++ {
+			if r.columns == nil {
+				cells = append(cells, TableCellPos{row, -1})
+				continue
+			}
+			for _, column := range r.columns {
+				cells = append(cells, TableCellPos{row, column})
+			}
+		}
+	}
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i].Row != cells[j].Row {
+			return cells[i].Row < cells[j].Row
+		}
+		return cells[i].Column < cells[j].Column
+	})
+	return cells
+}
+
+// SelectedRows returns the sorted indices of the rows with selected cells
+func (t *Table) SelectedRows() []int {
+
+	// Merges the overlapping row intervals of the ranges sorted by their first row
+	ranges := append([]tableRange(nil), t.selectedRanges()...)
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].first < ranges[j].first })
+	var rows []int
+	for _, r := range ranges {
+		first := r.first
+		if len(rows) > 0 {
+			first = util.Max(first, rows[len(rows)-1]+1)
+		}
+		for row := first; row <= r.last; row++ {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// SelectAll selects all cells in multi selection mode
+func (t *Table) SelectAll() {
+
+	if t.mode != SelectMulti || t.rowCount() == 0 || t.all {
+		return
+	}
+	t.all = true
+	t.ranges = nil
+	t.selectionChanged()
+}
+
+// ClearSelection unselects all cells
+func (t *Table) ClearSelection() {
+
+	if len(t.ranges) == 0 && !t.all {
+		return
+	}
+	t.all = false
+	t.ranges = nil
+	t.selectionChanged()
+}
+
+// SetCurrentCell sets the current cell for keyboard navigation and scrolls to show it
+func (t *Table) SetCurrentCell(row, column int) {
+
+	if !t.validCell(row, column) {
+		return
+	}
+	t.current = TableCellPos{row, column}
+	t.ScrollToCell(row, column)
+}
+
+// CurrentCell returns the row and column of the current cell or -1, -1 if none
+func (t *Table) CurrentCell() (int, int) {
+
+	return t.current.Row, t.current.Column
+}
+
+// ScrollToCell scrolls the minimum necessary to show the specified cell
+func (t *Table) ScrollToCell(row, column int) {
+
+	if !t.validCell(row, column) {
+		return
+	}
+	t.EnsureVisible(t.cellRect(row, t.displayPos(column)))
+}
+
+// CellAt returns the row and column of the cell at the specified point in local coordinates or -1, -1 if none
+func (t *Table) CellAt(pos gb.Vec2) (int, int) {
+
+	if !t.viewport.Contains(pos) {
+		return -1, -1
+	}
+	p := t.toContent(pos)
+	h := t.rowHeight()
+	if h <= 0 {
+		return -1, -1
+	}
+	row := int(p.Y / h)
+	dpos := t.displayPosAtX(p.X)
+	if row >= t.rowCount() || dpos < 0 {
+		return -1, -1
+	}
+	return row, t.order[dpos]
+}
+
+// SetSort sets the sorted column and order, which is indicated in its header, sorting the rows
+// if the data source implements TableSorter, and clears the selection. A negative column clears the sort indicator.
+func (t *Table) SetSort(column int, ascending bool) {
+
+	if column >= len(t.columns) {
+		return
+	}
+	t.sortColumn = column
+	t.sortAsc = ascending
+	if column < 0 {
+		return
+	}
+	if sorter, ok := t.src.(TableSorter); ok {
+		sorter.Sort(column, ascending)
+	}
+	t.ClearSelection()
+	if t.onSort != nil {
+		t.onSort(t, column, ascending)
+	}
+}
+
+// Sort returns the sorted column (-1 if none) and if the order is ascending
+func (t *Table) Sort() (int, bool) {
+
+	return t.sortColumn, t.sortAsc
+}
+
+// DataChanged must be called when the rows of the data source changed.
+// Selected cells and the current cell outside the new rows are discarded.
+func (t *Table) DataChanged() {
+
+	n := t.rowCount()
+	if t.current.Row >= n {
+		t.current = TableCellPos{-1, -1}
+	}
+	t.anchor.Row = util.Max(util.Min(t.anchor.Row, n-1), 0)
+	t.hovered = TableCellPos{-1, -1}
+	changed := false
+	ranges := t.ranges[:0]
+	for _, r := range t.ranges {
+		if r.first >= n {
+			changed = true
+			continue
+		}
+		if r.last >= n {
+			r.last = n - 1
+			changed = true
+		}
+		ranges = append(ranges, r)
+	}
+	t.ranges = ranges
+	if t.all && n == 0 {
+		t.all = false
+		changed = true
+	}
+	if changed {
+		t.selectionChanged()
+	}
+}
+
+// OnSelectionChange sets the function called when the selection changes
+func (t *Table) OnSelectionChange(cb func(t *Table)) {
+
+	t.onSelect = cb
+}
+
+// OnActivate sets the function called when a cell is activated by the Enter key
+func (t *Table) OnActivate(cb func(t *Table, row, column int)) {
+
+	t.onActivate = cb
+}
+
+// OnSort sets the function called when the sorted column or order is changed
+func (t *Table) OnSort(cb func(t *Table, column int, ascending bool)) {
+
+	t.onSort = cb
+}
+
+// Measure satisfies the IView interface
+func (t *Table) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	t.autoHeight = w.Font(window.FontRegular, 0).Height() + 2*listItemPadding
+	t.header = t.rowHeight()
+	size := t.ScrollView.Measure(w, avail)
+	if t.prefSize.Y == 0 {
+		size.Y = util.Min(size.Y, t.rowHeight()*(tableDefaultRows+1)+t.padding.Vertical())
+		size = t.ClampSize(size)
+	}
+	return size
+}
+
+// OnEvent satisfies the IView interface
+func (t *Table) OnEvent(w *window.Window, ev *Event) bool {
+
+	switch ev.Type {
+	case EventMouseMove:
+		p := t.toContent(ev.Pos)
+		if t.resizeCol >= 0 {
+			c := t.columns[t.resizeCol]
+			c.Width = util.Max(t.resizeWidth+p.X-t.resizeX, c.MinWidth)
+			return true
+		}
+		if t.pressPos >= 0 {
+			if !t.moving && t.reorderable && util.Abs(p.X-t.pressX) > tableDragThreshold {
+				t.moving = true
+			}
+			t.moveX = p.X
+			return true
+		}
+		t.headerHover = -1
+		t.hovered = TableCellPos{-1, -1}
+		resize := false
+		if t.inHeader(ev.Pos) {
+			t.headerHover = t.displayPosAtX(p.X)
+			resize = t.borderAt(p.X) >= 0
+		} else {
+			t.hovered.Row, t.hovered.Column = t.CellAt(ev.Pos)
+		}
+		t.setResizeHover(w, resize)
+	case EventMouseLeave:
+		t.headerHover = -1
+		t.hovered = TableCellPos{-1, -1}
+		if t.resizeCol < 0 {
+			t.setResizeHover(w, false)
+		}
+	case EventMouseDown:
+		if t.disabled || ev.Button != gb.MouseButtonLeft {
+			break
+		}
+		if axis, _ := t.barAt(ev.Pos); axis >= 0 {
+			break
+		}
+		p := t.toContent(ev.Pos)
+		if t.inHeader(ev.Pos) {
+			if dpos := t.borderAt(p.X); dpos >= 0 {
+				t.resizeCol = t.order[dpos]
+				t.resizeX = p.X
+				t.resizeWidth = t.columns[t.resizeCol].Width
+			} else if dpos := t.displayPosAtX(p.X); dpos >= 0 {
+				t.pressPos = dpos
+				t.pressX = p.X
+				t.moveX = p.X
+			}
+			return true
+		}
+		row, column := t.CellAt(ev.Pos)
+		if row < 0 {
+			break
+		}
+		t.clickCell(TableCellPos{row, column}, ev.Mods)
+		return true
+	case EventMouseUp:
+		if ev.Button != gb.MouseButtonLeft {
+			break
+		}
+		if t.resizeCol >= 0 {
+			t.resizeCol = -1
+			t.setResizeHover(w, t.inHeader(ev.Pos) && t.borderAt(t.toContent(ev.Pos).X) >= 0)
+			return true
+		}
+		if t.pressPos >= 0 {
+			if t.moving {
+				t.moveColumn(t.pressPos, t.dropPos())
+			} else if c := t.columns[t.order[t.pressPos]]; c.Sortable {
+				column := t.order[t.pressPos]
+				t.SetSort(column, !(t.sortColumn == column && t.sortAsc))
+			}
+			t.pressPos = -1
+			t.moving = false
+			return true
+		}
+	case EventKeyDown:
+		if !t.disabled && t.onKey(ev) {
+			return true
+		}
+	}
+	return t.ScrollView.OnEvent(w, ev)
+}
+
+// Render satisfies the IView interface
+func (t *Table) Render(w *window.Window) {
+
+	if !t.visible {
+		return
+	}
+	t.ScrollView.Render(w)
+
+	// Draws the header clipped to its area
+	dl := t.BeginRender()
+	fa := w.Font(window.FontRegular, 0)
+	header := t.headerRect()
+	w.PushClipRect(t.WindowRect(header))
+	w.AddRectFilled(dl, header.Min, header.Max, t.StyleColor(w, StyleColorTableHeader).RGBA(), 0, 0)
+	border := t.StyleColor(w, StyleColorBorder).RGBA()
+	textColor := t.StyleColor(w, StyleColorText).RGBA()
+	if t.disabled {
+		textColor = t.StyleColor(w, StyleColorTextDisabled).RGBA()
+	}
+	x := header.Min.X - t.offset.X
+	for dpos, index := range t.order {
+		c := t.columns[index]
+		r := gb.Rect{Min: gb.Vec2{x, header.Min.Y}, Max: gb.Vec2{x + c.Width, header.Max.Y}}
+		x += c.Width
+		if r.Max.X < header.Min.X {
+			continue
+		}
+		if r.Min.X > header.Max.X {
+			break
+		}
+		if dpos == t.headerHover && !t.disabled && !t.moving && t.resizeCol < 0 {
+			w.AddRectFilled(dl, r.Min, r.Max, t.StyleColor(w, StyleColorButtonHovered).RGBA(), 0, 0)
+		}
+		t.renderHeaderCell(w, dl, fa, r, index, textColor)
+		w.AddLine(dl, gb.Vec2{r.Max.X, r.Min.Y}, gb.Vec2{r.Max.X, r.Max.Y}, border, 1)
+	}
+
+	// Draws the moving column header at the cursor and the position where it will be dropped
+	if t.moving {
+		c := t.columns[t.order[t.pressPos]]
+		mx := header.Min.X - t.offset.X + t.moveX - c.Width/2
+		r := gb.Rect{Min: gb.Vec2{mx, header.Min.Y}, Max: gb.Vec2{mx + c.Width, header.Max.Y}}
+		w.AddRectFilled(dl, r.Min, r.Max, t.StyleColor(w, StyleColorButtonPressed).RGBA(), 0, 0)
+		t.renderHeaderCell(w, dl, fa, r, t.order[t.pressPos], textColor)
+		dropX := header.Min.X - t.offset.X + t.columnX(t.dropPos())
+		if t.dropPos() > t.pressPos {
+			dropX += c.Width
+		}
+		w.AddLine(dl, gb.Vec2{dropX, header.Min.Y}, gb.Vec2{dropX, header.Max.Y}, t.StyleColor(w, StyleColorFocus).RGBA(), 2)
+	}
+	w.PopClipRect()
+	w.AddLine(dl, gb.Vec2{header.Min.X, header.Max.Y}, header.Max, border, 1)
+	if t.HasFocus(w) && !t.disabled {
+		w.AddRect(dl, gb.Vec2{}, t.size, t.StyleColor(w, StyleColorFocus).RGBA(), 0, 0, 1)
+	}
+	t.EndRender(w)
+}
+
+// Measure satisfies the IView interface
+func (c *tableContent) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	return gb.Vec2{c.t.columnX(len(c.t.order)), float32(c.t.rowCount()) * c.t.rowHeight()}
+}
+
+// Render satisfies the IView interface
+func (c *tableContent) Render(w *window.Window) {
+
+	t := c.t
+	dl := c.BeginRender()
+	rows := t.rowCount()
+	h := t.rowHeight()
+	if rows == 0 || h <= 0 || t.src == nil {
+		c.EndRender(w)
+		return
+	}
+	vsize := t.viewport.Size()
+	first := int(t.offset.Y / h)
+	last := util.Min(int((t.offset.Y+vsize.Y)/h), rows-1)
+	left := t.offset.X
+	right := util.Min(left+vsize.X, c.size.X)
+	focused := t.HasFocus(w) && !t.disabled
+	border := t.StyleColor(w, StyleColorBorder).RGBA()
+	cell := TableCell{Table: t, DL: dl}
+
+	for row := first; row <= last; row++ {
+		y := float32(row) * h
+		rowRect := gb.Rect{Min: gb.Vec2{left, y}, Max: gb.Vec2{right, y + h}}
+
+		// Draws the row background
+		switch {
+		case t.rowSelect && t.Selected(row, 0):
+			w.AddRectFilled(dl, rowRect.Min, rowRect.Max, t.StyleColor(w, StyleColorItemSelected).RGBA(), 0, 0)
+		case t.rowSelect && t.hovered.Row == row && !t.disabled:
+			w.AddRectFilled(dl, rowRect.Min, rowRect.Max, t.StyleColor(w, StyleColorItemHovered).RGBA(), 0, 0)
+		case row%2 == 1:
+			w.AddRectFilled(dl, rowRect.Min, rowRect.Max, t.StyleColor(w, StyleColorTableRowAlt).RGBA(), 0, 0)
+		}
+
+		// Draws the visible cells of the row, each one clipped to its rectangle
+		x := float32(0)
+		for _, index := range t.order {
+			width := t.columns[index].Width
+			if x+width <= left {
+				x += width
+				continue
+			}
+			if x >= right {
+				break
+			}
+			cell.Row = row
+			cell.Column = index
+			cell.Rect = gb.Rect{Min: gb.Vec2{x, y}, Max: gb.Vec2{x + width, y + h}}
+			cell.Selected = t.Selected(row, index)
+			cell.Hovered = t.hovered.Row == row && (t.rowSelect || t.hovered.Column == index)
+			cell.Current = focused && t.current.Row == row && (t.rowSelect || t.current.Column == index)
+			if !t.rowSelect {
+				if cell.Selected {
+					w.AddRectFilled(dl, cell.Rect.Min, cell.Rect.Max, t.StyleColor(w, StyleColorItemSelected).RGBA(), 0, 0)
+				} else if cell.Hovered && !t.disabled {
+					w.AddRectFilled(dl, cell.Rect.Min, cell.Rect.Max, t.StyleColor(w, StyleColorItemHovered).RGBA(), 0, 0)
+				}
+			}
+			w.PushClipRect(c.WindowRect(cell.Rect))
+			t.src.RenderCell(w, &cell)
+			w.PopClipRect()
+			if cell.Current && !t.rowSelect {
+				w.AddRect(dl, cell.Rect.Min, cell.Rect.Max, t.StyleColor(w, StyleColorFocus).RGBA(), 0, 0, 1)
+			}
+			w.AddLine(dl, gb.Vec2{x + width, y}, gb.Vec2{x + width, y + h}, border, 1)
+			x += width
+		}
+		if t.rowSelect && focused && t.current.Row == row {
+			w.AddRect(dl, rowRect.Min, rowRect.Max, t.StyleColor(w, StyleColorFocus).RGBA(), 0, 0, 1)
+		}
+	}
+	c.EndRender(w)
+}
+
+// DrawText draws the specified text in the cell rectangle, vertically centered,
+// aligned as specified by its column and with the table text color
+func (cell *TableCell) DrawText(w *window.Window, text string) {
+
+	t := cell.Table
+	fa := w.Font(window.FontRegular, 0)
+	col := StyleColorText
+	if t.disabled {
+		col = StyleColorTextDisabled
+	}
+	drawAlignedText(w, cell.DL, fa, cell.Rect, t.columns[cell.Column].Align, text, t.StyleColor(w, col).RGBA())
+}
+
+// renderHeaderCell draws the title and the sort indicator of the specified column in the header cell rectangle
+func (t *Table) renderHeaderCell(w *window.Window, dl *gb.DrawList, fa *window.FontAtlas, r gb.Rect, index int, col gb.RGBA) {
+
+	c := t.columns[index]
+	text := r
+	if index == t.sortColumn {
+		size := fa.Height() * 0.5
+		text.Max.X -= size + listItemPadding
+		dir := arrowDown
+		if t.sortAsc {
+			dir = arrowUp
+		}
+		drawArrow(w, dl, gb.Vec2{r.Max.X - listItemPadding - size/2, (r.Min.Y + r.Max.Y) / 2}, size, dir, col)
+	}
+	w.PushClipRect(t.WindowRect(text))
+	drawAlignedText(w, dl, fa, text, c.Align, c.Title, col)
+	w.PopClipRect()
+}
+
+// drawAlignedText draws the text vertically centered in the rectangle with
+// the specified horizontal alignment and padding
+func drawAlignedText(w *window.Window, dl *gb.DrawList, fa *window.FontAtlas, r gb.Rect, align Align, text string, col gb.RGBA) {
+
+	space := r.Size().X - 2*listItemPadding
+	pos := gb.Vec2{
+		r.Min.X + listItemPadding + alignOffset(align, space, fa.MeasureString(text)),
+		r.Min.Y + (r.Size().Y-fa.Height())/2,
+	}
+	w.AddText(dl, fa, &pos, col, window.TextVAlignTop, text)
+}
+
+// clickCell updates the current cell and the selection for a click on the specified cell
+func (t *Table) clickCell(pos TableCellPos, mods gb.ModKey) {
+
+	t.current = pos
+	switch {
+	case t.mode == SelectMulti && mods&gb.ModShift != 0:
+		t.selectRange(t.anchor, pos, mods&gb.ModControl != 0)
+	case t.mode == SelectMulti && mods&gb.ModControl != 0:
+		t.anchor = pos
+		t.SetSelected(pos.Row, pos.Column, !t.Selected(pos.Row, pos.Column))
+	default:
+		t.anchor = pos
+		t.selectRange(pos, pos, false)
+	}
+}
+
+// onKey processes a key down event and returns if it was handled
+func (t *Table) onKey(ev *Event) bool {
+
+	rows := t.rowCount()
+	if rows == 0 || len(t.order) == 0 {
+		return false
+	}
+	shift := ev.Mods&gb.ModShift != 0
+	ctrl := ev.Mods&gb.ModControl != 0
+	row := util.Max(t.current.Row, 0)
+	dpos := 0
+	if t.current.Row >= 0 {
+		dpos = t.displayPos(t.current.Column)
+	}
+	page := util.Max(int(t.viewport.Size().Y/t.rowHeight()), 1)
+	switch ev.Key {
+	case gb.KeyUp:
+		row--
+	case gb.KeyDown:
+		row++
+	case gb.KeyLeft:
+		dpos--
+	case gb.KeyRight:
+		dpos++
+	case gb.KeyPageUp:
+		row -= page
+	case gb.KeyPageDown:
+		row += page
+	case gb.KeyHome:
+		if ctrl {
+			row = 0
+		} else {
+			dpos = 0
+		}
+	case gb.KeyEnd:
+		if ctrl {
+			row = rows - 1
+		} else {
+			dpos = len(t.order) - 1
+		}
+	case gb.KeySpace:
+		if t.current.Row < 0 {
+			return false
+		}
+		if ctrl && t.mode == SelectMulti {
+			t.SetSelected(t.current.Row, t.current.Column, !t.Selected(t.current.Row, t.current.Column))
+		} else {
+			t.selectRange(t.current, t.current, false)
+		}
+		t.anchor = t.current
+		return true
+	case gb.KeyA:
+		if !ctrl || t.mode != SelectMulti {
+			return false
+		}
+		t.SelectAll()
+		return true
+	case gb.KeyEnter, gb.KeyKPEnter:
+		if t.current.Row < 0 || t.onActivate == nil {
+			return false
+		}
+		t.onActivate(t, t.current.Row, t.current.Column)
+		return true
+	default:
+		return false
+	}
+	target := TableCellPos{util.Clamp(row, 0, rows-1), t.order[util.Clamp(dpos, 0, len(t.order)-1)]}
+	t.current = target
+	t.ScrollToCell(target.Row, target.Column)
+	switch {
+	case shift && t.mode == SelectMulti:
+		t.selectRange(t.anchor, target, false)
+	case ctrl && t.mode == SelectMulti:
+		// Moves the current cell keeping the selection
+	default:
+		t.anchor = target
+		t.selectRange(target, target, false)
+	}
+	return true
+}
+
+// selectRange selects the rectangle of cells between the specified cells in display order,
+// adding them to the current selection if requested or replacing it
+func (t *Table) selectRange(from, to TableCellPos, add bool) {
+
+	if t.mode == SelectNone {
+		return
+	}
+	if t.mode == SelectSingle {
+		from = to
+		add = false
+	}
+	if t.all {
+		if add {
+			return
+		}
+		t.all = false
+	}
+	r0, r1 := util.Min(from.Row, to.Row), util.Max(from.Row, to.Row)
+	p0, p1 := t.displayPos(from.Column), t.displayPos(to.Column)
+	if p0 > p1 {
+		p0, p1 = p1, p0
+	}
+	columns := make([]int, 0, p1-p0+1)
+	for p := p0; p <= p1; p++ {
+		columns = append(columns, t.order[p])
+	}
+	sort.Ints(columns)
+	if !add {
+		t.ranges = nil
+	}
+	t.addRange(t.newRange(r0, r1, columns))
+	t.selectionChanged()
+}
+
+// selectionChanged calls the selection change callback
+func (t *Table) selectionChanged() {
+
+	if t.onSelect != nil {
+		t.onSelect(t)
+	}
+}
+
+// expandAll replaces the select all state by a range with all cells
+func (t *Table) expandAll() {
+
+	t.ranges = t.selectedRanges()
+	t.all = false
+}
+
+// selectedRanges returns the ranges of selected cells, which is a single range with all cells
+// in the select all state. The returned slice must not be modified.
+func (t *Table) selectedRanges() []tableRange {
+
+	if !t.all {
+		return t.ranges
+	}
+	if t.rowCount() == 0 {
+		return nil
+	}
+	columns := make([]int, len(t.columns))
+	for i := range columns {
+		columns[i] = i
+	}
+	return []tableRange{t.newRange(0, t.rowCount()-1, columns)}
+}
+
+// newRange returns a range with the specified rows and sorted columns, without columns with row selection
+func (t *Table) newRange(first, last int, columns []int) tableRange {
+
+	if t.rowSelect {
+		columns = nil
+	}
+	return tableRange{first: first, last: last, columns: columns}
+}
+
+// addRange adds the specified range to the selection, keeping the ranges disjoint
+func (t *Table) addRange(r tableRange) {
+
+	t.removeRange(r)
+	t.ranges = append(t.ranges, r)
+}
+
+// removeRange removes the cells of the specified range from the selection
+func (t *Table) removeRange(r tableRange) {
+
+	var ranges []tableRange
+	for _, sr := range t.ranges {
+		ranges = append(ranges, sr.subtract(r)...)
+	}
+	t.ranges = ranges
+}
+
+// contains returns if the range contains the specified cell
+func (r *tableRange) contains(row, column int) bool {
+
+	if row < r.first || row > r.last {
+		return false
+	}
+	if r.columns == nil {
+		return true
+	}
+	i := sort.SearchInts(r.columns, column)
+	return i < len(r.columns) && r.columns[i] == column
+}
+
+// subtract returns the up to three ranges which contain the cells of this range not in the specified range
+func (r tableRange) subtract(o tableRange) []tableRange {
+
+	first, last := util.Max(r.first, o.first), util.Min(r.last, o.last)
+	if first > last {
+		return []tableRange{r}
+	}
+
+	// Columns of the overlapped rows which are kept
+	var rest []int
+	if r.columns != nil && o.columns != nil {
+		for _, column := range r.columns {
+			i := sort.SearchInts(o.columns, column)
+			if i >= len(o.columns) || o.columns[i] != column {
+				rest = append(rest, column)
+			}
+		}
+		if len(rest) == len(r.columns) {
+			return []tableRange{r}
+		}
+	}
+	var parts []tableRange
+	if r.first < first {
+		parts = append(parts, tableRange{first: r.first, last: first - 1, columns: r.columns})
+	}
+	if len(rest) > 0 {
+		parts = append(parts, tableRange{first: first, last: last, columns: rest})
+	}
+	if last < r.last {
+		parts = append(parts, tableRange{first: last + 1, last: r.last, columns: r.columns})
+	}
+	return parts
+}
+
+// moveColumn moves the column at the specified display position to another display position
+func (t *Table) moveColumn(from, to int) {
+
+	if from == to {
+		return
+	}
+	index := t.order[from]
+	order := append(t.order[:from:from], t.order[from+1:]...)
+	order = append(order[:to], append([]int{index}, order[to:]...)...)
+	t.order = order
+}
+
+// dropPos returns the display position where the moving column would be dropped
+func (t *Table) dropPos() int {
+
+	dpos := t.displayPosAtX(t.moveX)
+	if dpos < 0 {
+		if t.moveX < 0 {
+			return 0
+		}
+		return len(t.order) - 1
+	}
+	return dpos
+}
+
+// setResizeHover sets if the cursor is over a resizable column border, changing its shape
+func (t *Table) setResizeHover(w *window.Window, hover bool) {
+
+	if hover == t.resizeHover {
+		return
+	}
+	t.resizeHover = hover
+	if hover {
+		w.SetCursor(gb.CursorHResize)
+	} else {
+		w.SetCursor(gb.CursorDefault)
+	}
+}
+
+// borderAt returns the display position of the resizable column whose right border
+// is near the specified horizontal position in content coordinates or -1
+func (t *Table) borderAt(x float32) int {
+
+	edge := float32(0)
+	for dpos, index := range t.order {
+		c := t.columns[index]
+		edge += c.Width
+		if c.Resizable && util.Abs(x-edge) <= tableResizeMargin {
+			return dpos
+		}
+	}
+	return -1
+}
+
+// displayPosAtX returns the display position of the column at the specified
+// horizontal position in content coordinates or -1
+func (t *Table) displayPosAtX(x float32) int {
+
+	if x < 0 {
+		return -1
+	}
+	edge := float32(0)
+	for dpos, index := range t.order {
+		edge += t.columns[index].Width
+		if x < edge {
+			return dpos
+		}
+	}
+	return -1
+}
+
+// displayPos returns the display position of the column with the specified index
+func (t *Table) displayPos(column int) int {
+
+	for dpos, index := range t.order {
+		if index == column {
+			return dpos
+		}
+	}
+	return 0
+}
+
+// columnX returns the horizontal position in content coordinates of the column at the
+// specified display position. The number of columns gives the total width.
+func (t *Table) columnX(dpos int) float32 {
+
+	x := float32(0)
+	for _, index := range t.order[:util.Clamp(dpos, 0, len(t.order))] {
+		x += t.columns[index].Width
+	}
+	return x
+}
+
+// cellRect returns the rectangle in content coordinates of the cell at the specified row and display position
+func (t *Table) cellRect(row, dpos int) gb.Rect {
+
+	h := t.rowHeight()
+	x := t.columnX(dpos)
+	return gb.Rect{
+		Min: gb.Vec2{x, float32(row) * h},
+		Max: gb.Vec2{x + t.columns[t.order[dpos]].Width, float32(row+1) * h},
+	}
+}
+
+// headerRect returns the header rectangle in local coordinates
+func (t *Table) headerRect() gb.Rect {
+
+	return gb.Rect{
+		Min: gb.Vec2{t.viewport.Min.X, t.viewport.Min.Y - t.header},
+		Max: gb.Vec2{t.viewport.Max.X, t.viewport.Min.Y},
+	}
+}
+
+// inHeader returns if the specified local point is inside the header
+func (t *Table) inHeader(pos gb.Vec2) bool {
+
+	return t.headerRect().Contains(pos)
+}
+
+// toContent converts a local point to content coordinates
+func (t *Table) toContent(pos gb.Vec2) gb.Vec2 {
+
+	return gb.Vec2{pos.X - t.viewport.Min.X + t.offset.X, pos.Y - t.viewport.Min.Y + t.offset.Y}
+}
+
+// validCell returns if the specified cell exists
+func (t *Table) validCell(row, column int) bool {
+
+	return row >= 0 && row < t.rowCount() && column >= 0 && column < len(t.columns)
+}
+
+// rowCount returns the number of rows
+func (t *Table) rowCount() int {
+
+	if t.src == nil {
+		return 0
+	}
+	return t.src.Rows()
+}
+
+// rowHeight returns the height of the rows and of the header
+func (t *Table) rowHeight() float32 {
+
+	if t.rowH > 0 {
+		return t.rowH
+	}
+	return t.autoHeight
+}
@@ -0,0 +1,111 @@
+package view
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/leonsal/gux/window"
+)
+
+// testTableSource is a table data source with a variable number of rows which draws nothing
+type testTableSource struct {
+	rows int
+}
+
+func (s *testTableSource) Rows() int                                    { return s.rows }
+func (s *testTableSource) RenderCell(w *window.Window, cell *TableCell) {}
+
+// newTestTable returns a multi selection table with the specified number of rows and columns
+func newTestTable(rows, columns int) (*Table, *testTableSource) {
+
+	src := &testTableSource{rows: rows}
+	t := NewTable(src)
+	for i := 0; i < columns; i++ {
+		t.AddColumn("", 0)
+	}
+	t.SetSelectionMode(SelectMulti)
+	return t, src
+}
+
+func TestTableRangeSubtract(t *testing.T) {
+
+	cases := []struct {
+		name string
+		r, o tableRange
+		want []tableRange
+	}{
+		{"disjoint rows", tableRange{0, 4, []int{0, 1}}, tableRange{5, 9, []int{0, 1}}, []tableRange{{0, 4, []int{0, 1}}}},
+		{"disjoint columns", tableRange{0, 4, []int{0, 1}}, tableRange{0, 4, []int{2}}, []tableRange{{0, 4, []int{0, 1}}}},
+		{"all", tableRange{2, 3, []int{1}}, tableRange{0, 9, []int{0, 1, 2}}, nil},
+		{"middle cell", tableRange{0, 4, []int{0, 1, 2}}, tableRange{2, 2, []int{1}}, []tableRange{
+			{0, 1, []int{0, 1, 2}}, {2, 2, []int{0, 2}}, {3, 4, []int{0, 1, 2}},
+		}},
+		{"first rows", tableRange{0, 4, nil}, tableRange{0, 1, nil}, []tableRange{{2, 4, nil}}},
+		{"last rows", tableRange{0, 4, nil}, tableRange{3, 9, nil}, []tableRange{{0, 2, nil}}},
+	}
+	for _, c := range cases {
+		got := c.r.subtract(c.o)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestTableSelection(t *testing.T) {
+
+	tbl, _ := newTestTable(1000, 3)
+	tbl.Select(1, 0)
+	tbl.selectRange(TableCellPos{1, 0}, TableCellPos{2, 1}, false)
+	tbl.SetSelected(1, 1, false)
+	tbl.SetSelected(5, 2, true)
+	want := []TableCellPos{{1, 0}, {2, 0}, {2, 1}, {5, 2}}
+	if got := tbl.SelectedCells(); !reflect.DeepEqual(got, want) {
+		t.Errorf("SelectedCells: got %v, want %v", got, want)
+	}
+	if got := tbl.SelectedRows(); !reflect.DeepEqual(got, []int{1, 2, 5}) {
+		t.Errorf("SelectedRows: got %v", got)
+	}
+
+	// Unselecting a cell after selecting all keeps a few ranges
+	tbl.SelectAll()
+	tbl.SetSelected(500, 1, false)
+	if len(tbl.ranges) > 3 {
+		t.Errorf("got %d ranges after unselecting one cell of all", len(tbl.ranges))
+	}
+	if tbl.Selected(500, 1) || !tbl.Selected(500, 0) || !tbl.Selected(999, 2) {
+		t.Errorf("wrong selection after unselecting one cell of all")
+	}
+	if got := len(tbl.SelectedRows()); got != 1000 {
+		t.Errorf("SelectedRows: got %d rows, want 1000", got)
+	}
+}
+
+func TestTableRowSelection(t *testing.T) {
+
+	tbl, _ := newTestTable(10, 3)
+	tbl.SetRowSelection(true)
+	tbl.selectRange(TableCellPos{2, 0}, TableCellPos{4, 2}, false)
+	tbl.selectRange(TableCellPos{3, 1}, TableCellPos{6, 1}, true)
+	want := []TableCellPos{{2, -1}, {3, -1}, {4, -1}, {5, -1}, {6, -1}}
+	if got := tbl.SelectedCells(); !reflect.DeepEqual(got, want) {
+		t.Errorf("SelectedCells: got %v, want %v", got, want)
+	}
+	if !tbl.Selected(4, 2) {
+		t.Errorf("row 4 must be selected in any column")
+	}
+}
+
+func TestTableDataChanged(t *testing.T) {
+
+	tbl, src := newTestTable(10, 2)
+	tbl.clickCell(TableCellPos{8, 0}, 0)
+	tbl.selectRange(TableCellPos{3, 0}, TableCellPos{9, 1}, false)
+	src.rows = 5
+	tbl.DataChanged()
+	if got := tbl.SelectedRows(); !reflect.DeepEqual(got, []int{3, 4}) {
+		t.Errorf("SelectedRows after shrinking: got %v", got)
+	}
+	if tbl.anchor.Row != 4 {
+		t.Errorf("anchor row after shrinking: got %d, want 4", tbl.anchor.Row)
+	}
+}
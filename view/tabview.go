@@ -0,0 +1,578 @@
+package view
+
+import (
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// Horizontal space between the tab borders and its title
+const tabPadding = 10
+
+// Vertical space between the tab bar borders and the tab titles
+const tabVPadding = 4
+
+// Horizontal space between tabs
+const tabSpacing = 2
+
+// Distance the cursor must move after pressing a tab to start moving it
+const tabDragThreshold = 5
+
+// tabItem contains the state of a TabView tab
+type tabItem struct {
+	title     string  // Tab title
+	view      IView   // View shown when the tab is current
+	closeable bool    // Tab has a close button
+	width     float32 // Tab width from the last measure
+}
+
+// TabView is a container which shows a row of tabs and only the child view of the current tab below them.
+// Tabs can have close buttons and can be moved by dragging them. When the tabs are wider than the view,
+// the tab bar can be scrolled with the mouse wheel or by the arrow buttons at its right side.
+// Control+Tab and Control+PageDown select the next tab and Control+Shift+Tab and Control+PageUp
+// the previous one; the Left and Right keys also change the tab while the TabView has the focus.
+// The TabView controls the visibility of its tab views.
+type TabView struct {
+	View
+	tabs        []*tabItem               // Tabs in display order
+	current     int                      // Index of the current tab (-1 if none)
+	reorderable bool                     // Tabs can be moved by the user
+	barHeight   float32                  // Tab bar height from the last measure
+	scroll      float32                  // Horizontal scroll offset of the tabs
+	overflow    bool                     // Tabs are wider than the bar and the arrow buttons are shown
+	reveal      bool                     // Scrolls to show the current tab in the next arrange
+	hoverTab    int                      // Tab under the cursor (-1 if none)
+	hoverClose  bool                     // Cursor is over the close button of the hovered tab
+	hoverArrow  int                      // Arrow button under the cursor (-1 left, 1 right, 0 none)
+	pressTab    int                      // Tab being pressed (-1 if none)
+	pressClose  bool                     // Close button of the pressed tab is being pressed
+	pressX      float32                  // Cursor position when the tab was pressed
+	dragging    bool                     // Pressed tab is being moved
+	onChange    func(*TabView)           // Current tab change callback
+	onClose     func(*TabView, int) bool // Tab close callback
+}
+
+// NewTabView creates and returns a new TabView without tabs
+func NewTabView() *TabView {
+
+	t := new(TabView)
+	t.Init(t)
+	t.focusable = true
+	t.current = -1
+	t.reorderable = true
+	t.hoverTab = -1
+	t.pressTab = -1
+	return t
+}
+
+// AddTab appends a tab with the specified title and view and returns its index.
+// The first tab added becomes the current tab.
+func (t *TabView) AddTab(title string, view IView) int {
+
+	t.InsertTab(len(t.tabs), title, view)
+	return len(t.tabs) - 1
+}
+
+// InsertTab inserts a tab with the specified title and view at the specified index
+func (t *TabView) InsertTab(index int, title string, view IView) {
+
+	index = util.Clamp(index, 0, len(t.tabs))
+	t.View.Add(view)
+	t.tabs = append(t.tabs, nil)
+	copy(t.tabs[index+1:], t.tabs[index:])
+	t.tabs[index] = &tabItem{title: title, view: view}
+	if t.current >= index {
+		t.current++
+	}
+	if t.current < 0 {
+		t.SetCurrent(index)
+		return
+	}
+	view.GetView().visible = false
+}
+
+// RemoveTab removes the tab with the specified index and its view, which is made visible again.
+// If the current tab is removed, the next tab (or the previous one if it was the last) becomes current.
+func (t *TabView) RemoveTab(index int) {
+
+	if index < 0 || index >= len(t.tabs) {
+		return
+	}
+	view := t.tabs[index].view
+	t.View.Remove(view)
+	view.GetView().visible = true
+	t.tabs = append(t.tabs[:index], t.tabs[index+1:]...)
+	t.hoverTab = -1
+	t.pressTab = -1
+	t.dragging = false
+	switch {
+	case index < t.current:
+		t.current--
+	case index == t.current:
+		t.current = -1
+		if len(t.tabs) > 0 {
+			t.SetCurrent(util.Min(index, len(t.tabs)-1))
+		} else if t.onChange != nil {
+			t.onChange(t)
+		}
+	}
+}
+
+// CloseTab removes the tab with the specified index as if its close button was clicked,
+// if the close callback is not set or returns true
+func (t *TabView) CloseTab(index int) {
+
+	if index < 0 || index >= len(t.tabs) {
+		return
+	}
+	if t.onClose != nil && !t.onClose(t, index) {
+		return
+	}
+	t.RemoveTab(index)
+}
+
+// TabCount returns the number of tabs
+func (t *TabView) TabCount() int {
+
+	return len(t.tabs)
+}
+
+// Tab returns the view of the tab with the specified index
+func (t *TabView) Tab(index int) IView {
+
+	return t.tabs[index].view
+}
+
+// IndexOf returns the index of the tab with the specified view or -1 if not found
+func (t *TabView) IndexOf(view IView) int {
+
+	for i, tab := range t.tabs {
+		if tab.view == view {
+			return i
+		}
+	}
+	return -1
+}
+
+// SetTabTitle sets the title of the tab with the specified index
+func (t *TabView) SetTabTitle(index int, title string) {
+
+	t.tabs[index].title = title
+}
+
+// TabTitle returns the title of the tab with the specified index
+func (t *TabView) TabTitle(index int) string {
+
+	return t.tabs[index].title
+}
+
+// SetTabCloseable sets if the tab with the specified index has a close button
+func (t *TabView) SetTabCloseable(index int, closeable bool) {
+
+	t.tabs[index].closeable = closeable
+}
+
+// TabCloseable returns if the tab with the specified index has a close button
+func (t *TabView) TabCloseable(index int) bool {
+
+	return t.tabs[index].closeable
+}
+
+// SetCurrent sets the current tab, showing its view and scrolling the tab bar to show the tab
+func (t *TabView) SetCurrent(index int) {
+
+	if index < 0 || index >= len(t.tabs) || index == t.current {
+		return
+	}
+	for i, tab := range t.tabs {
+		tab.view.GetView().visible = i == index
+	}
+	t.current = index
+	t.reveal = true
+	if t.onChange != nil {
+		t.onChange(t)
+	}
+}
+
+// Current returns the index of the current tab or -1 if there are no tabs
+func (t *TabView) Current() int {
+
+	return t.current
+}
+
+// SetReorderable sets if the tabs can be moved by dragging them. The default is true.
+func (t *TabView) SetReorderable(reorderable bool) {
+
+	t.reorderable = reorderable
+}
+
+// Reorderable returns if the tabs can be moved by dragging them
+func (t *TabView) Reorderable() bool {
+
+	return t.reorderable
+}
+
+// OnChange sets the function called when the current tab changes
+func (t *TabView) OnChange(cb func(t *TabView)) {
+
+	t.onChange = cb
+}
+
+// OnClose sets the function called when the close button of a tab is clicked.
+// The tab is removed if the function returns true.
+func (t *TabView) OnClose(cb func(t *TabView, index int) bool) {
+
+	t.onClose = cb
+}
+
+// Measure satisfies the IView interface.
+// The desired size fits the largest tab view below the tab bar.
+func (t *TabView) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	fa := w.Font(window.FontRegular, 0)
+	t.barHeight = fa.Height() + 2*tabVPadding
+	content := gb.Vec2{
+		util.Max(avail.X-t.padding.Horizontal(), 0),
+		util.Max(avail.Y-t.padding.Vertical()-t.barHeight, 0),
+	}
+	var size gb.Vec2
+	for _, tab := range t.tabs {
+		tab.width = fa.MeasureString(tab.title) + 2*tabPadding
+		if tab.closeable {
+			tab.width += t.closeSize() + tabPadding/2
+		}
+		cv := tab.view.GetView()
+		d := MeasureChild(w, tab.view, content)
+		size.X = util.Max(size.X, d.X+cv.margin.Horizontal())
+		size.Y = util.Max(size.Y, d.Y+cv.margin.Vertical())
+	}
+	size.Y += t.barHeight
+	size.Add(t.padding.Size())
+	return t.ConstrainSize(size)
+}
+
+// Arrange satisfies the IView interface
+func (t *TabView) Arrange(w *window.Window, pos gb.Vec2, size gb.Vec2) {
+
+	t.View.Arrange(w, pos, size)
+	bar := t.barRect()
+	total := t.tabX(len(t.tabs))
+	t.overflow = total > bar.Size().X
+	area := t.tabsRect()
+	if t.reveal && t.current >= 0 {
+		x0 := t.tabX(t.current)
+		x1 := x0 + t.tabs[t.current].width
+		if x0 < t.scroll {
+			t.scroll = x0
+		} else if x1 > t.scroll+area.Size().X {
+			t.scroll = x1 - area.Size().X
+		}
+		t.reveal = false
+	}
+	t.setScroll(t.scroll)
+
+	content := t.ContentRect()
+	content.Min.Y = util.Min(bar.Max.Y, content.Max.Y)
+	if t.current < 0 {
+		return
+	}
+	c := t.tabs[t.current].view
+	cv := c.GetView()
+	csize := content.Size()
+	csize.Sub(cv.margin.Size())
+	ArrangeChild(w, c, gb.Vec2{content.Min.X + cv.margin.Left, content.Min.Y + cv.margin.Top}, csize)
+}
+
+// OnEvent satisfies the IView interface
+func (t *TabView) OnEvent(w *window.Window, ev *Event) bool {
+
+	switch ev.Type {
+	case EventMouseMove:
+		if t.pressTab >= 0 && !t.pressClose {
+			if !t.dragging && t.reorderable && util.Abs(ev.Pos.X-t.pressX) > tabDragThreshold {
+				t.dragging = true
+			}
+			if t.dragging {
+				t.dragTab(ev.Pos.X)
+			}
+			return true
+		}
+		t.updateHover(ev.Pos)
+		return t.barRect().Contains(ev.Pos)
+	case EventMouseLeave:
+		t.hoverTab = -1
+		t.hoverClose = false
+		t.hoverArrow = 0
+	case EventMouseDown:
+		if t.disabled || ev.Button != gb.MouseButtonLeft || !t.barRect().Contains(ev.Pos) {
+			break
+		}
+		t.updateHover(ev.Pos)
+		if t.hoverArrow != 0 {
+			t.setScroll(t.scroll + float32(t.hoverArrow)*t.tabsRect().Size().X/2)
+			return true
+		}
+		if t.hoverTab < 0 {
+			return true
+		}
+		t.pressTab = t.hoverTab
+		t.pressClose = t.hoverClose
+		t.pressX = ev.Pos.X
+		if !t.pressClose {
+			t.SetCurrent(t.pressTab)
+		}
+		return true
+	case EventMouseUp:
+		if t.pressTab < 0 || ev.Button != gb.MouseButtonLeft {
+			break
+		}
+		pressed := t.pressTab
+		closing := t.pressClose
+		t.pressTab = -1
+		t.pressClose = false
+		t.dragging = false
+		t.updateHover(ev.Pos)
+		if closing && t.hoverTab == pressed && t.hoverClose {
+			t.CloseTab(pressed)
+			t.updateHover(ev.Pos)
+		}
+		return true
+	case EventScroll:
+		if !t.overflow || !t.barRect().Contains(ev.Pos) {
+			break
+		}
+		delta := ev.Scroll.Y
+		if delta == 0 {
+			delta = ev.Scroll.X
+		}
+		t.setScroll(t.scroll - delta*t.barHeight)
+		t.updateHover(ev.Pos)
+		return true
+	case EventKeyDown:
+		if t.disabled || len(t.tabs) == 0 {
+			break
+		}
+		ctrl := ev.Mods&gb.ModControl != 0
+		dir := 0
+		switch {
+		case ctrl && ev.Key == gb.KeyTab && ev.Mods&gb.ModShift != 0, ctrl && ev.Key == gb.KeyPageUp:
+			dir = -1
+		case ctrl && (ev.Key == gb.KeyTab || ev.Key == gb.KeyPageDown):
+			dir = 1
+		case ev.Key == gb.KeyLeft && t.HasFocus(w):
+			dir = -1
+		case ev.Key == gb.KeyRight && t.HasFocus(w):
+			dir = 1
+		default:
+			return false
+		}
+		// Wraps around when switching with the Control key
+		next := t.current + dir
+		if ctrl {
+			next = (next + len(t.tabs)) % len(t.tabs)
+		}
+		t.SetCurrent(util.Clamp(next, 0, len(t.tabs)-1))
+		return true
+	}
+	return false
+}
+
+// Render satisfies the IView interface
+func (t *TabView) Render(w *window.Window) {
+
+	if !t.visible {
+		return
+	}
+	dl := t.BeginRender()
+	fa := w.Font(window.FontRegular, 0)
+	bar := t.barRect()
+	area := t.tabsRect()
+	w.AddRectFilled(dl, bar.Min, bar.Max, t.StyleColor(w, StyleColorTabBar).RGBA(), 0, 0)
+	textColor := t.StyleColor(w, StyleColorText).RGBA()
+	if t.disabled {
+		textColor = t.StyleColor(w, StyleColorTextDisabled).RGBA()
+	}
+	border := t.StyleColor(w, StyleColorBorder).RGBA()
+	rounding := t.StyleFrameRounding(w)
+
+	// Draws the tabs clipped to the tabs area
+	w.PushClipRect(t.WindowRect(area))
+	for i, tab := range t.tabs {
+		r := t.tabRect(i)
+		if r.Max.X < area.Min.X {
+			continue
+		}
+		if r.Min.X > area.Max.X {
+			break
+		}
+		bg := StyleColorTab
+		switch {
+		case i == t.current:
+			bg = StyleColorTabActive
+		case i == t.hoverTab && !t.disabled:
+			bg = StyleColorTabHovered
+		}
+		flags := window.DrawFlags_RoundCornersTopLeft | window.DrawFlags_RoundCornersTopRight
+		w.AddRectFilled(dl, r.Min, r.Max, t.StyleColor(w, bg).RGBA(), rounding, flags)
+		if i == t.current && t.HasFocus(w) {
+			w.AddRect(dl, r.Min, r.Max, t.StyleColor(w, StyleColorFocus).RGBA(), rounding, flags, 1)
+		}
+		pos := gb.Vec2{r.Min.X + tabPadding, r.Min.Y + (r.Size().Y-fa.Height())/2}
+		w.AddText(dl, fa, &pos, textColor, window.TextVAlignTop, tab.title)
+		if tab.closeable {
+			cr := t.closeRect(i)
+			if i == t.hoverTab && t.hoverClose && !t.disabled {
+				w.AddRectFilled(dl, cr.Min, cr.Max, t.StyleColor(w, StyleColorTabCloseHovered).RGBA(), rounding, 0)
+			}
+			d := cr.Size().X * 0.25
+			w.AddLine(dl, gb.Vec2{cr.Min.X + d, cr.Min.Y + d}, gb.Vec2{cr.Max.X - d, cr.Max.Y - d}, textColor, 1.5)
+			w.AddLine(dl, gb.Vec2{cr.Max.X - d, cr.Min.Y + d}, gb.Vec2{cr.Min.X + d, cr.Max.Y - d}, textColor, 1.5)
+		}
+	}
+	w.PopClipRect()
+
+	// Draws the scroll arrow buttons when the tabs overflow
+	if t.overflow {
+		aw := t.barHeight
+		maxScroll := t.tabX(len(t.tabs)) - area.Size().X
+		for _, dir := range []int{-1, 1} {
+			min := gb.Vec2{area.Max.X, bar.Min.Y}
+			arrow := arrowLeft
+			if dir > 0 {
+				min.X += aw
+				arrow = arrowRight
+			}
+			max := gb.Vec2{min.X + aw, bar.Max.Y}
+			enabled := !t.disabled && ((dir < 0 && t.scroll > 0) || (dir > 0 && t.scroll < maxScroll))
+			bg := StyleColorButton
+			switch {
+			case !enabled:
+				bg = StyleColorButtonDisabled
+			case t.hoverArrow == dir:
+				bg = StyleColorButtonHovered
+			}
+			w.AddRectFilled(dl, min, max, t.StyleColor(w, bg).RGBA(), 0, 0)
+			center := gb.Vec2{(min.X + max.X) / 2, (min.Y + max.Y) / 2}
+			drawArrow(w, dl, center, aw*0.4, arrow, textColor)
+		}
+	}
+	w.AddLine(dl, gb.Vec2{bar.Min.X, bar.Max.Y}, bar.Max, border, 1)
+	t.EndRender(w)
+	t.RenderChildren(w)
+}
+
+// updateHover updates the tab, close button and arrow button under the specified local point
+func (t *TabView) updateHover(pos gb.Vec2) {
+
+	t.hoverTab = -1
+	t.hoverClose = false
+	t.hoverArrow = 0
+	if !t.barRect().Contains(pos) {
+		return
+	}
+	area := t.tabsRect()
+	if !area.Contains(pos) {
+		if t.overflow && pos.X >= area.Max.X {
+			t.hoverArrow = -1
+			if pos.X >= area.Max.X+t.barHeight {
+				t.hoverArrow = 1
+			}
+		}
+		return
+	}
+	for i, tab := range t.tabs {
+		if t.tabRect(i).Contains(pos) {
+			t.hoverTab = i
+			t.hoverClose = tab.closeable && t.closeRect(i).Contains(pos)
+			return
+		}
+	}
+}
+
+// dragTab moves the pressed tab to the position of the tab under the specified horizontal local position
+func (t *TabView) dragTab(x float32) {
+
+	area := t.tabsRect()
+	x = util.Clamp(x, area.Min.X, area.Max.X)
+	target := t.pressTab
+	for target > 0 && x < t.tabRect(target-1).Max.X-t.tabs[target-1].width/2 {
+		target--
+	}
+	for target < len(t.tabs)-1 && x > t.tabRect(target+1).Min.X+t.tabs[target+1].width/2 {
+		target++
+	}
+	if target == t.pressTab {
+		return
+	}
+	tab := t.tabs[t.pressTab]
+	currentView := t.tabs[t.current].view
+	t.tabs = append(t.tabs[:t.pressTab], t.tabs[t.pressTab+1:]...)
+	t.tabs = append(t.tabs[:target], append([]*tabItem{tab}, t.tabs[target:]...)...)
+	t.current = t.IndexOf(currentView)
+	t.pressTab = target
+	t.hoverTab = target
+}
+
+// setScroll sets the scroll offset of the tabs clamped to the valid range
+func (t *TabView) setScroll(scroll float32) {
+
+	maxScroll := util.Max(t.tabX(len(t.tabs))-t.tabsRect().Size().X, 0)
+	t.scroll = util.Clamp(scroll, 0, maxScroll)
+}
+
+// closeSize returns the size of the tab close buttons
+func (t *TabView) closeSize() float32 {
+
+	return (t.barHeight - 2*tabVPadding) * 0.8
+}
+
+// barRect returns the tab bar rectangle in local coordinates
+func (t *TabView) barRect() gb.Rect {
+
+	content := t.ContentRect()
+	return gb.Rect{
+		Min: content.Min,
+		Max: gb.Vec2{content.Max.X, content.Min.Y + t.barHeight},
+	}
+}
+
+// tabsRect returns the rectangle of the tab bar where the tabs are shown, excluding the arrow buttons
+func (t *TabView) tabsRect() gb.Rect {
+
+	r := t.barRect()
+	if t.overflow {
+		r.Max.X = util.Max(r.Max.X-2*t.barHeight, r.Min.X)
+	}
+	return r
+}
+
+// tabX returns the horizontal position of the tab with the specified index relative to the first tab.
+// The number of tabs gives the total width.
+func (t *TabView) tabX(index int) float32 {
+
+	x := float32(0)
+	for _, tab := range t.tabs[:index] {
+		x += tab.width + tabSpacing
+	}
+	return x
+}
+
+// tabRect returns the rectangle of the tab with the specified index in local coordinates
+func (t *TabView) tabRect(index int) gb.Rect {
+
+	area := t.tabsRect()
+	x := area.Min.X + t.tabX(index) - t.scroll
+	return gb.Rect{
+		Min: gb.Vec2{x, area.Min.Y + tabVPadding/2},
+		Max: gb.Vec2{x + t.tabs[index].width, area.Max.Y},
+	}
+}
+
+// closeRect returns the rectangle of the close button of the tab with the specified index in local coordinates
+func (t *TabView) closeRect(index int) gb.Rect {
+
+	r := t.tabRect(index)
+	size := t.closeSize()
+	min := gb.Vec2{r.Max.X - tabPadding/2 - size, (r.Min.Y + r.Max.Y - size) / 2}
+	return gb.Rect{Min: min, Max: gb.Vec2{min.X + size, min.Y + size}}
+}
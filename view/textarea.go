@@ -0,0 +1,1102 @@
+package view
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// Maximum number of edits kept in the TextArea undo stack
+const textAreaMaxUndo = 1000
+
+// Number of rows scrolled by each mouse wheel step
+const textAreaScrollRows = 3
+
+// TextPos is a position in a multi-line text as a line index and a rune column in this line
+type TextPos struct {
+	Line int
+	Col  int
+}
+
+// Less returns if this position is before the other
+func (p TextPos) Less(other TextPos) bool {
+
+	return p.Line < other.Line || (p.Line == other.Line && p.Col < other.Col)
+}
+
+// textRow is a visual row of a TextArea: a full line or part of a wrapped line
+type textRow struct {
+	line  int // Line index
+	start int // Column of the first rune of the row
+	end   int // Column after the last rune of the row
+}
+
+// textLineInfo contains cached geometry of a TextArea line
+type textLineInfo struct {
+	width  float32 // Width of the full line
+	breaks []int   // Start columns of the wrapped rows after the first one
+}
+
+// textAreaEdit is an entry of the undo and redo stacks
+type textAreaEdit struct {
+	start        TextPos // Start position of the edit
+	removed      []rune  // Removed text with lines separated by '\n'
+	inserted     []rune  // Inserted text with lines separated by '\n'
+	caretBefore  TextPos // Caret position before the edit
+	anchorBefore TextPos // Selection anchor before the edit
+	caretAfter   TextPos // Caret position after the edit
+}
+
+// TextArea is a multi-line text editor view with optional soft wrapping and undo/redo.
+// Only the visible rows of the text are drawn, so large documents can be edited.
+// Tab characters are expanded to spaces.
+type TextArea struct {
+	View
+	lines       [][]rune             // Text lines without line separators
+	infos       []textLineInfo       // Cached geometry for each line
+	ff          window.FontStyleType // Text font style
+	caret       TextPos              // Caret position
+	anchor      TextPos              // Selection anchor (equal to caret if no selection)
+	desiredX    float32              // Horizontal caret position kept during vertical moves (negative if none)
+	scroll      gb.Vec2              // Current scroll offsets
+	wrap        bool                 // Soft wrap lines at the view width
+	tabSize     int                  // Number of spaces of each tab stop
+	readOnly    bool                 // Text cannot be edited by the user
+	hovered     bool                 // Cursor is over the view
+	dragging    bool                 // Selecting with the mouse
+	ensureCaret bool                 // Scroll to make the caret visible in the next render
+	blinkStart  time.Time            // Start of the caret blinking cycle
+	rows        []textRow            // Visual rows of the text
+	rowsDirty   bool                 // Rows must be rebuilt
+	rowsWidth   float32              // Wrap width used to build the rows
+	rowsFont    *window.FontAtlas    // Font used to build the rows
+	maxWidth    float32              // Width of the longest line
+	maxDirty    bool                 // Width of the longest line must be recomputed
+	offsets     []float32            // Temporary buffer for caret offsets
+	undo        []textAreaEdit       // Undo stack
+	redo        []textAreaEdit       // Redo stack
+	coalesce    bool                 // Next typed character can be merged with the last undo entry
+	onChange    func(*TextArea)      // Text change callback
+}
+
+// NewTextArea creates and returns a new TextArea with the specified initial text
+func NewTextArea(text string) *TextArea {
+
+	t := new(TextArea)
+	t.Init(t)
+	t.ff = window.FontRegular
+	t.focusable = true
+	t.padding = InsetsAll(4)
+	t.tabSize = 4
+	t.desiredX = -1
+	t.SetText(text)
+	return t
+}
+
+// SetText replaces all the text, moving the caret to the start and clearing the undo history.
+// The change callback is not called.
+func (t *TextArea) SetText(text string) {
+
+	t.lines = splitLines(t.normalize(text))
+	t.infos = make([]textLineInfo, len(t.lines))
+	t.rowsDirty = true
+	t.caret = TextPos{}
+	t.anchor = t.caret
+	t.scroll = gb.Vec2{}
+	t.undo = nil
+	t.redo = nil
+	t.coalesce = false
+}
+
+// Text returns all the text with lines separated by '\n'
+func (t *TextArea) Text() string {
+
+	var sb strings.Builder
+	for i, line := range t.lines {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(string(line))
+	}
+	return sb.String()
+}
+
+// AppendText appends the specified text at the end of the text without recording it for undo.
+// If the caret was at the end of the text it is kept at the end, following the appended text.
+// The change callback is not called.
+func (t *TextArea) AppendText(text string) {
+
+	end := t.endPos()
+	follow := t.caret == end && t.anchor == end
+	newEnd := t.replace(end, end, t.normalize(text))
+	if follow {
+		t.caret = newEnd
+		t.anchor = newEnd
+		t.ensureCaret = true
+	}
+	// Positions stored in the undo stack remain valid as the text was appended at the end
+}
+
+// LineCount returns the number of lines of the text
+func (t *TextArea) LineCount() int {
+
+	return len(t.lines)
+}
+
+// Line returns the text of the line with the specified index
+func (t *TextArea) Line(index int) string {
+
+	return string(t.lines[index])
+}
+
+// SetWrap sets if lines longer than the view width are wrapped into several rows
+func (t *TextArea) SetWrap(wrap bool) {
+
+	if wrap == t.wrap {
+		return
+	}
+	t.wrap = wrap
+	t.invalidateAll()
+}
+
+// Wrap returns if lines are wrapped
+func (t *TextArea) Wrap() bool {
+
+	return t.wrap
+}
+
+// SetTabSize sets the number of spaces of each tab stop. The default is 4.
+func (t *TextArea) SetTabSize(size int) {
+
+	t.tabSize = util.Max(size, 1)
+}
+
+// TabSize returns the number of spaces of each tab stop
+func (t *TextArea) TabSize() int {
+
+	return t.tabSize
+}
+
+// SetReadOnly sets if the text can be edited by the user.
+// Read only text can still be selected and copied.
+func (t *TextArea) SetReadOnly(readOnly bool) {
+
+	t.readOnly = readOnly
+}
+
+// ReadOnly returns if the text cannot be edited by the user
+func (t *TextArea) ReadOnly() bool {
+
+	return t.readOnly
+}
+
+// SetCaret moves the caret to the specified position, clearing the selection
+func (t *TextArea) SetCaret(pos TextPos) {
+
+	t.moveCaret(pos, false)
+}
+
+// Caret returns the current caret position
+func (t *TextArea) Caret() TextPos {
+
+	return t.caret
+}
+
+// SetSelection sets the selection anchor and caret positions
+func (t *TextArea) SetSelection(anchor, caret TextPos) {
+
+	t.anchor = t.clampPos(anchor)
+	t.moveCaret(caret, true)
+}
+
+// Selection returns the start and end positions of the selected text
+func (t *TextArea) Selection() (TextPos, TextPos) {
+
+	if t.caret.Less(t.anchor) {
+		return t.caret, t.anchor
+	}
+	return t.anchor, t.caret
+}
+
+// SelectedText returns the selected text
+func (t *TextArea) SelectedText() string {
+
+	start, end := t.Selection()
+	return string(t.textRange(start, end))
+}
+
+// SelectAll selects all the text
+func (t *TextArea) SelectAll() {
+
+	t.anchor = TextPos{}
+	t.moveCaret(t.endPos(), true)
+}
+
+// CanUndo returns if there are edits to undo
+func (t *TextArea) CanUndo() bool {
+
+	return len(t.undo) > 0
+}
+
+// CanRedo returns if there are undone edits to redo
+func (t *TextArea) CanRedo() bool {
+
+	return len(t.redo) > 0
+}
+
+// Undo reverts the last edit. Returns false if there is nothing to undo.
+func (t *TextArea) Undo() bool {
+
+	if len(t.undo) == 0 {
+		return false
+	}
+	e := t.undo[len(t.undo)-1]
+	t.undo = t.undo[:len(t.undo)-1]
+	t.replace(e.start, endOfText(e.start, e.inserted), e.removed)
+	t.redo = append(t.redo, e)
+	t.caret = e.caretBefore
+	t.anchor = e.anchorBefore
+	t.afterEdit()
+	return true
+}
+
+// Redo applies the last undone edit again. Returns false if there is nothing to redo.
+func (t *TextArea) Redo() bool {
+
+	if len(t.redo) == 0 {
+		return false
+	}
+	e := t.redo[len(t.redo)-1]
+	t.redo = t.redo[:len(t.redo)-1]
+	t.replace(e.start, endOfText(e.start, e.removed), e.inserted)
+	t.undo = append(t.undo, e)
+	t.caret = e.caretAfter
+	t.anchor = e.caretAfter
+	t.afterEdit()
+	return true
+}
+
+// Copy copies the selected text to the clipboard of the specified window
+func (t *TextArea) Copy(w *window.Window) {
+
+	start, end := t.Selection()
+	if start != end {
+		w.SetClipboard(string(t.textRange(start, end)))
+	}
+}
+
+// Cut copies the selected text to the clipboard of the specified window and deletes it
+func (t *TextArea) Cut(w *window.Window) {
+
+	start, end := t.Selection()
+	if start == end || t.readOnly {
+		return
+	}
+	t.Copy(w)
+	t.edit(start, end, nil, false)
+}
+
+// Paste replaces the selected text with the text from the clipboard of the specified window
+func (t *TextArea) Paste(w *window.Window) {
+
+	text := w.Clipboard()
+	if text == "" || t.readOnly {
+		return
+	}
+	start, end := t.Selection()
+	t.edit(start, end, t.normalize(text), false)
+}
+
+// OnChange sets the function called when the text is changed by the user
+func (t *TextArea) OnChange(cb func(t *TextArea)) {
+
+	t.onChange = cb
+}
+
+// Measure satisfies the IView interface
+func (t *TextArea) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	fa := w.Font(t.ff, 0)
+	size := gb.Vec2{fa.MeasureString("0") * textEditDefaultChars * 2, fa.Height() * 5}
+	size.Add(t.padding.Size())
+	return t.ConstrainSize(size)
+}
+
+// OnEvent satisfies the IView interface
+func (t *TextArea) OnEvent(w *window.Window, ev *Event) bool {
+
+	switch ev.Type {
+	case EventMouseEnter:
+		t.hovered = true
+		w.SetCursor(gb.CursorIBeam)
+	case EventMouseLeave:
+		t.hovered = false
+		w.SetCursor(gb.CursorDefault)
+	case EventFocusIn, EventFocusOut:
+		t.blinkStart = w.FrameTime()
+		t.dragging = false
+	case EventMouseDown:
+		if t.disabled || ev.Button != gb.MouseButtonLeft {
+			return false
+		}
+		t.moveCaret(t.posAt(w, ev.Pos), ev.Mods&gb.ModShift != 0)
+		t.dragging = true
+		t.blinkStart = w.FrameTime()
+		return true
+	case EventMouseMove:
+		if t.dragging {
+			t.moveCaret(t.posAt(w, ev.Pos), true)
+			return true
+		}
+	case EventMouseUp:
+		if t.dragging && ev.Button == gb.MouseButtonLeft {
+			t.dragging = false
+			return true
+		}
+	case EventScroll:
+		lineH := w.Font(t.ff, 0).Height()
+		delta := ev.Scroll
+		if ev.Mods&gb.ModShift != 0 {
+			delta = gb.Vec2{delta.Y, delta.X}
+		}
+		t.scroll.X -= delta.X * textAreaScrollRows * lineH
+		t.scroll.Y -= delta.Y * textAreaScrollRows * lineH
+		return true
+	case EventChar:
+		if t.disabled || t.readOnly || !unicode.IsPrint(ev.Char) {
+			return false
+		}
+		start, end := t.Selection()
+		t.edit(start, end, []rune{ev.Char}, true)
+		t.blinkStart = w.FrameTime()
+		return true
+	case EventKeyDown:
+		if t.disabled {
+			return false
+		}
+		if t.onKey(w, ev) {
+			t.blinkStart = w.FrameTime()
+			return true
+		}
+	}
+	return false
+}
+
+// onKey processes a key down event and returns if it was handled
+func (t *TextArea) onKey(w *window.Window, ev *Event) bool {
+
+	shift := ev.Mods&gb.ModShift != 0
+	ctrl := ev.Mods&gb.ModControl != 0
+	start, end := t.Selection()
+	editable := !t.readOnly
+	switch ev.Key {
+	case gb.KeyLeft:
+		if start != end && !shift {
+			t.moveCaret(start, false)
+		} else if ctrl {
+			t.moveCaret(t.prevWordPos(t.caret), shift)
+		} else {
+			t.moveCaret(t.prevPos(t.caret), shift)
+		}
+	case gb.KeyRight:
+		if start != end && !shift {
+			t.moveCaret(end, false)
+		} else if ctrl {
+			t.moveCaret(t.nextWordPos(t.caret), shift)
+		} else {
+			t.moveCaret(t.nextPos(t.caret), shift)
+		}
+	case gb.KeyUp:
+		t.moveVertical(w, -1, shift)
+	case gb.KeyDown:
+		t.moveVertical(w, 1, shift)
+	case gb.KeyPageUp:
+		t.moveVertical(w, -t.pageRows(w), shift)
+	case gb.KeyPageDown:
+		t.moveVertical(w, t.pageRows(w), shift)
+	case gb.KeyHome:
+		if ctrl {
+			t.moveCaret(TextPos{}, shift)
+		} else {
+			t.moveCaret(TextPos{t.caret.Line, 0}, shift)
+		}
+	case gb.KeyEnd:
+		if ctrl {
+			t.moveCaret(t.endPos(), shift)
+		} else {
+			t.moveCaret(TextPos{t.caret.Line, len(t.lines[t.caret.Line])}, shift)
+		}
+	case gb.KeyBackspace:
+		if !editable {
+			return false
+		}
+		if start == end {
+			if ctrl {
+				start = t.prevWordPos(t.caret)
+			} else {
+				start = t.prevPos(t.caret)
+			}
+		}
+		t.edit(start, end, nil, false)
+	case gb.KeyDelete:
+		if !editable {
+			return false
+		}
+		if start == end {
+			if ctrl {
+				end = t.nextWordPos(t.caret)
+			} else {
+				end = t.nextPos(t.caret)
+			}
+		}
+		t.edit(start, end, nil, false)
+	case gb.KeyEnter, gb.KeyKPEnter:
+		if !editable {
+			return false
+		}
+		t.edit(start, end, []rune{'\n'}, false)
+	case gb.KeyTab:
+		if !editable || ctrl {
+			return false
+		}
+		if shift {
+			t.indentLines(false)
+		} else if start.Line != end.Line {
+			t.indentLines(true)
+		} else {
+			spaces := t.tabSize - start.Col%t.tabSize
+			t.edit(start, end, []rune(strings.Repeat(" ", spaces)), false)
+		}
+	case gb.KeyA:
+		if !ctrl {
+			return false
+		}
+		t.SelectAll()
+	case gb.KeyC:
+		if !ctrl {
+			return false
+		}
+		t.Copy(w)
+	case gb.KeyX:
+		if !ctrl {
+			return false
+		}
+		t.Cut(w)
+	case gb.KeyV:
+		if !ctrl {
+			return false
+		}
+		t.Paste(w)
+	case gb.KeyZ:
+		if !ctrl || !editable {
+			return false
+		}
+		if shift {
+			t.Redo()
+		} else {
+			t.Undo()
+		}
+	case gb.KeyY:
+		if !ctrl || !editable {
+			return false
+		}
+		t.Redo()
+	default:
+		return false
+	}
+	return true
+}
+
+// Render satisfies the IView interface
+func (t *TextArea) Render(w *window.Window) {
+
+	if !t.visible {
+		return
+	}
+	dl := t.BeginRender()
+	fa := w.Font(t.ff, 0)
+	lineH := fa.Height()
+	focused := t.HasFocus(w) && !t.disabled
+
+	// Draws the frame
+	rounding := t.StyleFrameRounding(w)
+	bg := StyleColorFrame
+	if t.disabled {
+		bg = StyleColorButtonDisabled
+	}
+	w.AddRectFilled(dl, gb.Vec2{}, t.size, t.StyleColor(w, bg).RGBA(), rounding, window.DrawFlags_RoundCornersAll)
+	border := t.StyleColor(w, StyleColorBorder).RGBA()
+	thickness := float32(1)
+	if focused {
+		border = t.StyleColor(w, StyleColorFocus).RGBA()
+		thickness = 2
+	}
+	w.AddRect(dl, gb.Vec2{}, t.size, border, rounding, window.DrawFlags_RoundCornersAll, thickness)
+
+	// Updates the rows and the scroll offsets
+	content := t.ContentRect()
+	csize := content.Size()
+	t.updateRows(fa, csize.X)
+	caretRow, caretX := t.caretGeometry(fa)
+	if t.ensureCaret {
+		t.ensureCaret = false
+		top := float32(caretRow) * lineH
+		if top < t.scroll.Y {
+			t.scroll.Y = top
+		} else if top+lineH > t.scroll.Y+csize.Y {
+			t.scroll.Y = top + lineH - csize.Y
+		}
+		if caretX < t.scroll.X {
+			t.scroll.X = caretX
+		} else if caretX > t.scroll.X+csize.X-1 {
+			t.scroll.X = caretX - csize.X + 1
+		}
+	}
+	maxWidth := t.maxWidth
+	if t.wrap {
+		maxWidth = 0
+	}
+	t.scroll.X = util.Clamp(t.scroll.X, 0, util.Max(maxWidth-csize.X+1, 0))
+	t.scroll.Y = util.Clamp(t.scroll.Y, 0, util.Max(float32(len(t.rows))*lineH-csize.Y, 0))
+
+	// Clips to the content area
+	w.PushClipRect(t.WindowRect(content))
+
+	// Draws only the visible rows
+	textColor := t.StyleColor(w, StyleColorText).RGBA()
+	if t.disabled {
+		textColor = t.StyleColor(w, StyleColorTextDisabled).RGBA()
+	}
+	selColor := t.StyleColor(w, StyleColorTextSelectedBg).RGBA()
+	selStart, selEnd := t.Selection()
+	first := int(t.scroll.Y / lineH)
+	last := util.Min(int((t.scroll.Y+csize.Y)/lineH), len(t.rows)-1)
+	for ri := first; ri <= last; ri++ {
+		row := t.rows[ri]
+		line := t.lines[row.line]
+		t.offsets = fa.CaretOffsets(line, t.offsets[:0])
+		rowX := content.Min.X - t.scroll.X - t.offsets[row.start]
+		y := content.Min.Y + float32(ri)*lineH - t.scroll.Y
+
+		// Draws the part of the selection in this row
+		if selStart != selEnd && selStart.Line <= row.line && selEnd.Line >= row.line {
+			a := row.start
+			if selStart.Line == row.line {
+				a = util.Max(a, selStart.Col)
+			}
+			b := row.end
+			if selEnd.Line == row.line {
+				b = util.Min(b, selEnd.Col)
+			}
+			lastRow := ri+1 >= len(t.rows) || t.rows[ri+1].line != row.line
+			newline := selEnd.Line > row.line && lastRow
+			if a < b || (a == b && newline) {
+				x0 := rowX + t.offsets[a]
+				x1 := rowX + t.offsets[b]
+				if newline {
+					x1 += fa.MeasureString(" ")
+				}
+				w.AddRectFilled(dl, gb.Vec2{x0, y}, gb.Vec2{x1, y + lineH}, selColor, 0, 0)
+			}
+		}
+
+		// Draws the row text
+		if row.end > row.start {
+			pos := gb.Vec2{rowX + t.offsets[row.start], y}
+			w.AddText(dl, fa, &pos, textColor, window.TextVAlignTop, string(line[row.start:row.end]))
+		}
+	}
+
+	// Draws the blinking caret, requesting a frame at the next blink transition
+	if focused {
+		elapsed := float32(w.FrameTime().Sub(t.blinkStart).Seconds())
+		phase := int(elapsed / caretBlinkPeriod)
+		if phase%2 == 0 && caretRow >= first && caretRow <= last {
+			x := content.Min.X - t.scroll.X + caretX
+			y := content.Min.Y + float32(caretRow)*lineH - t.scroll.Y
+			w.AddLine(dl, gb.Vec2{x, y}, gb.Vec2{x, y + lineH}, t.StyleColor(w, StyleColorText).RGBA(), 1)
+		}
+		w.RequestFrame(float32(phase+1)*caretBlinkPeriod - elapsed)
+	}
+	w.PopClipRect()
+	t.EndRender(w)
+}
+
+// edit replaces the text between the specified positions with the specified text,
+// recording the change in the undo stack. Consecutive typed characters are merged in a single undo entry.
+func (t *TextArea) edit(start, end TextPos, text []rune, typing bool) {
+
+	if t.readOnly || (start == end && len(text) == 0) {
+		return
+	}
+	removed := t.textRange(start, end)
+	caretBefore, anchorBefore := t.caret, t.anchor
+	newEnd := t.replace(start, end, text)
+
+	merged := false
+	if typing && t.coalesce && len(t.undo) > 0 && len(removed) == 0 {
+		last := &t.undo[len(t.undo)-1]
+		if last.caretAfter == start {
+			last.inserted = append(last.inserted, text...)
+			last.caretAfter = newEnd
+			merged = true
+		}
+	}
+	if !merged {
+		t.undo = append(t.undo, textAreaEdit{
+			start:        start,
+			removed:      removed,
+			inserted:     append([]rune(nil), text...),
+			caretBefore:  caretBefore,
+			anchorBefore: anchorBefore,
+			caretAfter:   newEnd,
+		})
+		if len(t.undo) > textAreaMaxUndo {
+			t.undo = append(t.undo[:0], t.undo[1:]...)
+		}
+	}
+	t.redo = t.redo[:0]
+	t.caret = newEnd
+	t.anchor = newEnd
+	t.afterEdit()
+	t.coalesce = typing
+}
+
+// afterEdit updates the state after the text was changed by the user
+func (t *TextArea) afterEdit() {
+
+	t.coalesce = false
+	t.desiredX = -1
+	t.ensureCaret = true
+	if t.onChange != nil {
+		t.onChange(t)
+	}
+}
+
+// replace replaces the text between the specified ordered positions with the
+// specified text, which can contain '\n' line separators, and returns the position after the inserted text.
+func (t *TextArea) replace(start, end TextPos, text []rune) TextPos {
+
+	head := t.lines[start.Line][:start.Col]
+	tail := t.lines[end.Line][end.Col:]
+	parts := splitLines(text)
+	newLines := make([][]rune, len(parts))
+	for i, part := range parts {
+		var line []rune
+		if i == 0 {
+			line = append(line, head...)
+		}
+		line = append(line, part...)
+		if i == len(parts)-1 {
+			line = append(line, tail...)
+		}
+		newLines[i] = line
+	}
+	endPos := TextPos{start.Line + len(parts) - 1, len(parts[len(parts)-1])}
+	if len(parts) == 1 {
+		endPos.Col += len(head)
+	}
+
+	// Replaces the lines and their geometry information in place.
+	// If the rows are up to date only the rows of the replaced lines are rebuilt.
+	count := end.Line - start.Line + 1
+	update := !t.rowsDirty && t.rowsFont != nil
+	first, last := t.firstRow(start.Line), t.firstRow(end.Line+1)
+	lost := false
+	for _, info := range t.infos[start.Line : end.Line+1] {
+		lost = lost || info.width >= t.maxWidth
+	}
+	t.lines = splice(t.lines, start.Line, count, newLines)
+	t.infos = splice(t.infos, start.Line, count, make([]textLineInfo, len(newLines)))
+	if !update {
+		t.rowsDirty = true
+		return endPos
+	}
+	var rows []textRow
+	newMax := float32(0)
+	for li := start.Line; li < start.Line+len(newLines); li++ {
+		t.measureLine(t.rowsFont, li, t.rowsWidth)
+		newMax = util.Max(newMax, t.infos[li].width)
+		rows = t.appendLineRows(rows, li)
+	}
+	t.rows = splice(t.rows, first, last-first, rows)
+	if delta := len(newLines) - count; delta != 0 {
+		for i := first + len(rows); i < len(t.rows); i++ {
+			t.rows[i].line += delta
+		}
+	}
+	if lost && newMax < t.maxWidth {
+		t.maxDirty = true
+	} else {
+		t.maxWidth = util.Max(t.maxWidth, newMax)
+	}
+	return endPos
+}
+
+// textRange returns the text between the specified ordered positions with lines separated by '\n'
+func (t *TextArea) textRange(start, end TextPos) []rune {
+
+	var text []rune
+	for li := start.Line; li <= end.Line; li++ {
+		line := t.lines[li]
+		c0, c1 := 0, len(line)
+		if li == start.Line {
+			c0 = start.Col
+		}
+		if li == end.Line {
+			c1 = end.Col
+		}
+		text = append(text, line[c0:c1]...)
+		if li < end.Line {
+			text = append(text, '\n')
+		}
+	}
+	return text
+}
+
+// indentLines indents or outdents all the lines of the selection by one tab stop as a single edit
+func (t *TextArea) indentLines(indent bool) {
+
+	start, end := t.Selection()
+	lastLine := end.Line
+	if end.Col == 0 && end.Line > start.Line {
+		lastLine--
+	}
+	var text []rune
+	for li := start.Line; li <= lastLine; li++ {
+		line := t.lines[li]
+		if indent {
+			text = append(text, []rune(strings.Repeat(" ", t.tabSize))...)
+		} else {
+			n := 0
+			for n < len(line) && n < t.tabSize && line[n] == ' ' {
+				n++
+			}
+			line = line[n:]
+		}
+		text = append(text, line...)
+		if li < lastLine {
+			text = append(text, '\n')
+		}
+	}
+	blockStart := TextPos{start.Line, 0}
+	blockEnd := TextPos{lastLine, len(t.lines[lastLine])}
+	if string(text) == string(t.textRange(blockStart, blockEnd)) {
+		return
+	}
+	t.edit(blockStart, blockEnd, text, false)
+	t.anchor = blockStart
+	t.caret = TextPos{lastLine, len(t.lines[lastLine])}
+}
+
+// normalize converts text for insertion removing carriage returns and expanding tabs
+func (t *TextArea) normalize(text string) []rune {
+
+	var runes []rune
+	col := 0
+	for _, r := range text {
+		switch r {
+		case '\r':
+			continue
+		case '\n':
+			col = -1
+		case '\t':
+			spaces := t.tabSize - col%t.tabSize
+			for i := 0; i < spaces; i++ {
+				runes = append(runes, ' ')
+			}
+			col += spaces
+			continue
+		}
+		runes = append(runes, r)
+		col++
+	}
+	return runes
+}
+
+// moveCaret moves the caret to the specified position, extending the selection if requested
+func (t *TextArea) moveCaret(pos TextPos, extend bool) {
+
+	t.caret = t.clampPos(pos)
+	if !extend {
+		t.anchor = t.caret
+	}
+	t.coalesce = false
+	t.desiredX = -1
+	t.ensureCaret = true
+}
+
+// moveVertical moves the caret by the specified number of rows keeping its horizontal position
+func (t *TextArea) moveVertical(w *window.Window, delta int, extend bool) {
+
+	fa := w.Font(t.ff, 0)
+	t.updateRows(fa, t.rowsWidth)
+	row, x := t.caretGeometry(fa)
+	if t.desiredX >= 0 {
+		x = t.desiredX
+	}
+	target := util.Clamp(row+delta, 0, len(t.rows)-1)
+	pos := TextPos{t.rows[target].line, t.colAtX(fa, target, x)}
+	if row+delta < 0 {
+		pos = TextPos{}
+	} else if row+delta >= len(t.rows) {
+		pos = t.endPos()
+	}
+	t.moveCaret(pos, extend)
+	t.desiredX = x
+}
+
+// pageRows returns the number of rows visible in the view
+func (t *TextArea) pageRows(w *window.Window) int {
+
+	return util.Max(int(t.ContentRect().Size().Y/w.Font(t.ff, 0).Height()), 1)
+}
+
+// posAt returns the text position nearest to the specified point in local coordinates
+func (t *TextArea) posAt(w *window.Window, p gb.Vec2) TextPos {
+
+	fa := w.Font(t.ff, 0)
+	t.updateRows(fa, t.rowsWidth)
+	content := t.ContentRect()
+	ri := int((p.Y - content.Min.Y + t.scroll.Y) / fa.Height())
+	if ri < 0 {
+		return TextPos{}
+	}
+	if ri >= len(t.rows) {
+		return t.endPos()
+	}
+	return TextPos{t.rows[ri].line, t.colAtX(fa, ri, p.X-content.Min.X+t.scroll.X)}
+}
+
+// colAtX returns the column of the caret position of the specified row
+// nearest to the specified horizontal position relative to the text origin.
+func (t *TextArea) colAtX(fa *window.FontAtlas, ri int, x float32) int {
+
+	row := t.rows[ri]
+	t.offsets = fa.CaretOffsets(t.lines[row.line], t.offsets[:0])
+	end := row.end
+	// The end of a wrapped row, except the last, is shown at the start of the next row
+	if ri+1 < len(t.rows) && t.rows[ri+1].line == row.line && end > row.start {
+		end--
+	}
+	x += t.offsets[row.start]
+	for c := row.start; c < end; c++ {
+		if x < (t.offsets[c]+t.offsets[c+1])/2 {
+			return c
+		}
+	}
+	return end
+}
+
+// caretGeometry returns the row index of the caret and its horizontal position relative to the text origin
+func (t *TextArea) caretGeometry(fa *window.FontAtlas) (int, float32) {
+
+	ri := t.rowOf(t.caret)
+	if ri < 0 {
+		return 0, 0
+	}
+	row := t.rows[ri]
+	t.offsets = fa.CaretOffsets(t.lines[row.line], t.offsets[:0])
+	return ri, t.offsets[t.caret.Col] - t.offsets[row.start]
+}
+
+// rowOf returns the index of the row which contains the specified position
+func (t *TextArea) rowOf(pos TextPos) int {
+
+	i := sort.Search(len(t.rows), func(i int) bool {
+		r := t.rows[i]
+		return r.line > pos.Line || (r.line == pos.Line && r.start > pos.Col)
+	})
+	return i - 1
+}
+
+// updateRows rebuilds the rows and the geometry of all lines if necessary
+func (t *TextArea) updateRows(fa *window.FontAtlas, width float32) {
+
+	if fa != t.rowsFont || (t.wrap && width != t.rowsWidth) {
+		t.rowsDirty = true
+	}
+	t.rowsFont = fa
+	t.rowsWidth = width
+	if t.rowsDirty {
+		t.rows = t.rows[:0]
+		for i := range t.lines {
+			t.measureLine(fa, i, width)
+			t.rows = t.appendLineRows(t.rows, i)
+		}
+		t.rowsDirty = false
+		t.maxDirty = true
+	}
+	if t.maxDirty {
+		t.maxWidth = 0
+		for i := range t.infos {
+			t.maxWidth = util.Max(t.maxWidth, t.infos[i].width)
+		}
+		t.maxDirty = false
+	}
+}
+
+// appendLineRows appends the rows of the specified line to the specified rows and returns the result
+func (t *TextArea) appendLineRows(rows []textRow, li int) []textRow {
+
+	start := 0
+	for _, b := range t.infos[li].breaks {
+		rows = append(rows, textRow{line: li, start: start, end: b})
+		start = b
+	}
+	return append(rows, textRow{line: li, start: start, end: len(t.lines[li])})
+}
+
+// firstRow returns the index of the first row of the specified line or the number of rows
+// if the line is after the last one
+func (t *TextArea) firstRow(li int) int {
+
+	return sort.Search(len(t.rows), func(i int) bool { return t.rows[i].line >= li })
+}
+
+// measureLine updates the geometry of the specified line, wrapping it at the specified width if enabled.
+// Lines are preferably broken after spaces.
+func (t *TextArea) measureLine(fa *window.FontAtlas, li int, width float32) {
+
+	line := t.lines[li]
+	info := &t.infos[li]
+	t.offsets = fa.CaretOffsets(line, t.offsets[:0])
+	info.width = t.offsets[len(line)]
+	info.breaks = info.breaks[:0]
+	if !t.wrap || width <= 0 {
+		return
+	}
+	start := 0
+	for t.offsets[len(line)]-t.offsets[start] > width {
+		end := start + 1
+		for end < len(line) && t.offsets[end+1]-t.offsets[start] <= width {
+			end++
+		}
+		for k := end; k > start+1; k-- {
+			if line[k-1] == ' ' {
+				end = k
+				break
+			}
+		}
+		info.breaks = append(info.breaks, end)
+		start = end
+	}
+}
+
+// invalidateAll invalidates the geometry of all lines, which is recomputed in the next render
+func (t *TextArea) invalidateAll() {
+
+	t.rowsDirty = true
+}
+
+// clampPos returns the specified position clamped to the text
+func (t *TextArea) clampPos(pos TextPos) TextPos {
+
+	pos.Line = util.Clamp(pos.Line, 0, len(t.lines)-1)
+	pos.Col = util.Clamp(pos.Col, 0, len(t.lines[pos.Line]))
+	return pos
+}
+
+// endPos returns the position at the end of the text
+func (t *TextArea) endPos() TextPos {
+
+	last := len(t.lines) - 1
+	return TextPos{last, len(t.lines[last])}
+}
+
+// prevPos returns the caret position before the specified one
+func (t *TextArea) prevPos(pos TextPos) TextPos {
+
+	if pos.Col > 0 {
+		return TextPos{pos.Line, pos.Col - 1}
+	}
+	if pos.Line > 0 {
+		return TextPos{pos.Line - 1, len(t.lines[pos.Line-1])}
+	}
+	return pos
+}
+
+// nextPos returns the caret position after the specified one
+func (t *TextArea) nextPos(pos TextPos) TextPos {
+
+	if pos.Col < len(t.lines[pos.Line]) {
+		return TextPos{pos.Line, pos.Col + 1}
+	}
+	if pos.Line < len(t.lines)-1 {
+		return TextPos{pos.Line + 1, 0}
+	}
+	return pos
+}
+
+// prevWordPos returns the position of the start of the word before the specified position
+func (t *TextArea) prevWordPos(pos TextPos) TextPos {
+
+	if pos.Col == 0 {
+		return t.prevPos(pos)
+	}
+	return TextPos{pos.Line, prevWordIndex(t.lines[pos.Line], pos.Col)}
+}
+
+// nextWordPos returns the position of the end of the word after the specified position
+func (t *TextArea) nextWordPos(pos TextPos) TextPos {
+
+	if pos.Col == len(t.lines[pos.Line]) {
+		return t.nextPos(pos)
+	}
+	return TextPos{pos.Line, nextWordIndex(t.lines[pos.Line], pos.Col)}
+}
+
+// splitLines splits the text at '\n' separators. The result has at least one line.
+func splitLines(text []rune) [][]rune {
+
+	lines := [][]rune{nil}
+	for _, r := range text {
+		if r == '\n' {
+			lines = append(lines, nil)
+			continue
+		}
+		lines[len(lines)-1] = append(lines[len(lines)-1], r)
+	}
+	return lines
+}
+
+// splice replaces the specified number of elements of the slice at the specified index with the
+// specified items in place, growing the slice if necessary, and returns the result
+func splice[T any](s []T, index, count int, items []T) []T {
+
+	n := len(s)
+	delta := len(items) - count
+	if delta > 0 {
+		s = append(s, make([]T, delta)...)
+	}
+	copy(s[index+len(items):], s[index+count:n])
+	copy(s[index:], items)
+	if delta < 0 {
+		var zero T
+		for i := n + delta; i < n; i++ {
+			s[i] = zero
+		}
+		s = s[:n+delta]
+	}
+	return s
+}
+
+// endOfText returns the position after the specified text inserted at the specified position
+func endOfText(start TextPos, text []rune) TextPos {
+
+	end := start
+	for _, r := range text {
+		if r == '\n' {
+			end.Line++
+			end.Col = 0
+		} else {
+			end.Col++
+		}
+	}
+	return end
+}
@@ -0,0 +1,158 @@
+package view
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitLines(t *testing.T) {
+
+	cases := []struct {
+		text string
+		want []string
+	}{
+		{"", []string{""}},
+		{"abc", []string{"abc"}},
+		{"a\nbc", []string{"a", "bc"}},
+		{"a\n", []string{"a", ""}},
+		{"\n\n", []string{"", "", ""}},
+		{"é\nüx", []string{"é", "üx"}},
+	}
+	for _, c := range cases {
+		var got []string
+		for _, line := range splitLines([]rune(c.text)) {
+			got = append(got, string(line))
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("splitLines(%q): got %q, want %q", c.text, got, c.want)
+		}
+	}
+}
+
+func TestEndOfText(t *testing.T) {
+
+	cases := []struct {
+		start TextPos
+		text  string
+		want  TextPos
+	}{
+		{TextPos{0, 0}, "", TextPos{0, 0}},
+		{TextPos{2, 3}, "ab", TextPos{2, 5}},
+		{TextPos{2, 3}, "ab\n", TextPos{3, 0}},
+		{TextPos{2, 3}, "a\nbcé", TextPos{3, 3}},
+		{TextPos{0, 1}, "\n\nx", TextPos{2, 1}},
+	}
+	for _, c := range cases {
+		if got := endOfText(c.start, []rune(c.text)); got != c.want {
+			t.Errorf("endOfText(%v, %q): got %v, want %v", c.start, c.text, got, c.want)
+		}
+	}
+}
+
+func TestSplice(t *testing.T) {
+
+	cases := []struct {
+		s            []int
+		index, count int
+		items        []int
+		want         []int
+	}{
+		{[]int{1, 2, 3}, 1, 1, []int{9}, []int{1, 9, 3}},
+		{[]int{1, 2, 3}, 1, 0, []int{8, 9}, []int{1, 8, 9, 2, 3}},
+		{[]int{1, 2, 3}, 0, 2, nil, []int{3}},
+		{[]int{1, 2, 3}, 3, 0, []int{4}, []int{1, 2, 3, 4}},
+		{[]int{1, 2, 3, 4}, 1, 3, []int{7, 8}, []int{1, 7, 8}},
+		{[]int{1, 2}, 0, 2, []int{5, 6, 7, 8}, []int{5, 6, 7, 8}},
+	}
+	for _, c := range cases {
+		s := append(make([]int, 0, len(c.s)), c.s...)
+		if got := splice(s, c.index, c.count, c.items); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("splice(%v, %d, %d, %v): got %v, want %v", c.s, c.index, c.count, c.items, got, c.want)
+		}
+	}
+}
+
+func TestTextAreaEdit(t *testing.T) {
+
+	cases := []struct {
+		text       string
+		start, end TextPos
+		insert     string
+		want       string
+		caret      TextPos
+	}{
+		{"abc", TextPos{0, 1}, TextPos{0, 1}, "x", "axbc", TextPos{0, 2}},
+		{"abc", TextPos{0, 1}, TextPos{0, 2}, "", "ac", TextPos{0, 1}},
+		{"abc", TextPos{0, 1}, TextPos{0, 1}, "1\n2", "a1\n2bc", TextPos{1, 1}},
+		{"ab\ncd\nef", TextPos{0, 1}, TextPos{2, 1}, "", "af", TextPos{0, 1}},
+		{"ab\ncd\nef", TextPos{1, 0}, TextPos{1, 2}, "x\ny\nz", "ab\nx\ny\nz\nef", TextPos{3, 1}},
+		{"ab", TextPos{0, 2}, TextPos{0, 2}, "\n", "ab\n", TextPos{1, 0}},
+	}
+	for _, c := range cases {
+		ta := NewTextArea(c.text)
+		ta.edit(c.start, c.end, []rune(c.insert), false)
+		if got := ta.Text(); got != c.want {
+			t.Errorf("edit %q at %v-%v with %q: got %q, want %q", c.text, c.start, c.end, c.insert, got, c.want)
+		}
+		if ta.Caret() != c.caret {
+			t.Errorf("edit %q at %v-%v with %q: caret %v, want %v", c.text, c.start, c.end, c.insert, ta.Caret(), c.caret)
+		}
+		if !ta.Undo() || ta.Text() != c.text {
+			t.Errorf("undo of edit %q with %q: got %q", c.text, c.insert, ta.Text())
+		}
+		if !ta.Redo() || ta.Text() != c.want {
+			t.Errorf("redo of edit %q with %q: got %q, want %q", c.text, c.insert, ta.Text(), c.want)
+		}
+	}
+}
+
+func TestTextAreaUndoCoalescing(t *testing.T) {
+
+	ta := NewTextArea("")
+	for _, r := range "abc" {
+		ta.edit(ta.Caret(), ta.Caret(), []rune{r}, true)
+	}
+	if len(ta.undo) != 1 {
+		t.Fatalf("typed characters: got %d undo entries, want 1", len(ta.undo))
+	}
+
+	// A non typing edit and a caret move end the merging
+	ta.edit(ta.Caret(), ta.Caret(), []rune("\n"), false)
+	ta.edit(ta.Caret(), ta.Caret(), []rune{'d'}, true)
+	ta.SetCaret(TextPos{0, 0})
+	ta.edit(ta.Caret(), ta.Caret(), []rune{'e'}, true)
+	if ta.Text() != "eabc\nd" || len(ta.undo) != 4 {
+		t.Fatalf("got %q with %d undo entries, want \"eabc\\nd\" with 4", ta.Text(), len(ta.undo))
+	}
+	steps := []string{"abc\nd", "abc\n", "abc", ""}
+	for _, want := range steps {
+		if !ta.Undo() || ta.Text() != want {
+			t.Errorf("undo: got %q, want %q", ta.Text(), want)
+		}
+	}
+	if ta.Undo() || !ta.CanRedo() {
+		t.Errorf("undo stack must be empty and redo stack not")
+	}
+
+	// A new edit clears the redo stack
+	ta.Redo()
+	ta.edit(ta.Caret(), ta.Caret(), []rune{'x'}, false)
+	if ta.CanRedo() || ta.Text() != "abcx" {
+		t.Errorf("after new edit: got %q, redo %v", ta.Text(), ta.CanRedo())
+	}
+}
+
+func TestTextAreaAppendText(t *testing.T) {
+
+	ta := NewTextArea("log")
+	ta.SetCaret(ta.endPos())
+	for _, line := range []string{"\none", "\ntwo", "\nthree"} {
+		ta.AppendText(line)
+	}
+	if ta.Text() != "log\none\ntwo\nthree" || ta.LineCount() != 4 {
+		t.Errorf("got %q with %d lines", ta.Text(), ta.LineCount())
+	}
+	if ta.Caret() != (TextPos{3, 5}) || ta.CanUndo() {
+		t.Errorf("caret %v, undo %v: the caret must follow the text and appends must not be undoable", ta.Caret(), ta.CanUndo())
+	}
+}
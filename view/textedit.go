@@ -0,0 +1,546 @@
+package view
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// Caret blinking period in seconds
+const caretBlinkPeriod = 0.5
+
+// Number of average characters used for the desired width of a TextEdit
+const textEditDefaultChars = 20
+
+// TextEdit is a single line text input view.
+// The caret and selection are rune indices in the text.
+type TextEdit struct {
+	View
+	text        []rune               // Current text
+	ff          window.FontStyleType // Text font style
+	caret       int                  // Caret rune index
+	anchor      int                  // Selection anchor rune index (equal to caret if no selection)
+	scrollX     float32              // Horizontal scroll of the text
+	placeholder string               // Text shown when empty
+	password    bool                 // Masks the text
+	mask        rune                 // Rune used to mask the text
+	maxLength   int                  // Maximum number of runes (0 is unlimited)
+	hovered     bool                 // Cursor is over the view
+	dragging    bool                 // Selecting with the mouse
+	blinkStart  time.Time            // Start of the caret blinking cycle
+	offsets     []float32            // Caret offsets of the displayed text (nil if not computed)
+	offsetFont  *window.FontAtlas    // Font of the caret offsets
+	offsetScale float32              // Scale of the font of the caret offsets
+	onChange    func(*TextEdit)      // Text change callback
+	onSubmit    func(*TextEdit)      // Enter key callback
+}
+
+// NewTextEdit creates and returns a new TextEdit with the specified initial text
+func NewTextEdit(text string) *TextEdit {
+
+	t := new(TextEdit)
+	t.initTextEdit(t, text)
+	return t
+}
+
+// initTextEdit initializes the TextEdit base of the specified IView
+func (t *TextEdit) initTextEdit(iv IView, text string) {
+
+	t.Init(iv)
+	t.ff = window.FontRegular
+	t.focusable = true
+	t.padding = InsetsXY(6, 4)
+	t.mask = '•'
+	t.SetText(text)
+}
+
+// SetText sets the text, truncated to the maximum length, and moves the caret to its end.
+// The change callback is not called.
+func (t *TextEdit) SetText(text string) {
+
+	t.text = []rune(text)
+	if t.maxLength > 0 && len(t.text) > t.maxLength {
+		t.text = t.text[:t.maxLength]
+	}
+	t.offsets = nil
+	t.caret = len(t.text)
+	t.anchor = t.caret
+}
+
+// Text returns the current text
+func (t *TextEdit) Text() string {
+
+	return string(t.text)
+}
+
+// SetPlaceholder sets the text shown with the disabled text color when the text is empty
+func (t *TextEdit) SetPlaceholder(placeholder string) {
+
+	t.placeholder = placeholder
+}
+
+// Placeholder returns the current placeholder text
+func (t *TextEdit) Placeholder() string {
+
+	return t.placeholder
+}
+
+// SetPassword sets if the text is masked. Masked text cannot be navigated by words.
+func (t *TextEdit) SetPassword(password bool) {
+
+	t.password = password
+	t.offsets = nil
+}
+
+// Password returns if the text is masked
+func (t *TextEdit) Password() bool {
+
+	return t.password
+}
+
+// SetPasswordMask sets the rune used to mask the text. The default is '•'.
+func (t *TextEdit) SetPasswordMask(mask rune) {
+
+	t.mask = mask
+	t.offsets = nil
+}
+
+// SetMaxLength sets the maximum number of runes of the text, truncating the current text if necessary.
+// Zero is unlimited.
+func (t *TextEdit) SetMaxLength(maxLength int) {
+
+	t.maxLength = util.Max(maxLength, 0)
+	if t.maxLength > 0 && len(t.text) > t.maxLength {
+		t.text = t.text[:t.maxLength]
+		t.offsets = nil
+		t.caret = util.Min(t.caret, len(t.text))
+		t.anchor = util.Min(t.anchor, len(t.text))
+	}
+}
+
+// MaxLength returns the maximum number of runes of the text
+func (t *TextEdit) MaxLength() int {
+
+	return t.maxLength
+}
+
+// SetSelection sets the selection anchor and caret rune indices
+func (t *TextEdit) SetSelection(anchor, caret int) {
+
+	t.anchor = util.Clamp(anchor, 0, len(t.text))
+	t.caret = util.Clamp(caret, 0, len(t.text))
+}
+
+// Selection returns the start and end rune indices of the selected text
+func (t *TextEdit) Selection() (int, int) {
+
+	return util.Min(t.anchor, t.caret), util.Max(t.anchor, t.caret)
+}
+
+// SelectedText returns the selected text
+func (t *TextEdit) SelectedText() string {
+
+	start, end := t.Selection()
+	return string(t.text[start:end])
+}
+
+// SelectAll selects all the text
+func (t *TextEdit) SelectAll() {
+
+	t.anchor = 0
+	t.caret = len(t.text)
+}
+
+// OnChange sets the function called when the text is changed by the user
+func (t *TextEdit) OnChange(cb func(t *TextEdit)) {
+
+	t.onChange = cb
+}
+
+// OnSubmit sets the function called when the Enter key is pressed
+func (t *TextEdit) OnSubmit(cb func(t *TextEdit)) {
+
+	t.onSubmit = cb
+}
+
+// Measure satisfies the IView interface
+func (t *TextEdit) Measure(w *window.Window, avail gb.Vec2) gb.Vec2 {
+
+	fa := w.Font(t.ff, 0)
+	size := gb.Vec2{fa.MeasureString("0") * textEditDefaultChars, fa.Height()}
+	size.Add(t.padding.Size())
+	return t.ConstrainSize(size)
+}
+
+// OnEvent satisfies the IView interface
+func (t *TextEdit) OnEvent(w *window.Window, ev *Event) bool {
+
+	switch ev.Type {
+	case EventMouseEnter:
+		t.hovered = true
+		w.SetCursor(gb.CursorIBeam)
+	case EventMouseLeave:
+		t.hovered = false
+		w.SetCursor(gb.CursorDefault)
+	case EventFocusIn, EventFocusOut:
+		t.resetBlink(w)
+		t.dragging = false
+	case EventMouseDown:
+		if t.disabled || ev.Button != gb.MouseButtonLeft {
+			return false
+		}
+		t.caret = t.indexAt(w, ev.Pos.X)
+		if ev.Mods&gb.ModShift == 0 {
+			t.anchor = t.caret
+		}
+		t.dragging = true
+		t.resetBlink(w)
+		return true
+	case EventMouseMove:
+		if t.dragging {
+			t.caret = t.indexAt(w, ev.Pos.X)
+			return true
+		}
+	case EventMouseUp:
+		if t.dragging && ev.Button == gb.MouseButtonLeft {
+			t.dragging = false
+			return true
+		}
+	case EventChar:
+		if t.disabled {
+			return false
+		}
+		t.insert([]rune{ev.Char})
+		t.resetBlink(w)
+		return true
+	case EventKeyDown:
+		if t.disabled {
+			return false
+		}
+		if t.onKey(w, ev) {
+			t.resetBlink(w)
+			return true
+		}
+	}
+	return false
+}
+
+// onKey processes a key down event and returns if it was handled
+func (t *TextEdit) onKey(w *window.Window, ev *Event) bool {
+
+	shift := ev.Mods&gb.ModShift != 0
+	ctrl := ev.Mods&gb.ModControl != 0
+	start, end := t.Selection()
+	switch ev.Key {
+	case gb.KeyLeft:
+		if start != end && !shift {
+			t.moveCaret(start, false)
+		} else if ctrl {
+			t.moveCaret(t.prevWord(t.caret), shift)
+		} else {
+			t.moveCaret(t.caret-1, shift)
+		}
+	case gb.KeyRight:
+		if start != end && !shift {
+			t.moveCaret(end, false)
+		} else if ctrl {
+			t.moveCaret(t.nextWord(t.caret), shift)
+		} else {
+			t.moveCaret(t.caret+1, shift)
+		}
+	case gb.KeyHome:
+		t.moveCaret(0, shift)
+	case gb.KeyEnd:
+		t.moveCaret(len(t.text), shift)
+	case gb.KeyBackspace:
+		if start == end {
+			if ctrl {
+				start = t.prevWord(t.caret)
+			} else {
+				start = util.Max(t.caret-1, 0)
+			}
+		}
+		t.delete(start, end)
+	case gb.KeyDelete:
+		if start == end {
+			if ctrl {
+				end = t.nextWord(t.caret)
+			} else {
+				end = util.Min(t.caret+1, len(t.text))
+			}
+		}
+		t.delete(start, end)
+	case gb.KeyA:
+		if !ctrl {
+			return false
+		}
+		t.SelectAll()
+	case gb.KeyC, gb.KeyX:
+		// Password text is never copied to the clipboard
+		start, end := t.Selection()
+		if !ctrl || t.password || start == end {
+			return false
+		}
+		w.SetClipboard(string(t.text[start:end]))
+		if ev.Key == gb.KeyX {
+			t.delete(start, end)
+		}
+	case gb.KeyV:
+		if !ctrl {
+			return false
+		}
+		t.insert([]rune(w.Clipboard()))
+	case gb.KeyEnter, gb.KeyKPEnter:
+		if t.onSubmit != nil {
+			t.onSubmit(t)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// Render satisfies the IView interface
+func (t *TextEdit) Render(w *window.Window) {
+
+	if !t.visible {
+		return
+	}
+	dl := t.BeginRender()
+	fa := w.Font(t.ff, 0)
+	focused := t.HasFocus(w) && !t.disabled
+
+	// Draws the frame
+	rounding := t.StyleFrameRounding(w)
+	bg := StyleColorFrame
+	if t.disabled {
+		bg = StyleColorButtonDisabled
+	} else if t.hovered && !focused {
+		bg = StyleColorFrameHovered
+	}
+	w.AddRectFilled(dl, gb.Vec2{}, t.size, t.StyleColor(w, bg).RGBA(), rounding, window.DrawFlags_RoundCornersAll)
+	border := t.StyleColor(w, StyleColorBorder).RGBA()
+	thickness := float32(1)
+	if focused {
+		border = t.StyleColor(w, StyleColorFocus).RGBA()
+		thickness = 2
+	}
+	w.AddRect(dl, gb.Vec2{}, t.size, border, rounding, window.DrawFlags_RoundCornersAll, thickness)
+
+	// Scrolls the text horizontally to keep the caret visible
+	content := t.ContentRect()
+	width := content.Size().X
+	display := t.displayText()
+	offsets := t.caretOffsets(fa)
+	caretX := offsets[t.caret]
+	if caretX-t.scrollX > width {
+		t.scrollX = caretX - width
+	} else if caretX < t.scrollX {
+		t.scrollX = caretX
+	}
+	t.scrollX = util.Clamp(t.scrollX, 0, util.Max(offsets[len(offsets)-1]-width+1, 0))
+
+	// Clips the text to the content area
+	w.PushClipRect(t.WindowRect(content))
+	origin := gb.Vec2{content.Min.X - t.scrollX, content.Min.Y + (content.Size().Y-fa.Height())/2}
+
+	// Draws the selection background
+	start, end := t.Selection()
+	if start != end && focused {
+		x0 := offsets[start]
+		x1 := offsets[end]
+		w.AddRectFilled(dl, gb.Vec2{origin.X + x0, origin.Y}, gb.Vec2{origin.X + x1, origin.Y + fa.Height()},
+			t.StyleColor(w, StyleColorTextSelectedBg).RGBA(), 0, 0)
+	}
+
+	// Draws the text or the placeholder
+	pos := origin
+	if len(t.text) == 0 {
+		if t.placeholder != "" {
+			w.AddText(dl, fa, &pos, t.StyleColor(w, StyleColorTextDisabled).RGBA(), window.TextVAlignTop, t.placeholder)
+		}
+	} else {
+		textColor := StyleColorText
+		if t.disabled {
+			textColor = StyleColorTextDisabled
+		}
+		w.AddText(dl, fa, &pos, t.StyleColor(w, textColor).RGBA(), window.TextVAlignTop, string(display))
+	}
+
+	// Draws the blinking caret, requesting a frame at the next blink transition
+	if focused {
+		elapsed := float32(w.FrameTime().Sub(t.blinkStart).Seconds())
+		phase := int(elapsed / caretBlinkPeriod)
+		if phase%2 == 0 {
+			x := origin.X + caretX
+			w.AddLine(dl, gb.Vec2{x, origin.Y}, gb.Vec2{x, origin.Y + fa.Height()}, t.StyleColor(w, StyleColorText).RGBA(), 1)
+		}
+		w.RequestFrame(float32(phase+1)*caretBlinkPeriod - elapsed)
+	}
+	w.PopClipRect()
+	t.EndRender(w)
+}
+
+// displayText returns the text as displayed, masked if necessary
+func (t *TextEdit) displayText() []rune {
+
+	if !t.password {
+		return t.text
+	}
+	return []rune(strings.Repeat(string(t.mask), len(t.text)))
+}
+
+// indexAt returns the rune index of the caret position nearest to the specified local horizontal position
+func (t *TextEdit) indexAt(w *window.Window, x float32) int {
+
+	return nearestOffset(t.caretOffsets(w.Font(t.ff, 0)), x-t.padding.Left+t.scrollX)
+}
+
+// caretOffsets returns the horizontal offset of each caret position of the displayed text,
+// which are only computed again when the text or the font changed
+func (t *TextEdit) caretOffsets(fa *window.FontAtlas) []float32 {
+
+	if t.offsets == nil || fa != t.offsetFont || fa.Scale() != t.offsetScale {
+		t.offsets = fa.CaretOffsets(t.displayText(), nil)
+		t.offsetFont = fa
+		t.offsetScale = fa.Scale()
+	}
+	return t.offsets
+}
+
+// nearestOffset returns the index of the offset nearest to the specified position in the
+// specified ascending offsets, which must not be empty
+func nearestOffset(offsets []float32, x float32) int {
+
+	i := sort.Search(len(offsets), func(i int) bool { return offsets[i] > x })
+	switch {
+	case i == 0:
+		return 0
+	case i == len(offsets):
+		return i - 1
+	case x < (offsets[i-1]+offsets[i])/2:
+		return i - 1
+	}
+	return i
+}
+
+// moveCaret moves the caret to the specified index, extending the selection if requested
+func (t *TextEdit) moveCaret(index int, extend bool) {
+
+	t.caret = util.Clamp(index, 0, len(t.text))
+	if !extend {
+		t.anchor = t.caret
+	}
+}
+
+// insert replaces the selected text with the specified runes, limited by the maximum length
+func (t *TextEdit) insert(runes []rune) {
+
+	filtered := runes[:0:0]
+	for _, r := range runes {
+		if unicode.IsPrint(r) || r == ' ' {
+			filtered = append(filtered, r)
+		}
+	}
+	start, end := t.Selection()
+	if t.maxLength > 0 {
+		free := t.maxLength - (len(t.text) - (end - start))
+		if len(filtered) > free {
+			filtered = filtered[:util.Max(free, 0)]
+		}
+	}
+	if len(filtered) == 0 && start == end {
+		return
+	}
+	text := make([]rune, 0, len(t.text)-(end-start)+len(filtered))
+	text = append(text, t.text[:start]...)
+	text = append(text, filtered...)
+	text = append(text, t.text[end:]...)
+	t.text = text
+	t.offsets = nil
+	t.caret = start + len(filtered)
+	t.anchor = t.caret
+	t.changed()
+}
+
+// delete removes the text between the specified rune indices
+func (t *TextEdit) delete(start, end int) {
+
+	if start >= end {
+		return
+	}
+	t.text = append(t.text[:start], t.text[end:]...)
+	t.offsets = nil
+	t.caret = start
+	t.anchor = start
+	t.changed()
+}
+
+// changed calls the change callback
+func (t *TextEdit) changed() {
+
+	if t.onChange != nil {
+		t.onChange(t)
+	}
+}
+
+// resetBlink restarts the caret blinking cycle showing the caret
+func (t *TextEdit) resetBlink(w *window.Window) {
+
+	t.blinkStart = w.FrameTime()
+}
+
+// prevWord returns the index of the start of the word before the specified index
+func (t *TextEdit) prevWord(index int) int {
+
+	if t.password {
+		return 0
+	}
+	return prevWordIndex(t.text, index)
+}
+
+// nextWord returns the index of the end of the word after the specified index
+func (t *TextEdit) nextWord(index int) int {
+
+	if t.password {
+		return len(t.text)
+	}
+	return nextWordIndex(t.text, index)
+}
+
+// isWordRune returns if the rune is part of a word for word navigation
+func isWordRune(r rune) bool {
+
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// prevWordIndex returns the index of the start of the word before the specified index
+func prevWordIndex(text []rune, index int) int {
+
+	i := util.Min(index, len(text))
+	for i > 0 && !isWordRune(text[i-1]) {
+		i--
+	}
+	for i > 0 && isWordRune(text[i-1]) {
+		i--
+	}
+	return i
+}
+
+// nextWordIndex returns the index of the end of the word after the specified index
+func nextWordIndex(text []rune, index int) int {
+
+	i := util.Max(index, 0)
+	for i < len(text) && !isWordRune(text[i]) {
+		i++
+	}
+	for i < len(text) && isWordRune(text[i]) {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,32 @@
+package view
+
+import (
+	"testing"
+)
+
+func TestNearestOffset(t *testing.T) {
+
+	offsets := []float32{0, 10, 15, 30}
+	cases := []struct {
+		x    float32
+		want int
+	}{
+		{-5, 0},
+		{0, 0},
+		{4.9, 0},
+		{5, 1},
+		{12, 1},
+		{12.5, 2},
+		{22, 2},
+		{23, 3},
+		{100, 3},
+	}
+	for _, c := range cases {
+		if got := nearestOffset(offsets, c.x); got != c.want {
+			t.Errorf("nearestOffset(%v): got %d, want %d", c.x, got, c.want)
+		}
+	}
+	if got := nearestOffset([]float32{0}, 10); got != 0 {
+		t.Errorf("nearestOffset of empty text: got %d, want 0", got)
+	}
+}
@@ -0,0 +1,168 @@
+package view
+
+import (
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	"github.com/leonsal/gux/window"
+)
+
+// toggleView is the base of the boolean input views, which draw an indicator followed by a label.
+// They are activated by a left mouse click or, when focused, by the Space key.
+type toggleView struct {
+	View
+	text     string               // Label text
+	ff       window.FontStyleType // Label font style
+	hovered  bool                 // Cursor is over the view
+	pressed  bool                 // Left mouse button was pressed over the view
+	keyDown  bool                 // Space key was pressed while focused
+	activate func()               // Function called when the view is activated
+}
+
+// initToggle initializes the toggleView base of the specified IView
+func (t *toggleView) initToggle(iv IView, text string, activate func()) {
+
+	t.Init(iv)
+	t.ff = window.FontRegular
+	t.focusable = true
+	t.text = text
+	t.activate = activate
+}
+
+// SetText sets the label text
+func (t *toggleView) SetText(text string) {
+
+	t.text = text
+}
+
+// Text returns the label text
+func (t *toggleView) Text() string {
+
+	return t.text
+}
+
+// Hovered returns if the cursor is over the view
+func (t *toggleView) Hovered() bool {
+
+	return t.hovered
+}
+
+// Pressed returns if the view is being pressed by the mouse or keyboard
+func (t *toggleView) Pressed() bool {
+
+	return (t.pressed && t.hovered) || t.keyDown
+}
+
+// OnEvent satisfies the IView interface
+func (t *toggleView) OnEvent(w *window.Window, ev *Event) bool {
+
+	switch ev.Type {
+	case EventMouseEnter:
+		t.hovered = true
+	case EventMouseLeave:
+		t.hovered = false
+	case EventFocusOut:
+		t.keyDown = false
+	case EventMouseDown:
+		if t.disabled || ev.Button != gb.MouseButtonLeft {
+			return false
+		}
+		t.pressed = true
+		return true
+	case EventMouseUp:
+		if ev.Button != gb.MouseButtonLeft || !t.pressed {
+			return false
+		}
+		t.pressed = false
+		if t.hovered && !t.disabled {
+			t.activate()
+		}
+		return true
+	case EventKeyDown:
+		if !t.disabled && ev.Key == gb.KeySpace {
+			t.keyDown = true
+			return true
+		}
+	case EventKeyUp:
+		if ev.Key == gb.KeySpace && t.keyDown {
+			t.keyDown = false
+			if !t.disabled {
+				t.activate()
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// measureToggle returns the desired size of the view with an indicator of the specified size
+func (t *toggleView) measureToggle(w *window.Window, indicator gb.Vec2) gb.Vec2 {
+
+	fa := w.Font(t.ff, 0)
+	size := indicator
+	if t.text != "" {
+		size.X += t.labelSpacing(fa) + fa.MeasureString(t.text)
+		size.Y = util.Max(size.Y, fa.Height())
+	}
+	size.Add(t.padding.Size())
+	return t.ConstrainSize(size)
+}
+
+// indicatorRect returns the rectangle of an indicator of the specified size
+// vertically centered at the start of the content area.
+func (t *toggleView) indicatorRect(indicator gb.Vec2) gb.Rect {
+
+	content := t.ContentRect()
+	min := gb.Vec2{content.Min.X, content.Min.Y + (content.Size().Y-indicator.Y)/2}
+	return gb.Rect{Min: min, Max: gb.Vec2Add(min, indicator)}
+}
+
+// renderLabel draws the label text after the indicator with the specified width
+func (t *toggleView) renderLabel(w *window.Window, dl *gb.DrawList, indicatorWidth float32) {
+
+	if t.text == "" {
+		return
+	}
+	fa := w.Font(t.ff, 0)
+	content := t.ContentRect()
+	textColor := StyleColorText
+	if t.disabled {
+		textColor = StyleColorTextDisabled
+	}
+	pos := gb.Vec2{content.Min.X + indicatorWidth + t.labelSpacing(fa), content.Min.Y + (content.Size().Y-fa.Height())/2}
+	w.AddText(dl, fa, &pos, t.StyleColor(w, textColor).RGBA(), window.TextVAlignTop, t.text)
+}
+
+// frameColor returns the indicator frame background color for the current state
+func (t *toggleView) frameColor(w *window.Window) gb.RGBA {
+
+	scolor := StyleColorFrame
+	if t.disabled {
+		scolor = StyleColorButtonDisabled
+	} else if t.Pressed() {
+		scolor = StyleColorFrameActive
+	} else if t.hovered {
+		scolor = StyleColorFrameHovered
+	}
+	return t.StyleColor(w, scolor).RGBA()
+}
+
+// markColor returns the color of the indicator marks for the current state
+func (t *toggleView) markColor(w *window.Window) gb.RGBA {
+
+	if t.disabled {
+		return t.StyleColor(w, StyleColorTextDisabled).RGBA()
+	}
+	return t.StyleColor(w, StyleColorCheckMark).RGBA()
+}
+
+// indicatorSide returns the size of the side of square indicators for the specified font
+func indicatorSide(fa *window.FontAtlas) float32 {
+
+	return fa.Height() * 0.8
+}
+
+// labelSpacing returns the space between the indicator and the label
+func (t *toggleView) labelSpacing(fa *window.FontAtlas) float32 {
+
+	return fa.Height() * 0.3
+}
@@ -0,0 +1,145 @@
+package view
+
+import (
+	"time"
+
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/window"
+)
+
+// Vertical distance between the cursor and its tooltip
+const tooltipCursorOffset = 20
+
+// Padding of text tooltips
+const tooltipPadding = 4
+
+// tooltipState contains the per window state of tooltips
+type tooltipState struct {
+	owner   IView     // View under the cursor with a tooltip (nil if none)
+	shown   IView     // Tooltip view being shown (nil if none)
+	start   time.Time // Time the cursor started resting over the owner
+	blocked bool      // Tooltip hidden by a mouse press until the cursor leaves the owner
+}
+
+// windowTooltips maps Windows to their tooltip state
+var windowTooltips = map[*window.Window]*tooltipState{}
+
+// SetTooltip sets the text shown in a tooltip when the cursor rests over the view.
+// An empty text removes the tooltip.
+func (v *View) SetTooltip(text string) {
+
+	if text == "" {
+		v.tooltip = nil
+		return
+	}
+	p := NewPanel()
+	p.bg = StyleColorTooltip
+	p.padding = Insets{tooltipPadding, tooltipPadding, tooltipPadding, tooltipPadding}
+	p.Add(NewLabel(text))
+	v.tooltip = p
+}
+
+// SetTooltipView sets the view shown as tooltip when the cursor rests over the view.
+// A nil view removes the tooltip.
+func (v *View) SetTooltipView(iv IView) {
+
+	v.tooltip = iv
+}
+
+// Tooltip returns the tooltip view of the view or nil
+func (v *View) Tooltip() IView {
+
+	return v.tooltip
+}
+
+// SetTooltipFollow sets if the tooltip follows the cursor while it moves over the view
+// instead of staying where it was shown. The default is false.
+func (v *View) SetTooltipFollow(follow bool) {
+
+	v.tipFollow = follow
+}
+
+// TooltipFollow returns if the tooltip follows the cursor
+func (v *View) TooltipFollow() bool {
+
+	return v.tipFollow
+}
+
+// updateTooltip shows or hides the tooltip of the view under the cursor after the events of a frame
+// were dispatched, indicating if the cursor moved or a mouse button was pressed in the frame.
+// A frame is requested for when the tooltip delay expires, so tooltips appear even if there are no events.
+func updateTooltip(w *window.Window, s *eventState, moved, pressed bool) {
+
+	ts, ok := windowTooltips[w]
+	if !ok {
+		ts = new(tooltipState)
+		windowTooltips[w] = ts
+		w.OnDestroy(func(w *window.Window) { delete(windowTooltips, w) })
+	}
+	now := w.FrameTime()
+	var owner IView
+	if s.inside {
+		owner = tooltipOwner(s.hovered)
+	}
+	if owner != ts.owner {
+		ts.hide(w)
+		ts.owner = owner
+		ts.start = now
+		ts.blocked = false
+	}
+	if owner == nil || ts.blocked {
+		return
+	}
+	if pressed {
+		ts.hide(w)
+		ts.blocked = true
+		return
+	}
+	ov := owner.GetView()
+	if ts.shown != nil {
+		if moved && ov.tipFollow {
+			ts.place(w, s.cursor)
+		}
+		return
+	}
+
+	// Restarts the delay while the cursor moves
+	if moved {
+		ts.start = now
+	}
+	due := ts.start.Add(time.Duration(float64(ov.StyleTooltipDelay(w)) * float64(time.Second)))
+	if now.Before(due) {
+		w.RequestFrame(float32(due.Sub(now).Seconds()))
+		return
+	}
+	ts.shown = ov.tooltip
+	ShowOverlayEx(w, ts.shown, OverlayPassThrough)
+	ts.place(w, s.cursor)
+}
+
+// place positions the shown tooltip below the cursor, or above it if there is no space, inside the window
+func (ts *tooltipState) place(w *window.Window, cursor gb.Vec2) {
+
+	anchor := gb.Rect{Min: cursor, Max: gb.Vec2{cursor.X, cursor.Y + tooltipCursorOffset}}
+	SetOverlayAnchor(w, ts.shown, anchor, PlaceBelow)
+}
+
+// hide closes the shown tooltip, if any
+func (ts *tooltipState) hide(w *window.Window) {
+
+	if ts.shown != nil {
+		CloseOverlay(w, ts.shown)
+		ts.shown = nil
+	}
+}
+
+// tooltipOwner returns the first view with a tooltip from the specified view up to the top view or nil
+func tooltipOwner(iv IView) IView {
+
+	for ; iv != nil; iv = iv.GetView().parent {
+		if iv.GetView().tooltip != nil {
+			return iv
+		}
+	}
+	return nil
+}
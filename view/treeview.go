@@ -0,0 +1,449 @@
+package view
+
+import (
+	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/window"
+)
+
+// TreeDataSource supplies the nodes of a TreeView.
+// Nodes are values which identify them and are used as map keys, so they must be of a comparable
+// type, such as pointers or paths: uncomparable nodes, such as slices or maps, cause a panic
+// unless the data source implements TreeNodeKeys.
+// Children are only requested when their parent node is expanded and are kept until refreshed.
+type TreeDataSource interface {
+	Children(node any) []any                     // Returns the children of the node or the root nodes if nil
+	HasChildren(node any) bool                   // Returns if the node can have children, without loading them
+	RenderNode(w *window.Window, item *TreeItem) // Draws the node in the item rectangle
+}
+
+// TreeNodeKeys can be implemented by a TreeDataSource whose nodes are not comparable
+// to supply a comparable key which identifies each node
+type TreeNodeKeys interface {
+	NodeKey(node any) any // Returns the comparable key of the node
+}
+
+// TreeItem describes a node of a TreeView being drawn.
+// Its rectangle excludes the indentation and the expand arrow.
+type TreeItem struct {
+	ListItem
+	Node     any  // Node being drawn
+	Depth    int  // Depth of the node (0 for root nodes)
+	Expanded bool // Node is expanded
+}
+
+// TreeView shows a hierarchy of nodes supplied by a TreeDataSource as a virtualized list of the
+// visible nodes, with indentation guides and arrows to expand and collapse nodes with children.
+// Besides the ListView keys, Right expands the current node or moves to its first child
+// and Left collapses it or moves to its parent.
+type TreeView struct {
+	ListView
+	tsrc           TreeDataSource             // Data source
+	rows           []treeRow                  // Visible nodes in display order
+	children       map[any][]any              // Loaded children of the nodes by node key (roots use nil)
+	expanded       map[any]bool               // Keys of the expanded nodes
+	indent         float32                    // Indentation of each level (0 uses the item height)
+	onExpand       func(*TreeView, any, bool) // Node expansion change callback
+	onActivateNode func(*TreeView, any)       // Node activation callback
+	onSelectNode   func(*TreeView)            // Selection change callback
+}
+
+// treeRow is a visible node of a TreeView
+type treeRow struct {
+	node        any  // Node
+	key         any  // Node key
+	depth       int  // Depth of the node
+	hasChildren bool // Node shows an expand arrow
+}
+
+// treeRows is the ListDataSource with the visible nodes of a TreeView
+type treeRows struct {
+	tv *TreeView
+}
+
+// NewTreeView creates and returns a new TreeView with the specified data source, which can be nil
+func NewTreeView(src TreeDataSource) *TreeView {
+
+	tv := new(TreeView)
+	tv.initListView(tv, treeRows{tv})
+	tv.ListView.onSelect = func(*ListView) {
+		if tv.onSelectNode != nil {
+			tv.onSelectNode(tv)
+		}
+	}
+	tv.ListView.onActivate = func(_ *ListView, index int) {
+		if tv.onActivateNode != nil {
+			tv.onActivateNode(tv, tv.rows[index].node)
+		}
+	}
+	tv.expanded = make(map[any]bool)
+	tv.SetDataSource(src)
+	return tv
+}
+
+// SetDataSource sets the data source, clearing the loaded nodes, the expansion states and the selection
+func (tv *TreeView) SetDataSource(src TreeDataSource) {
+
+	tv.tsrc = src
+	tv.children = make(map[any][]any)
+	tv.expanded = make(map[any]bool)
+	tv.rows = tv.subRows(nil, 0)
+	tv.ListView.SetDataSource(treeRows{tv})
+}
+
+// DataSource returns the data source
+func (tv *TreeView) DataSource() TreeDataSource {
+
+	return tv.tsrc
+}
+
+// SetIndent sets the indentation of each tree level. The default (0) uses the item height.
+func (tv *TreeView) SetIndent(indent float32) {
+
+	tv.indent = indent
+}
+
+// Indent returns the indentation of each tree level set by SetIndent()
+func (tv *TreeView) Indent() float32 {
+
+	return tv.indent
+}
+
+// Expand expands the specified visible node, loading its children if necessary.
+// Returns false if the node is not visible.
+func (tv *TreeView) Expand(node any) bool {
+
+	index := tv.rowOf(node)
+	if index < 0 {
+		return false
+	}
+	tv.expandRow(index)
+	return true
+}
+
+// Collapse collapses the specified visible node. Returns false if the node is not visible.
+func (tv *TreeView) Collapse(node any) bool {
+
+	index := tv.rowOf(node)
+	if index < 0 {
+		return false
+	}
+	tv.collapseRow(index)
+	return true
+}
+
+// IsExpanded returns if the specified node is expanded
+func (tv *TreeView) IsExpanded(node any) bool {
+
+	return tv.expanded[tv.key(node)]
+}
+
+// Refresh discards the loaded children of the specified node, or of all nodes if nil,
+// requesting them again from the data source. Expanded nodes remain expanded.
+func (tv *TreeView) Refresh(node any) {
+
+	if node == nil {
+		tv.children = make(map[any][]any)
+		tv.rows = tv.subRows(nil, 0)
+		tv.DataChanged()
+		return
+	}
+	index := tv.rowOf(node)
+	delete(tv.children, tv.key(node))
+	if index < 0 {
+		return
+	}
+	if tv.expanded[tv.key(node)] {
+		tv.collapseRow(index)
+		tv.rows[index].hasChildren = tv.tsrc.HasChildren(node)
+		tv.expandRow(index)
+		return
+	}
+	tv.rows[index].hasChildren = tv.tsrc.HasChildren(node)
+}
+
+// NodeAt returns the visible node at the specified point in local coordinates or nil if none
+func (tv *TreeView) NodeAt(pos gb.Vec2) any {
+
+	index := tv.ItemAt(pos)
+	if index < 0 {
+		return nil
+	}
+	return tv.rows[index].node
+}
+
+// SelectNode selects only the specified visible node, making it the current node.
+// Returns false if the node is not visible.
+func (tv *TreeView) SelectNode(node any) bool {
+
+	index := tv.rowOf(node)
+	if index < 0 {
+		return false
+	}
+	tv.Select(index)
+	tv.ScrollToIndex(index)
+	return true
+}
+
+// SelectedNode returns the first selected node or nil if none
+func (tv *TreeView) SelectedNode() any {
+
+	index := tv.SelectedIndex()
+	if index < 0 {
+		return nil
+	}
+	return tv.rows[index].node
+}
+
+// SelectedNodes returns the selected nodes in display order
+func (tv *TreeView) SelectedNodes() []any {
+
+	var nodes []any
+	for _, index := range tv.SelectedIndices() {
+		nodes = append(nodes, tv.rows[index].node)
+	}
+	return nodes
+}
+
+// CurrentNode returns the current node for keyboard navigation or nil if none
+func (tv *TreeView) CurrentNode() any {
+
+	if tv.current < 0 {
+		return nil
+	}
+	return tv.rows[tv.current].node
+}
+
+// OnExpand sets the function called when a node is expanded or collapsed
+func (tv *TreeView) OnExpand(cb func(tv *TreeView, node any, expanded bool)) {
+
+	tv.onExpand = cb
+}
+
+// OnActivate sets the function called when a node is activated by the Enter key
+func (tv *TreeView) OnActivate(cb func(tv *TreeView, node any)) {
+
+	tv.onActivateNode = cb
+}
+
+// OnSelectionChange sets the function called when the selection changes
+func (tv *TreeView) OnSelectionChange(cb func(tv *TreeView)) {
+
+	tv.onSelectNode = cb
+}
+
+// OnEvent satisfies the IView interface
+func (tv *TreeView) OnEvent(w *window.Window, ev *Event) bool {
+
+	switch ev.Type {
+	case EventMouseDown:
+		if tv.disabled || ev.Button != gb.MouseButtonLeft {
+			break
+		}
+		if axis, _ := tv.barAt(ev.Pos); axis >= 0 {
+			break
+		}
+		// Clicking on the arrow toggles the node without changing the selection
+		index := tv.ItemAt(ev.Pos)
+		if index < 0 || !tv.rows[index].hasChildren {
+			break
+		}
+		x := ev.Pos.X - tv.viewport.Min.X + tv.offset.X
+		ax := float32(tv.rows[index].depth) * tv.indentWidth()
+		if x >= ax && x < ax+tv.indentWidth() {
+			tv.toggleRow(index)
+			return true
+		}
+	case EventKeyDown:
+		if tv.disabled || tv.current < 0 {
+			break
+		}
+		row := tv.rows[tv.current]
+		switch ev.Key {
+		case gb.KeyRight:
+			if row.hasChildren && !tv.expanded[row.key] {
+				tv.expandRow(tv.current)
+			} else if tv.current+1 < len(tv.rows) && tv.rows[tv.current+1].depth > row.depth {
+				tv.moveTo(tv.current + 1)
+			}
+			return true
+		case gb.KeyLeft:
+			if tv.expanded[row.key] {
+				tv.collapseRow(tv.current)
+				return true
+			}
+			for i := tv.current - 1; i >= 0; i-- {
+				if tv.rows[i].depth < row.depth {
+					tv.moveTo(i)
+					break
+				}
+			}
+			return true
+		}
+	}
+	return tv.ListView.OnEvent(w, ev)
+}
+
+// Len satisfies the ListDataSource interface
+func (r treeRows) Len() int {
+
+	return len(r.tv.rows)
+}
+
+// RenderItem satisfies the ListDataSource interface
+func (r treeRows) RenderItem(w *window.Window, item *ListItem) {
+
+	tv := r.tv
+	row := tv.rows[item.Index]
+	indent := tv.indentWidth()
+	midY := (item.Rect.Min.Y + item.Rect.Max.Y) / 2
+
+	// Draws the indentation guides of the expanded ancestors
+	guide := tv.StyleColor(w, StyleColorBorder).RGBA()
+	for d := 0; d < row.depth; d++ {
+		x := item.Rect.Min.X + float32(d)*indent + indent/2
+		w.AddLine(item.DL, gb.Vec2{x, item.Rect.Min.Y}, gb.Vec2{x, item.Rect.Max.Y}, guide, 1)
+	}
+
+	// Draws the expand arrow
+	ax := item.Rect.Min.X + float32(row.depth)*indent
+	expanded := tv.expanded[row.key]
+	if row.hasChildren {
+		dir := arrowRight
+		if expanded {
+			dir = arrowDown
+		}
+		drawArrow(w, item.DL, gb.Vec2{ax + indent/2, midY}, indent*0.35, dir, tv.StyleColor(w, StyleColorText).RGBA())
+	}
+
+	if tv.tsrc == nil {
+		return
+	}
+	ti := TreeItem{ListItem: *item, Node: row.node, Depth: row.depth, Expanded: expanded}
+	ti.Rect.Min.X = ax + indent
+	tv.tsrc.RenderNode(w, &ti)
+}
+
+// indentWidth returns the indentation of each tree level
+func (tv *TreeView) indentWidth() float32 {
+
+	if tv.indent > 0 {
+		return tv.indent
+	}
+	return tv.rowHeight()
+}
+
+// rowOf returns the index of the row of the specified node or -1 if it is not visible
+func (tv *TreeView) rowOf(node any) int {
+
+	key := tv.key(node)
+	for i := range tv.rows {
+		if tv.rows[i].key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// moveTo makes the specified row the current and only selected row
+func (tv *TreeView) moveTo(index int) {
+
+	tv.Select(index)
+	tv.ScrollToIndex(index)
+}
+
+// toggleRow expands or collapses the node of the specified row
+func (tv *TreeView) toggleRow(index int) {
+
+	if tv.expanded[tv.rows[index].key] {
+		tv.collapseRow(index)
+	} else {
+		tv.expandRow(index)
+	}
+}
+
+// expandRow expands the node of the specified row inserting the rows of its visible descendants
+func (tv *TreeView) expandRow(index int) {
+
+	row := tv.rows[index]
+	if tv.expanded[row.key] || !row.hasChildren {
+		return
+	}
+	tv.expanded[row.key] = true
+	sub := tv.subRows(row.node, row.depth+1)
+	if len(sub) == 0 {
+		tv.rows[index].hasChildren = false
+	}
+	rows := make([]treeRow, 0, len(tv.rows)+len(sub))
+	rows = append(rows, tv.rows[:index+1]...)
+	rows = append(rows, sub...)
+	rows = append(rows, tv.rows[index+1:]...)
+	tv.rows = rows
+	tv.ItemsInserted(index+1, len(sub))
+	if tv.onExpand != nil {
+		tv.onExpand(tv, row.node, true)
+	}
+}
+
+// collapseRow collapses the node of the specified row removing the rows of its descendants.
+// The expansion state of the descendants is kept.
+func (tv *TreeView) collapseRow(index int) {
+
+	row := tv.rows[index]
+	if !tv.expanded[row.key] {
+		return
+	}
+	delete(tv.expanded, row.key)
+	end := index + 1
+	for end < len(tv.rows) && tv.rows[end].depth > row.depth {
+		end++
+	}
+	inside := tv.current > index && tv.current < end
+	tv.rows = append(tv.rows[:index+1], tv.rows[end:]...)
+	tv.ItemsRemoved(index+1, end-index-1)
+	if inside {
+		tv.current = index
+		tv.anchor = index
+	}
+	if tv.onExpand != nil {
+		tv.onExpand(tv, row.node, false)
+	}
+}
+
+// subRows returns the rows of the children of the specified node at the specified depth
+// followed by the rows of their expanded descendants
+func (tv *TreeView) subRows(node any, depth int) []treeRow {
+
+	var rows []treeRow
+	for _, child := range tv.loadChildren(node) {
+		key := tv.key(child)
+		rows = append(rows, treeRow{node: child, key: key, depth: depth, hasChildren: tv.tsrc.HasChildren(child)})
+		if tv.expanded[key] {
+			rows = append(rows, tv.subRows(child, depth+1)...)
+		}
+	}
+	return rows
+}
+
+// loadChildren returns the children of the specified node requesting them from the data source if not loaded
+func (tv *TreeView) loadChildren(node any) []any {
+
+	if tv.tsrc == nil {
+		return nil
+	}
+	key := tv.key(node)
+	children, ok := tv.children[key]
+	if !ok {
+		children = tv.tsrc.Children(node)
+		tv.children[key] = children
+	}
+	return children
+}
+
+// key returns the key which identifies the specified node in the maps of the TreeView
+func (tv *TreeView) key(node any) any {
+
+	if keys, ok := tv.tsrc.(TreeNodeKeys); ok && node != nil {
+		return keys.NodeKey(node)
+	}
+	return node
+}
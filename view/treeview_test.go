@@ -0,0 +1,52 @@
+package view
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/leonsal/gux/window"
+)
+
+// testTreeSource is a tree data source whose nodes are uncomparable paths of child indices.
+// Each node has two children up to the depth 2.
+type testTreeSource struct{}
+
+func (testTreeSource) Children(node any) []any {
+
+	path, _ := node.([]int)
+	if len(path) >= 2 {
+		return nil
+	}
+	var children []any
+	for i := 0; i < 2; i++ {
+		children = append(children, append(append([]int(nil), path...), i))
+	}
+	return children
+}
+
+func (testTreeSource) HasChildren(node any) bool                   { return len(node.([]int)) < 2 }
+func (testTreeSource) RenderNode(w *window.Window, item *TreeItem) {}
+func (testTreeSource) NodeKey(node any) any                        { return fmt.Sprint(node) }
+
+func TestTreeNodeKeys(t *testing.T) {
+
+	tv := NewTreeView(testTreeSource{})
+	if !tv.Expand([]int{1}) || !tv.IsExpanded([]int{1}) {
+		t.Fatalf("node not expanded")
+	}
+	var nodes []string
+	for _, row := range tv.rows {
+		nodes = append(nodes, fmt.Sprint(row.node))
+	}
+	if want := []string{"[0]", "[1]", "[1 0]", "[1 1]"}; !reflect.DeepEqual(nodes, want) {
+		t.Errorf("visible nodes %v, want %v", nodes, want)
+	}
+	if !tv.SelectNode([]int{1, 1}) || fmt.Sprint(tv.SelectedNode()) != "[1 1]" {
+		t.Errorf("selected node %v, want [1 1]", tv.SelectedNode())
+	}
+	tv.Refresh([]int{1})
+	if !tv.Collapse([]int{1}) || len(tv.rows) != 2 {
+		t.Errorf("node not collapsed: %d rows", len(tv.rows))
+	}
+}
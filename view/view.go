@@ -1,32 +1,48 @@
 package view
 
 import (
-	"fmt"
-
 	"github.com/leonsal/gux/gb"
 	"github.com/leonsal/gux/window"
 )
 
 type IView interface {
-	Render(*window.Window) // Renders the view at the specified window
-	//Event(gb.Event) bool
-	//Pos() gb.Vec2
-	//Size() gb.Vec2
-	SetPos(x, y float32) // Sets the view position relative to its parent
-	SetTransform(t *gb.Mat3)
+	Render(*window.Window)                               // Renders the view at the specified window
+	OnEvent(w *window.Window, ev *Event) bool            // Processes an event and returns true if it was handled
+	Pos() gb.Vec2                                        // Returns the view position relative to its parent
+	Size() gb.Vec2                                       // Returns the view size set by the last layout pass
+	SetPos(x, y float32)                                 // Sets the view position relative to its parent
+	SetTransform(t *gb.Mat3)                             // Sets the view transform from its parent transform
+	GetView() *View                                      // Returns the base View of this IView
+	Measure(w *window.Window, avail gb.Vec2) gb.Vec2     // Returns the desired size of the view for the available size
+	Arrange(w *window.Window, pos gb.Vec2, size gb.Vec2) // Sets the final position and size of the view
 }
 
 type View struct {
-	iview      IView         // Associated IView
-	visible    bool          // Visibility state
-	pos        gb.Vec2       // View position relative to its parent
-	scale      gb.Vec2       // View scale
-	rotation   float32       // Rotation in radians
-	transform  gb.Mat3       // Current transform matrix used  in AddList2()
-	style      StyleMap      // Optional specific style map
-	styleColor StyleColorMap // Optional specific style color map
-	parent     IView         // Parent IView (maybe nil)
-	children   []IView       // List of child views
+	iview       IView         // Associated IView
+	visible     bool          // Visibility state
+	disabled    bool          // Disabled state
+	focusable   bool          // View can receive the keyboard focus
+	pos         gb.Vec2       // View position relative to its parent
+	size        gb.Vec2       // View size set by the last layout pass
+	prefSize    gb.Vec2       // Preferred size (zero components are not used)
+	minSize     gb.Vec2       // Minimum size
+	maxSize     gb.Vec2       // Maximum size (zero components are not used)
+	desired     gb.Vec2       // Desired size calculated by the last measure pass
+	margin      Insets        // Space around the view used by its container
+	padding     Insets        // Space between the view bounds and its content
+	scale       gb.Vec2       // View scale
+	rotation    float32       // Rotation in radians
+	transform   gb.Mat3       // Current transform matrix used  in AddList2()
+	dl          gb.DrawList   // Draw list with the view commands in local coordinates
+	style       StyleMap      // Optional specific style map
+	styleColor  StyleColorMap // Optional specific style color map
+	parent      IView         // Parent IView (maybe nil)
+	children    []IView       // List of child views
+	clipChild   bool          // Children are clipped to childClip
+	childClip   gb.Rect       // Rectangle in local coordinates which clips the children
+	contextMenu *Menu         // Menu opened by the right mouse button (maybe nil)
+	tooltip     IView         // Tooltip view shown when the cursor rests over the view (maybe nil)
+	tipFollow   bool          // Tooltip follows the cursor
 }
 
 func (v *View) Init(iv IView) {
@@ -37,6 +53,12 @@ func (v *View) Init(iv IView) {
 	v.transform.Identity()
 }
 
+// GetView satisfies the IView interface and returns this base View
+func (v *View) GetView() *View {
+
+	return v
+}
+
 func (v *View) SetVisible(visible bool) {
 
 	v.visible = visible
@@ -57,6 +79,12 @@ func (v *View) Pos() gb.Vec2 {
 	return v.pos
 }
 
+// Size returns the view size set by the last layout pass
+func (v *View) Size() gb.Vec2 {
+
+	return v.size
+}
+
 func (v *View) SetScale(x, y float32) *View {
 
 	v.scale = gb.Vec2{x, y}
@@ -78,31 +106,90 @@ func (v *View) Rotation() float32 {
 	return v.rotation
 }
 
-// SetTransform updates the transform matrix of this view and applies
+// Parent returns the parent of this view or nil
+func (v *View) Parent() IView {
+
+	return v.parent
+}
+
+// Children returns the list of children of this view
+func (v *View) Children() []IView {
+
+	return v.children
+}
+
+// SetTransform updates the transform matrix of this view from its parent transform matrix
 func (v *View) SetTransform(t *gb.Mat3) {
 
-	v.transform.SetTranslationVec(v.pos).Rotate(v.rotation).ScaleVec(v.scale)
-	v.transform.Mult(t)
+	var local gb.Mat3
+	local.SetTranslationVec(v.pos).Rotate(v.rotation).ScaleVec(v.scale)
+	v.transform.MultMat(t, &local)
 }
 
-func (v *View) Add(iv IView) {
+// Transform returns pointer to the current transform matrix of this view
+func (v *View) Transform() *gb.Mat3 {
 
-	v.children = append(v.children, iv)
+	return &v.transform
 }
 
-func (v *View) RenderChildren(w *window.Window) {
+// Add appends the specified child views to this view
+func (v *View) Add(children ...IView) {
+
+	for _, iv := range children {
+		iv.GetView().parent = v.iview
+		v.children = append(v.children, iv)
+	}
+}
+
+// Remove removes the specified child view from this view.
+// Returns false if the view was not found.
+func (v *View) Remove(iv IView) bool {
+
+	for i, c := range v.children {
+		if c == iv {
+			copy(v.children[i:], v.children[i+1:])
+			v.children[len(v.children)-1] = nil
+			v.children = v.children[:len(v.children)-1]
+			iv.GetView().parent = nil
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveAll removes all the children of this view
+func (v *View) RemoveAll() {
 
-	// Sets parent world transform matrix
-	var mat gb.Mat3
-	mat.SetTranslationVec(v.pos).Rotate(v.rotation).ScaleVec(v.scale)
-	fmt.Printf("mat:%+v\n", mat)
 	for _, c := range v.children {
-		c.SetTransform(&mat)
-		c.Render(w)
+		c.GetView().parent = nil
 	}
+	v.children = nil
+}
 
+// BeginRender clears and returns the view draw list.
+// Views draw their commands in this list using local coordinates
+// with origin at the view top left corner.
+func (v *View) BeginRender() *gb.DrawList {
+
+	v.dl.Clear()
+	return &v.dl
+}
+
+// EndRender appends the view draw list to the window draw list
+// transformed by the view current transform.
+func (v *View) EndRender(w *window.Window) {
+
+	w.DrawList().AddList2(&v.dl, &v.transform)
 }
 
-func DispatchEvents(w *window.Window, v IView) {
+func (v *View) RenderChildren(w *window.Window) {
 
+	// Sets children world transform matrix from this view transform
+	for _, c := range v.children {
+		if !c.GetView().visible {
+			continue
+		}
+		c.SetTransform(&v.transform)
+		c.Render(w)
+	}
 }
@@ -0,0 +1,300 @@
+package window
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+
+	"golang.org/x/image/font/sfnt"
+)
+
+// Errors returned when reading the color tables of a font
+var (
+	errNoColorTables = errors.New("font has no supported color glyph tables (sbix or CBLC/CBDT)")
+	errInvalidSfnt   = errors.New("invalid font table directory")
+)
+
+// FontGlyphSource is a ColorGlyphSource with the bitmap color glyphs of a color font,
+// such as the emoji fonts Noto Color Emoji (CBLC/CBDT tables) and Apple Color Emoji (sbix table).
+// Only PNG glyph images are supported and the strike with the largest size is used.
+// Fonts with vector color glyphs (COLR/CPAL and SVG tables) are not supported.
+type FontGlyphSource struct {
+	font *sfnt.Font  // Parsed font used to map runes to glyph indices
+	buf  sfnt.Buffer // Buffer used to map runes to glyph indices
+	sbix []byte      // sbix table (maybe nil)
+	cblc []byte      // CBLC table (maybe nil)
+	cbdt []byte      // CBDT table (maybe nil)
+}
+
+// NewFontGlyphSource creates and returns a new FontGlyphSource with the color glyphs of the
+// specified font data, which must be a single font (not a collection).
+func NewFontGlyphSource(fontData []byte) (*FontGlyphSource, error) {
+
+	f, err := sfnt.Parse(fontData)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := sfntTables(fontData)
+	if err != nil {
+		return nil, err
+	}
+	gs := &FontGlyphSource{font: f, sbix: tables["sbix"], cblc: tables["CBLC"], cbdt: tables["CBDT"]}
+	if gs.sbix == nil && (gs.cblc == nil || gs.cbdt == nil) {
+		return nil, errNoColorTables
+	}
+	return gs, nil
+}
+
+// ColorGlyph satisfies the ColorGlyphSource interface
+func (gs *FontGlyphSource) ColorGlyph(r rune) (image.Image, bool) {
+
+	gid, err := gs.font.GlyphIndex(&gs.buf, r)
+	if err != nil || gid == 0 {
+		return nil, false
+	}
+	var data []byte
+	var ok bool
+	if gs.sbix != nil {
+		data, ok = sbixGlyph(gs.sbix, gs.font.NumGlyphs(), uint16(gid))
+	}
+	if !ok && gs.cblc != nil && gs.cbdt != nil {
+		data, ok = cbdtGlyph(gs.cblc, gs.cbdt, uint16(gid))
+	}
+	if !ok {
+		return nil, false
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, false
+	}
+	return img, true
+}
+
+// sfntTables returns the tables of the specified font data indexed by their tags
+func sfntTables(data []byte) (map[string][]byte, error) {
+
+	if len(data) < 12 {
+		return nil, errInvalidSfnt
+	}
+	count := int(binary.BigEndian.Uint16(data[4:]))
+	if len(data) < 12+16*count {
+		return nil, errInvalidSfnt
+	}
+	tables := make(map[string][]byte, count)
+	for i := 0; i < count; i++ {
+		rec := data[12+16*i:]
+		offset := uint64(binary.BigEndian.Uint32(rec[8:]))
+		length := uint64(binary.BigEndian.Uint32(rec[12:]))
+		if offset+length > uint64(len(data)) {
+			return nil, fmt.Errorf("%w: table %q out of bounds", errInvalidSfnt, rec[:4])
+		}
+		tables[string(rec[:4])] = data[offset : offset+length]
+	}
+	return tables, nil
+}
+
+// Maximum number of "dupe" references followed when reading a sbix glyph
+const sbixMaxDupes = 4
+
+// sbixGlyph returns the PNG data of the specified glyph from the strike with the largest size
+// of the specified sbix table
+func sbixGlyph(sbix []byte, numGlyphs int, gid uint16) ([]byte, bool) {
+
+	if len(sbix) < 8 || int(gid) >= numGlyphs {
+		return nil, false
+	}
+	numStrikes := int(binary.BigEndian.Uint32(sbix[4:]))
+	if len(sbix) < 8+4*numStrikes {
+		return nil, false
+	}
+	var strike []byte
+	bestPPEM := -1
+	for i := 0; i < numStrikes; i++ {
+		offset := int(binary.BigEndian.Uint32(sbix[8+4*i:]))
+		if offset < 0 || offset+4+4*(numGlyphs+1) > len(sbix) {
+			return nil, false
+		}
+		if ppem := int(binary.BigEndian.Uint16(sbix[offset:])); ppem > bestPPEM {
+			bestPPEM = ppem
+			strike = sbix[offset:]
+		}
+	}
+	if strike == nil {
+		return nil, false
+	}
+	for dupes := 0; dupes <= sbixMaxDupes; dupes++ {
+		start := int(binary.BigEndian.Uint32(strike[4+4*int(gid):]))
+		end := int(binary.BigEndian.Uint32(strike[4+4*(int(gid)+1):]))
+		if end-start < 8 || end > len(strike) {
+			return nil, false
+		}
+		data := strike[start:end]
+		switch string(data[4:8]) {
+		case "png ":
+			return data[8:], true
+		case "dupe":
+			if len(data) < 10 {
+				return nil, false
+			}
+			gid = binary.BigEndian.Uint16(data[8:])
+			if int(gid) >= numGlyphs {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// Sizes of the CBLC structures
+const (
+	cblcHeaderSize     = 8
+	cblcBitmapSizeSize = 48
+	cblcSubTableSize   = 8
+	cblcBigMetricsSize = 8
+)
+
+// cbdtGlyph returns the PNG data of the specified glyph from the strike with the largest size
+// of the specified CBLC and CBDT tables
+func cbdtGlyph(cblc, cbdt []byte, gid uint16) ([]byte, bool) {
+
+	if len(cblc) < cblcHeaderSize {
+		return nil, false
+	}
+	numSizes := int(binary.BigEndian.Uint32(cblc[4:]))
+	if len(cblc) < cblcHeaderSize+numSizes*cblcBitmapSizeSize {
+		return nil, false
+	}
+
+	// Finds the largest strike which contains the glyph
+	var size []byte
+	bestPPEM := -1
+	for i := 0; i < numSizes; i++ {
+		bs := cblc[cblcHeaderSize+i*cblcBitmapSizeSize:]
+		start, end := binary.BigEndian.Uint16(bs[40:]), binary.BigEndian.Uint16(bs[42:])
+		if ppem := int(bs[45]); gid >= start && gid <= end && ppem > bestPPEM {
+			bestPPEM = ppem
+			size = bs
+		}
+	}
+	if size == nil {
+		return nil, false
+	}
+
+	// Finds the index subtable which contains the glyph
+	arrayOffset := int(binary.BigEndian.Uint32(size[0:]))
+	numSubTables := int(binary.BigEndian.Uint32(size[8:]))
+	if arrayOffset < 0 || arrayOffset+numSubTables*cblcSubTableSize > len(cblc) {
+		return nil, false
+	}
+	for i := 0; i < numSubTables; i++ {
+		st := cblc[arrayOffset+i*cblcSubTableSize:]
+		first, last := binary.BigEndian.Uint16(st[0:]), binary.BigEndian.Uint16(st[2:])
+		if gid < first || gid > last {
+			continue
+		}
+		offset := arrayOffset + int(binary.BigEndian.Uint32(st[4:]))
+		start, end, format, ok := cblcGlyphRange(cblc, offset, first, last, gid)
+		if !ok || start < 0 || end > len(cbdt) || start >= end {
+			return nil, false
+		}
+		return cbdtImage(cbdt[start:end], format)
+	}
+	return nil, false
+}
+
+// cblcGlyphRange returns the range of the data of the specified glyph in the CBDT table
+// and its image format from the index subtable at the specified offset of the CBLC table
+func cblcGlyphRange(cblc []byte, offset int, first, last, gid uint16) (int, int, uint16, bool) {
+
+	if offset < 0 || offset+8 > len(cblc) {
+		return 0, 0, 0, false
+	}
+	indexFormat := binary.BigEndian.Uint16(cblc[offset:])
+	imageFormat := binary.BigEndian.Uint16(cblc[offset+2:])
+	imageData := int(binary.BigEndian.Uint32(cblc[offset+4:]))
+	body := cblc[offset+8:]
+	index := int(gid - first)
+	count := int(last-first) + 1
+	switch indexFormat {
+	case 1: // Variable size glyphs with 32 bit offsets
+		if len(body) < 4*(count+1) {
+			break
+		}
+		start := int(binary.BigEndian.Uint32(body[4*index:]))
+		end := int(binary.BigEndian.Uint32(body[4*(index+1):]))
+		return imageData + start, imageData + end, imageFormat, true
+	case 2: // Fixed size glyphs
+		if len(body) < 4 {
+			break
+		}
+		imageSize := int(binary.BigEndian.Uint32(body))
+		return imageData + index*imageSize, imageData + (index+1)*imageSize, imageFormat, true
+	case 3: // Variable size glyphs with 16 bit offsets
+		if len(body) < 2*(count+1) {
+			break
+		}
+		start := int(binary.BigEndian.Uint16(body[2*index:]))
+		end := int(binary.BigEndian.Uint16(body[2*(index+1):]))
+		return imageData + start, imageData + end, imageFormat, true
+	case 4: // Sparse variable size glyphs
+		if len(body) < 4 {
+			break
+		}
+		numGlyphs := int(binary.BigEndian.Uint32(body))
+		if len(body) < 4+4*(numGlyphs+1) {
+			break
+		}
+		for i := 0; i < numGlyphs; i++ {
+			pair := body[4+4*i:]
+			if binary.BigEndian.Uint16(pair) == gid {
+				start := int(binary.BigEndian.Uint16(pair[2:]))
+				end := int(binary.BigEndian.Uint16(pair[6:]))
+				return imageData + start, imageData + end, imageFormat, true
+			}
+		}
+	case 5: // Sparse fixed size glyphs
+		if len(body) < 4+cblcBigMetricsSize+4 {
+			break
+		}
+		imageSize := int(binary.BigEndian.Uint32(body))
+		numGlyphs := int(binary.BigEndian.Uint32(body[4+cblcBigMetricsSize:]))
+		ids := body[8+cblcBigMetricsSize:]
+		if len(ids) < 2*numGlyphs {
+			break
+		}
+		for i := 0; i < numGlyphs; i++ {
+			if binary.BigEndian.Uint16(ids[2*i:]) == gid {
+				return imageData + i*imageSize, imageData + (i+1)*imageSize, imageFormat, true
+			}
+		}
+	}
+	return 0, 0, 0, false
+}
+
+// cbdtImage returns the PNG data of the specified glyph data of the CBDT table with the specified image format
+func cbdtImage(data []byte, format uint16) ([]byte, bool) {
+
+	var skip int
+	switch format {
+	case 17: // Small metrics and PNG data
+		skip = 5
+	case 18: // Big metrics and PNG data
+		skip = cblcBigMetricsSize
+	case 19: // PNG data with metrics in the CBLC table
+	default:
+		return nil, false
+	}
+	if len(data) < skip+4 {
+		return nil, false
+	}
+	length := int(binary.BigEndian.Uint32(data[skip:]))
+	data = data[skip+4:]
+	if length < 0 || length > len(data) {
+		return nil, false
+	}
+	return data[:length], true
+}
@@ -0,0 +1,160 @@
+package window
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// testPNG returns the PNG data of a 2x2 image with the specified color
+func testPNG(c color.RGBA) []byte {
+
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	for i := 0; i < 4; i++ {
+		img.Set(i%2, i/2, c)
+	}
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+// putBE appends the big endian encoding of the specified values to the buffer
+func putBE(buf *bytes.Buffer, values ...any) {
+
+	for _, v := range values {
+		binary.Write(buf, binary.BigEndian, v)
+	}
+}
+
+// testSbixStrike returns a sbix strike with the specified size and glyph records
+func testSbixStrike(ppem uint16, glyphs [][]byte) []byte {
+
+	var buf bytes.Buffer
+	putBE(&buf, ppem, uint16(72))
+	offset := uint32(4 + 4*(len(glyphs)+1))
+	for _, g := range glyphs {
+		putBE(&buf, offset)
+		offset += uint32(len(g))
+	}
+	putBE(&buf, offset)
+	for _, g := range glyphs {
+		buf.Write(g)
+	}
+	return buf.Bytes()
+}
+
+// testSbixGlyph returns a sbix glyph record with the specified graphic type and data
+func testSbixGlyph(tag string, data []byte) []byte {
+
+	var buf bytes.Buffer
+	putBE(&buf, int16(0), int16(0))
+	buf.WriteString(tag)
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+func TestSbixGlyph(t *testing.T) {
+
+	red := testPNG(color.RGBA{255, 0, 0, 255})
+	blue := testPNG(color.RGBA{0, 0, 255, 255})
+	small := testSbixStrike(20, [][]byte{nil, testSbixGlyph("png ", red), nil, nil})
+	large := testSbixStrike(40, [][]byte{
+		nil,
+		testSbixGlyph("png ", blue),
+		testSbixGlyph("dupe", []byte{0, 1}),
+		testSbixGlyph("jpg ", []byte{1, 2, 3}),
+	})
+	var sbix bytes.Buffer
+	putBE(&sbix, uint16(1), uint16(1), uint32(2), uint32(16), uint32(16+len(small)))
+	sbix.Write(small)
+	sbix.Write(large)
+
+	cases := []struct {
+		gid  uint16
+		want []byte
+	}{
+		{0, nil},
+		{1, blue},
+		{2, blue},
+		{3, nil},
+		{4, nil},
+	}
+	for _, c := range cases {
+		data, ok := sbixGlyph(sbix.Bytes(), 4, c.gid)
+		if ok != (c.want != nil) || !bytes.Equal(data, c.want) {
+			t.Errorf("glyph %d: got %d bytes (%v), want %d", c.gid, len(data), ok, len(c.want))
+		}
+	}
+	if _, ok := sbixGlyph(sbix.Bytes()[:30], 4, 1); ok {
+		t.Errorf("truncated table: glyph found")
+	}
+}
+
+func TestCbdtGlyph(t *testing.T) {
+
+	red := testPNG(color.RGBA{255, 0, 0, 255})
+	green := testPNG(color.RGBA{0, 255, 0, 255})
+	blue := testPNG(color.RGBA{0, 0, 255, 255})
+
+	// Glyphs 1 and 2 have image format 17 and glyph 3 has image format 19
+	var g1, g2, g3 bytes.Buffer
+	putBE(&g1, [5]byte{}, uint32(len(red)))
+	g1.Write(red)
+	putBE(&g2, [5]byte{}, uint32(len(green)))
+	g2.Write(green)
+	putBE(&g3, uint32(len(blue)))
+	g3.Write(blue)
+	var cbdt bytes.Buffer
+	putBE(&cbdt, uint16(3), uint16(0))
+	cbdt.Write(g1.Bytes())
+	cbdt.Write(g2.Bytes())
+	cbdt.Write(g3.Bytes())
+
+	// One strike with an index subtable of format 1 for glyphs 1-2 and of format 2 for glyph 3
+	var cblc bytes.Buffer
+	putBE(&cblc, uint16(3), uint16(0), uint32(1))
+	putBE(&cblc, uint32(56), uint32(56), uint32(2), uint32(0), [24]byte{}, uint16(1), uint16(3), uint8(109), uint8(109), uint8(32), uint8(1))
+	putBE(&cblc, uint16(1), uint16(2), uint32(16), uint16(3), uint16(3), uint32(36))
+	putBE(&cblc, uint16(1), uint16(17), uint32(4), uint32(0), uint32(g1.Len()), uint32(g1.Len()+g2.Len()))
+	putBE(&cblc, uint16(2), uint16(19), uint32(4+g1.Len()+g2.Len()), uint32(g3.Len()), [8]byte{})
+
+	cases := []struct {
+		gid  uint16
+		want []byte
+	}{
+		{0, nil},
+		{1, red},
+		{2, green},
+		{3, blue},
+		{4, nil},
+	}
+	for _, c := range cases {
+		data, ok := cbdtGlyph(cblc.Bytes(), cbdt.Bytes(), c.gid)
+		if ok != (c.want != nil) || !bytes.Equal(data, c.want) {
+			t.Errorf("glyph %d: got %d bytes (%v), want %d", c.gid, len(data), ok, len(c.want))
+		}
+	}
+	if _, ok := cbdtGlyph(cblc.Bytes(), cbdt.Bytes()[:20], 3); ok {
+		t.Errorf("truncated CBDT table: glyph found")
+	}
+}
+
+func TestNewFontGlyphSource(t *testing.T) {
+
+	tables, err := sfntTables(goregular.TTF)
+	if err != nil || tables["glyf"] == nil || tables["cmap"] == nil {
+		t.Fatalf("tables of regular font: %v", err)
+	}
+	if _, err := NewFontGlyphSource(goregular.TTF); !errors.Is(err, errNoColorTables) {
+		t.Errorf("font without color tables: got error %v", err)
+	}
+	if _, err := sfntTables(goregular.TTF[:100]); err == nil {
+		t.Errorf("truncated font: no error")
+	}
+}
@@ -0,0 +1,91 @@
+package window
+
+import (
+	"fmt"
+	"image"
+	_ "image/png" // Registers PNG decoder for color glyph images
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// ColorGlyphSource is the interface for sources of color glyph images, such as emoji sets.
+// Color glyphs are stored untinted in the FontAtlas and drawn with their own colors.
+// BitmapGlyphSource supplies images from files and FontGlyphSource the bitmap glyphs of color fonts.
+type ColorGlyphSource interface {
+	// ColorGlyph returns the image of the color glyph for the specified rune, if found.
+	// The image is scaled by the FontAtlas to fit the height of the font line, keeping its aspect ratio.
+	ColorGlyph(r rune) (image.Image, bool)
+}
+
+// BitmapGlyphSource is a ColorGlyphSource which maps runes to bitmap images
+type BitmapGlyphSource struct {
+	images map[rune]image.Image
+}
+
+// NewBitmapGlyphSource creates and returns a new empty BitmapGlyphSource
+func NewBitmapGlyphSource() *BitmapGlyphSource {
+
+	bs := new(BitmapGlyphSource)
+	bs.images = make(map[rune]image.Image)
+	return bs
+}
+
+// Add adds or replaces the image of the glyph for the specified rune
+func (bs *BitmapGlyphSource) Add(r rune, img image.Image) {
+
+	bs.images[r] = img
+}
+
+// AddFS adds the glyph images from the specified directory of a file system.
+// The name of each image file must be the hexadecimal code point of its rune,
+// optionally prefixed by "u" or "emoji_u", as in "1f600.png" or "emoji_u1f600.png".
+// Files with other names, such as emoji sequences, are ignored.
+func (bs *BitmapGlyphSource) AddFS(fsys fs.FS, dir string) error {
+
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name := strings.TrimSuffix(e.Name(), path.Ext(e.Name()))
+		name = strings.TrimPrefix(name, "emoji_")
+		name = strings.TrimPrefix(name, "u")
+		code, err := strconv.ParseUint(name, 16, 32)
+		if err != nil {
+			continue
+		}
+		f, err := fsys.Open(path.Join(dir, e.Name()))
+		if err != nil {
+			return err
+		}
+		img, _, err := image.Decode(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("decoding glyph image %s: %w", e.Name(), err)
+		}
+		bs.images[rune(code)] = img
+	}
+	return nil
+}
+
+// Runes returns the list of runes which have glyph images in this source
+func (bs *BitmapGlyphSource) Runes() []rune {
+
+	runes := make([]rune, 0, len(bs.images))
+	for r := range bs.images {
+		runes = append(runes, r)
+	}
+	return runes
+}
+
+// ColorGlyph satisfies the ColorGlyphSource interface
+func (bs *BitmapGlyphSource) ColorGlyph(r rune) (image.Image, bool) {
+
+	img, ok := bs.images[r]
+	return img, ok
+}
@@ -133,6 +133,25 @@ func (w *Window) AddRectFilled(dl *gb.DrawList, min, max gb.Vec2, col gb.RGBA, r
 	w.PathFillConvex(dl, col)
 }
 
+// AddRectFilledMultiColor adds a filled rectangle to the DrawList from top left 'min' to bottom right 'max'
+// with the specified colors at its corners, which are interpolated across the rectangle.
+func (w *Window) AddRectFilledMultiColor(dl *gb.DrawList, min, max gb.Vec2, colTopLeft, colTopRight, colBottomRight, colBottomLeft gb.RGBA) {
+
+	if ((colTopLeft | colTopRight | colBottomRight | colBottomLeft) & gb.RGBAMaskA) == 0 {
+		return
+	}
+	_, bufIdx, bufVtx := w.NewDrawCmd(dl, 6, 4)
+	bufVtx[0].Pos = min
+	bufVtx[0].Col = colTopLeft
+	bufVtx[1].Pos = gb.Vec2{max.X, min.Y}
+	bufVtx[1].Col = colTopRight
+	bufVtx[2].Pos = max
+	bufVtx[2].Col = colBottomRight
+	bufVtx[3].Pos = gb.Vec2{min.X, max.Y}
+	bufVtx[3].Col = colBottomLeft
+	copy(bufIdx, []uint32{0, 1, 2, 0, 2, 3})
+}
+
 func (w *Window) AddCircle(dl *gb.DrawList, center gb.Vec2, radius float32, col gb.RGBA, numSegments int, thickness float32) {
 
 	if (col&gb.RGBAMaskA) == 0 || radius < 0.5 {
@@ -1,7 +1,6 @@
 package window
 
 import (
-	"unicode"
 	"unicode/utf8"
 
 	"github.com/leonsal/gux/gb"
@@ -51,9 +50,11 @@ func (w *Window) AddGlyph(dl *gb.DrawList, fa *FontAtlas, pos *gb.Vec2, color gb
 	}
 
 	// If glyph not found, use replacement char
-	gi, ok := fa.glyphs[code]
-	if !ok {
-		gi = fa.glyphs[unicode.ReplacementChar]
+	gi := fa.glyph(code)
+
+	// Color glyphs are not tinted by the text color, only its alpha is used.
+	if gi.Colored {
+		color = (gb.RGBAWhite &^ gb.RGBAMaskA) | (color & gb.RGBAMaskA)
 	}
 
 	// Adds  horizontal adjustment for the kerning pair (r0, r1) for the FontAtlas face.
@@ -89,15 +90,21 @@ func (w *Window) AddGlyph(dl *gb.DrawList, fa *FontAtlas, pos *gb.Vec2, color gb
 	pos.X += gi.Advance
 }
 
-// AddText adds command to draw text from a string
+// AddText adds command to draw text from a string.
+// A new line character moves 'pos' down by the font height and back to its initial
+// horizontal position, and no kerning is applied across lines.
+// Runes not found in the font atlas are drawn with the replacement char glyph and advance by its width.
 func (w *Window) AddText(dl *gb.DrawList, fa *FontAtlas, pos *gb.Vec2, color gb.RGBA, align TextVAlign, text string) {
 
+	startX := pos.X
 	prev := rune(-1)
 	for _, code := range text {
 
 		// Process new line
 		if code == 0x0A {
+			pos.X = startX
 			pos.Y += fa.Height()
+			prev = -1
 			continue
 		}
 		w.AddGlyph(dl, fa, pos, color, align, prev, code)
@@ -105,9 +112,12 @@ func (w *Window) AddText(dl *gb.DrawList, fa *FontAtlas, pos *gb.Vec2, color gb.
 	}
 }
 
-// AddTextBytes adds command to draw text from a slice of bytes encoded in UTF8
+// AddTextBytes adds command to draw text from a slice of bytes encoded in UTF8.
+// New lines and missing runes are handled as in AddText().
+// Drawing stops at the first invalid UTF8 sequence.
 func (w *Window) AddTextBytes(dl *gb.DrawList, fa *FontAtlas, pos *gb.Vec2, color gb.RGBA, align TextVAlign, text []byte) {
 
+	startX := pos.X
 	prev := rune(-1)
 	for len(text) > 0 {
 		// Decodes next rune from the byte slice
@@ -117,7 +127,10 @@ func (w *Window) AddTextBytes(dl *gb.DrawList, fa *FontAtlas, pos *gb.Vec2, colo
 		}
 		// Process new line
 		if code == 0x0A {
+			pos.X = startX
 			pos.Y += fa.Height()
+			prev = -1
+			text = text[size:]
 			continue
 		}
 		// Adds command to draw glyph
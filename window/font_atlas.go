@@ -2,6 +2,7 @@ package window
 
 import (
 	"bufio"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"image"
@@ -15,6 +16,8 @@ import (
 	"unsafe"
 
 	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
+	xdraw "golang.org/x/image/draw"
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/opentype"
 	"golang.org/x/image/font/sfnt"
@@ -25,17 +28,21 @@ type GlyphInfo struct {
 	Advance float32    // Amount to add to glyph origin to draw next Glyph
 	Bounds  gb.Rect    // Glyph bounds relative to its origin point at the baseline
 	UV      [4]gb.Vec2 // UV coordinates for glyph quad vertices
+	Colored bool       // Glyph image has its own colors and must not be tinted
 }
 
 // FontAtlas represents an image containing characters and the information about their location in the image
 type FontAtlas struct {
-	face    font.Face          // The font face used to generate the atlas
-	glyphs  map[rune]GlyphInfo // Maps rune code to correspondent Glyph info
-	image   *image.RGBA        // Font atlas generated image
-	ascent  float32            // Distance from the top of a line to its baseline
-	descent float32            // Distance from the bottom of a line to its baseline
-	height  float32            // Total line height
-	texID   gb.TextureID       // Texture ID (valid only after texture was created)
+	face    font.Face            // The font face used to generate the atlas
+	glyphs  map[rune]GlyphInfo   // Maps rune code to correspondent Glyph info
+	image   *image.RGBA          // Font atlas generated image
+	ascent  float32              // Distance from the top of a line to its baseline
+	descent float32              // Distance from the bottom of a line to its baseline
+	height  float32              // Total line height
+	scale   float32              // Ratio between the rasterization resolution and the logical resolution
+	hash    [32]byte             // SHA-256 hash of the font data used to build the atlas
+	opts    opentype.FaceOptions // Face options used to build the atlas
+	texID   gb.TextureID         // Texture ID (valid only after texture was created)
 }
 
 func NewFontAtlasFromFile(w *Window, filepath string, opts *opentype.FaceOptions, runeSets ...[]rune) (*FontAtlas, error) {
@@ -59,35 +66,84 @@ func NewFontAtlasFromReader(w *Window, r io.Reader, opts *opentype.FaceOptions,
 
 func NewFontAtlas(w *Window, fontData []byte, opts *opentype.FaceOptions, runeSets ...[]rune) (*FontAtlas, error) {
 
-	// Parses font data and creates the font face
-	fnt, err := opentype.Parse(fontData)
-	if err != nil {
-		return nil, nil
-	}
-	face, err := opentype.NewFace(fnt, opts)
+	return NewFontAtlasColor(w, fontData, opts, nil, runeSets...)
+}
+
+// NewFontAtlasColor creates and returns a FontAtlas which also contains the color glyphs
+// from the specified ColorGlyphSource for the runes in the rune sets.
+// Color glyphs have precedence over the glyphs from the font and are stored untinted in the atlas.
+// The ColorGlyphSource can be nil.
+func NewFontAtlasColor(w *Window, fontData []byte, opts *opentype.FaceOptions, colors ColorGlyphSource, runeSets ...[]rune) (*FontAtlas, error) {
+
+	return newFontAtlas(w, fontData, opts, colors, 1, runeSets...)
+}
+
+// newFontAtlas creates and returns a FontAtlas with its glyphs rasterized at the specified scale
+// relative to the resolution specified by the face options.
+// All the metrics of the FontAtlas are kept in the unscaled (logical) resolution.
+func newFontAtlas(w *Window, fontData []byte, opts *opentype.FaceOptions, colors ColorGlyphSource, scale float32, runeSets ...[]rune) (*FontAtlas, error) {
+
+	// Parses font data and creates the font face with the scaled resolution
+	fnt, face, err := newFace(fontData, opts, scale)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 
 	// Builds array of unique runes from all the specified rune sets
 	seen := make(map[rune]bool)
 	runes := []rune{unicode.ReplacementChar}
+	colorImages := make(map[rune]image.Image)
 	for _, set := range runeSets {
 		for _, r := range set {
-			// Checks if there is a Glyph for this rune
+			if seen[r] {
+				continue
+			}
+			// Checks if there is a color glyph or a font Glyph for this rune
+			if colors != nil {
+				if img, ok := colors.ColorGlyph(r); ok {
+					colorImages[r] = img
+					runes = append(runes, r)
+					seen[r] = true
+					continue
+				}
+			}
 			var b sfnt.Buffer
 			x, err := fnt.GlyphIndex(&b, r)
 			if x == 0 || err != nil {
 				continue
 			}
 			// Appends unique rune
-			if !seen[r] {
-				runes = append(runes, r)
-				seen[r] = true
+			runes = append(runes, r)
+			seen[r] = true
+		}
+	}
+
+	// Get the bounds of all glyphs. Color glyphs images are scaled to fit the line
+	// from its ascent to its descent, keeping their aspect ratio.
+	metrics := face.Metrics()
+	glyphBounds := make(map[rune]fixedBounds)
+	for _, r := range runes {
+		if img, ok := colorImages[r]; ok {
+			size := img.Bounds().Size()
+			if size.X <= 0 || size.Y <= 0 {
+				continue
+			}
+			height := metrics.Ascent + metrics.Descent
+			width := height * fixed.Int26_6(size.X) / fixed.Int26_6(size.Y)
+			glyphBounds[r] = fixedBounds{
+				bounds:  fixed.Rectangle26_6{Min: fixed.Point26_6{X: 0, Y: -metrics.Ascent}, Max: fixed.Point26_6{X: width, Y: metrics.Descent}},
+				advance: width,
+				colored: true,
 			}
+			continue
+		}
+		bounds, advance, ok := face.GlyphBounds(r)
+		if !ok {
+			continue
 		}
+		glyphBounds[r] = fixedBounds{bounds: bounds, advance: advance}
 	}
-	fixedMapping, fixedBounds := makeSquareMapping(face, runes, fixed.I(2))
+	fixedMapping, fixedBounds := makeSquareMapping(glyphBounds, metrics, runes, fixed.I(2))
 
 	// Creates font atlas image
 	img := image.NewRGBA(image.Rect(
@@ -99,6 +155,11 @@ func NewFontAtlas(w *Window, fontData []byte, opts *opentype.FaceOptions, runeSe
 
 	// Draw all glyphs to the font atlas image
 	for r, fg := range fixedMapping {
+		if src, ok := colorImages[r]; ok {
+			dr := image.Rect(fg.frame.Min.X.Floor(), fg.frame.Min.Y.Floor(), fg.frame.Max.X.Ceil(), fg.frame.Max.Y.Ceil())
+			xdraw.CatmullRom.Scale(img, dr, src, src.Bounds(), draw.Src, nil)
+			continue
+		}
 		if dr, mask, maskp, _, ok := face.Glyph(fg.dot, r); ok {
 			draw.Draw(img, dr, mask, maskp, draw.Src)
 		}
@@ -117,16 +178,22 @@ func NewFontAtlas(w *Window, fontData []byte, opts *opentype.FaceOptions, runeSe
 	imgWidth := imgMaxX - imgMinX
 	imgHeight := imgMaxY - imgMinY
 
+	// Converts from fixed point in the scaled resolution to float in the logical resolution
+	s2f := func(i fixed.Int26_6) float32 {
+		return i2f(i) / scale
+	}
+
 	// Builds draw information for each Glyph in the atlas
 	glyphs := make(map[rune]GlyphInfo)
 	for r, fg := range fixedMapping {
 
 		// Get Glyph bounds and advance converting from fixed to float
-		bounds, advance, _ := face.GlyphBounds(r)
+		fb := glyphBounds[r]
 		gi := GlyphInfo{}
-		gi.Advance = i2f(advance)
-		gi.Bounds.Min = gb.Vec2{i2f(bounds.Min.X), i2f(bounds.Min.Y)}
-		gi.Bounds.Max = gb.Vec2{i2f(bounds.Max.X), i2f(bounds.Max.Y)}
+		gi.Advance = s2f(fb.advance)
+		gi.Bounds.Min = gb.Vec2{s2f(fb.bounds.Min.X), s2f(fb.bounds.Min.Y)}
+		gi.Bounds.Max = gb.Vec2{s2f(fb.bounds.Max.X), s2f(fb.bounds.Max.Y)}
+		gi.Colored = fb.colored
 
 		// Transform glyphs image coordinates to UV coordinates
 		minX := i2f(fg.frame.Min.X)
@@ -144,13 +211,36 @@ func NewFontAtlas(w *Window, fontData []byte, opts *opentype.FaceOptions, runeSe
 		face:    face,
 		glyphs:  glyphs,
 		image:   img,
-		ascent:  i2f(face.Metrics().Ascent),
-		descent: i2f(face.Metrics().Descent),
-		height:  i2f(face.Metrics().Height),
+		ascent:  s2f(metrics.Ascent),
+		descent: s2f(metrics.Descent),
+		height:  s2f(metrics.Height),
+		scale:   scale,
+		hash:    sha256.Sum256(fontData),
+		opts:    *opts,
 		texID:   texID,
 	}, nil
 }
 
+// newFace parses the font data and creates a font face with the resolution
+// from the specified options multiplied by the specified scale.
+func newFace(fontData []byte, opts *opentype.FaceOptions, scale float32) (*opentype.Font, font.Face, error) {
+
+	fnt, err := opentype.Parse(fontData)
+	if err != nil {
+		return nil, nil, err
+	}
+	sopts := *opts
+	if sopts.DPI == 0 {
+		sopts.DPI = 72
+	}
+	sopts.DPI *= float64(scale)
+	face, err := opentype.NewFace(fnt, &sopts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return fnt, face, nil
+}
+
 // Face returns the font face of the FontAtlas
 func (a *FontAtlas) Face() font.Face {
 
@@ -186,7 +276,14 @@ func (a *FontAtlas) Height() float32 {
 // A positive kern means to move the glyphs further apart.
 func (a *FontAtlas) Kern(r0, r1 rune) float32 {
 
-	return i2f(a.face.Kern(r0, r1))
+	return i2f(a.face.Kern(r0, r1)) / a.scale
+}
+
+// Scale returns the ratio between the resolution used to rasterize the glyphs
+// and the logical resolution of the FontAtlas metrics.
+func (a *FontAtlas) Scale() float32 {
+
+	return a.scale
 }
 
 // ReleaseImage releases the memory allocated to the image created to build the font atlas texture.
@@ -248,22 +345,63 @@ func (a *FontAtlas) PrintInfo() {
 }
 
 // MeasureString returns how far dot would advance by drawing s with f.
+// If the string contains new lines, returns the advance of the longest line.
+// Runes not found in the font atlas advance by the width of the replacement char,
+// as they are drawn by AddText().
 func (a *FontAtlas) MeasureString(s string) float32 {
 
 	var advance float32
+	var maxAdvance float32
 	prevC := rune(-1)
 	for _, c := range s {
+		if c == 0x0A {
+			maxAdvance = util.Max(maxAdvance, advance)
+			advance = 0
+			prevC = -1
+			continue
+		}
 		if prevC >= 0 {
 			advance += a.Kern(prevC, c)
 		}
-		gi, ok := a.glyphs[c]
-		if !ok {
-			continue
+		advance += a.glyph(c).Advance
+		prevC = c
+	}
+	return util.Max(maxAdvance, advance)
+}
+
+// CaretOffsets appends to the specified slice the horizontal offset of each caret position
+// of a single line of runes, from before the first rune to after the last one, and returns the slice.
+// The offsets are consistent with MeasureString() and AddText().
+func (a *FontAtlas) CaretOffsets(runes []rune, offsets []float32) []float32 {
+
+	var advance float32
+	prevC := rune(-1)
+	for _, c := range runes {
+		if prevC >= 0 {
+			advance += a.Kern(prevC, c)
 		}
-		advance += gi.Advance
+		offsets = append(offsets, advance)
+		advance += a.glyph(c).Advance
 		prevC = c
 	}
-	return advance
+	return append(offsets, advance)
+}
+
+// glyph returns the GlyphInfo for the specified rune or for the
+// replacement char if the rune is not found in the FontAtlas.
+func (a *FontAtlas) glyph(r rune) GlyphInfo {
+
+	gi, ok := a.glyphs[r]
+	if !ok {
+		gi = a.glyphs[unicode.ReplacementChar]
+	}
+	return gi
+}
+
+type fixedBounds struct {
+	bounds  fixed.Rectangle26_6
+	advance fixed.Int26_6
+	colored bool
 }
 
 type fixedGlyph struct {
@@ -274,20 +412,20 @@ type fixedGlyph struct {
 
 // makeSquareMapping finds an optimal glyph arrangement of the given runes, so that their common
 // bounding box is as square as possible.
-func makeSquareMapping(face font.Face, runes []rune, padding fixed.Int26_6) (map[rune]fixedGlyph, fixed.Rectangle26_6) {
+func makeSquareMapping(glyphBounds map[rune]fixedBounds, metrics font.Metrics, runes []rune, padding fixed.Int26_6) (map[rune]fixedGlyph, fixed.Rectangle26_6) {
 
 	width := sort.Search(int(fixed.I(1024*1024)), func(i int) bool {
 		width := fixed.Int26_6(i)
-		_, bounds := makeMapping(face, runes, padding, width)
+		_, bounds := makeMapping(glyphBounds, metrics, runes, padding, width)
 		return bounds.Max.X-bounds.Min.X >= bounds.Max.Y-bounds.Min.Y
 	})
-	return makeMapping(face, runes, padding, fixed.Int26_6(width))
+	return makeMapping(glyphBounds, metrics, runes, padding, fixed.Int26_6(width))
 }
 
 // makeMapping arranges glyphs of the given runes into rows in such a way, that no glyph is located
 // fully to the right of the specified width. Specifically, it places glyphs in a row one by one and
 // once it reaches the specified width, it starts a new row.
-func makeMapping(face font.Face, runes []rune, padding, width fixed.Int26_6) (map[rune]fixedGlyph, fixed.Rectangle26_6) {
+func makeMapping(glyphBounds map[rune]fixedBounds, metrics font.Metrics, runes []rune, padding, width fixed.Int26_6) (map[rune]fixedGlyph, fixed.Rectangle26_6) {
 
 	mapping := make(map[rune]fixedGlyph)
 	bounds := fixed.Rectangle26_6{}
@@ -296,10 +434,11 @@ func makeMapping(face font.Face, runes []rune, padding, width fixed.Int26_6) (ma
 
 	for _, r := range runes {
 
-		b, advance, ok := face.GlyphBounds(r)
+		fb, ok := glyphBounds[r]
 		if !ok {
 			continue
 		}
+		b := fb.bounds
 
 		// this is important for drawing, artifacts arise otherwise
 		frame := fixed.Rectangle26_6{
@@ -313,7 +452,7 @@ func makeMapping(face font.Face, runes []rune, padding, width fixed.Int26_6) (ma
 		mapping[r] = fixedGlyph{
 			dot:     dot,
 			frame:   frame,
-			advance: advance,
+			advance: fb.advance,
 		}
 		bounds = bounds.Union(frame)
 
@@ -326,7 +465,7 @@ func makeMapping(face font.Face, runes []rune, padding, width fixed.Int26_6) (ma
 		// width exceeded, new row
 		if frame.Max.X >= width {
 			dot.X = 0
-			dot.Y += face.Metrics().Ascent + face.Metrics().Descent
+			dot.Y += metrics.Ascent + metrics.Descent
 
 			// padding + align to integer
 			dot.Y += padding
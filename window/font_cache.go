@@ -0,0 +1,282 @@
+package window
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	"unsafe"
+
+	"github.com/leonsal/gux/gb"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+)
+
+// Font atlas file format identification
+const (
+	fontAtlasMagic   = "GUXFA"
+	fontAtlasVersion = 2
+)
+
+// fontAtlasHeader is the fixed size header of a font atlas file, stored compressed after the magic and version.
+type fontAtlasHeader struct {
+	Hash       [32]byte // SHA-256 hash of the font data
+	Size       float64  // Face options used to build the atlas
+	DPI        float64
+	Hinting    int32
+	Ascent     float32
+	Descent    float32
+	Height     float32
+	Scale      float32
+	ImgMinX    int32
+	ImgMinY    int32
+	ImgMaxX    int32
+	ImgMaxY    int32
+	GlyphCount uint32
+}
+
+// fontAtlasGlyph is the stored information of a single glyph of a font atlas file
+type fontAtlasGlyph struct {
+	Code    int32
+	Advance float32
+	Bounds  [4]float32
+	UV      [8]float32
+	Colored uint8
+}
+
+// Save writes the FontAtlas image, glyph table, metrics, face options and font data hash to the specified writer
+// in a compact binary format which can be loaded with LoadFontAtlas().
+// The FontAtlas image must not have been released.
+func (a *FontAtlas) Save(w io.Writer) error {
+
+	if a.image == nil {
+		return errors.New("FontAtlas image was released")
+	}
+
+	// Writes uncompressed file identification
+	_, err := w.Write([]byte(fontAtlasMagic))
+	if err != nil {
+		return err
+	}
+	err = binary.Write(w, binary.LittleEndian, uint16(fontAtlasVersion))
+	if err != nil {
+		return err
+	}
+
+	// Writes compressed header, glyphs and image pixels
+	zw := zlib.NewWriter(w)
+	header := fontAtlasHeader{
+		Hash:       a.hash,
+		Size:       a.opts.Size,
+		DPI:        a.opts.DPI,
+		Hinting:    int32(a.opts.Hinting),
+		Ascent:     a.ascent,
+		Descent:    a.descent,
+		Height:     a.height,
+		Scale:      a.scale,
+		ImgMinX:    int32(a.image.Rect.Min.X),
+		ImgMinY:    int32(a.image.Rect.Min.Y),
+		ImgMaxX:    int32(a.image.Rect.Max.X),
+		ImgMaxY:    int32(a.image.Rect.Max.Y),
+		GlyphCount: uint32(len(a.glyphs)),
+	}
+	err = binary.Write(zw, binary.LittleEndian, &header)
+	if err != nil {
+		return err
+	}
+	glyphs := make([]fontAtlasGlyph, 0, len(a.glyphs))
+	for code, gi := range a.glyphs {
+		fg := fontAtlasGlyph{
+			Code:    code,
+			Advance: gi.Advance,
+			Bounds:  [4]float32{gi.Bounds.Min.X, gi.Bounds.Min.Y, gi.Bounds.Max.X, gi.Bounds.Max.Y},
+		}
+		for i := 0; i < len(gi.UV); i++ {
+			fg.UV[i*2] = gi.UV[i].X
+			fg.UV[i*2+1] = gi.UV[i].Y
+		}
+		if gi.Colored {
+			fg.Colored = 1
+		}
+		glyphs = append(glyphs, fg)
+	}
+	err = binary.Write(zw, binary.LittleEndian, glyphs)
+	if err != nil {
+		return err
+	}
+	_, err = zw.Write(a.image.Pix)
+	if err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// SaveFile saves the FontAtlas to the specified file using Save().
+// The file is replaced atomically, so a concurrent LoadFontAtlasFile() never reads a partial file.
+func (a *FontAtlas) SaveFile(filename string) error {
+
+	// Writes to a temporary file in the same directory which is then renamed,
+	// so readers never see a partially written file.
+	f, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	b := bufio.NewWriter(f)
+	err = a.Save(b)
+	if err == nil {
+		err = b.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), filename)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
+
+// LoadFontAtlas loads a FontAtlas previously saved with Save() from the specified reader.
+// The font data and face options must be the same used to build the saved atlas, as the font face
+// is still necessary for kerning. Returns an error if the font data hash or the face options do not match the saved ones.
+func LoadFontAtlas(w *Window, r io.Reader, fontData []byte, opts *opentype.FaceOptions) (*FontAtlas, error) {
+
+	a, err := readFontAtlas(r, fontData, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Creates Font Atlas texture
+	rect := a.image.Rect
+	a.texID = w.CreateTexture(rect.Dx(), rect.Dy(), (*gb.RGBA)(unsafe.Pointer(&a.image.Pix[0])))
+	return a, nil
+}
+
+// readFontAtlas reads a FontAtlas saved with Save() from the specified reader without creating its texture
+func readFontAtlas(r io.Reader, fontData []byte, opts *opentype.FaceOptions) (*FontAtlas, error) {
+
+	// Checks file identification
+	magic := make([]byte, len(fontAtlasMagic))
+	_, err := io.ReadFull(r, magic)
+	if err != nil {
+		return nil, err
+	}
+	if string(magic) != fontAtlasMagic {
+		return nil, errors.New("invalid font atlas file")
+	}
+	var version uint16
+	err = binary.Read(r, binary.LittleEndian, &version)
+	if err != nil {
+		return nil, err
+	}
+	if version != fontAtlasVersion {
+		return nil, fmt.Errorf("unsupported font atlas file version:%d", version)
+	}
+
+	// Reads and checks the header
+	zr, err := zlib.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	var header fontAtlasHeader
+	err = binary.Read(zr, binary.LittleEndian, &header)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(fontData)
+	if !bytes.Equal(hash[:], header.Hash[:]) {
+		return nil, errors.New("font atlas file was built from different font data")
+	}
+	if header.Size != opts.Size || header.DPI != opts.DPI || font.Hinting(header.Hinting) != opts.Hinting {
+		return nil, errors.New("font atlas file was built with different face options")
+	}
+	rect := image.Rect(int(header.ImgMinX), int(header.ImgMinY), int(header.ImgMaxX), int(header.ImgMaxY))
+	if rect.Empty() || rect.Dx() > 1<<16 || rect.Dy() > 1<<16 || header.GlyphCount > 1<<20 {
+		return nil, errors.New("invalid font atlas file header")
+	}
+
+	// Reads glyphs table
+	fglyphs := make([]fontAtlasGlyph, header.GlyphCount)
+	err = binary.Read(zr, binary.LittleEndian, fglyphs)
+	if err != nil {
+		return nil, err
+	}
+	glyphs := make(map[rune]GlyphInfo, len(fglyphs))
+	for _, fg := range fglyphs {
+		gi := GlyphInfo{
+			Advance: fg.Advance,
+			Bounds:  gb.Rect{Min: gb.Vec2{fg.Bounds[0], fg.Bounds[1]}, Max: gb.Vec2{fg.Bounds[2], fg.Bounds[3]}},
+			Colored: fg.Colored != 0,
+		}
+		for i := 0; i < len(gi.UV); i++ {
+			gi.UV[i] = gb.Vec2{fg.UV[i*2], fg.UV[i*2+1]}
+		}
+		glyphs[fg.Code] = gi
+	}
+
+	// Reads image pixels
+	img := image.NewRGBA(rect)
+	_, err = io.ReadFull(zr, img.Pix)
+	if err != nil {
+		return nil, err
+	}
+
+	// Creates the font face for kerning
+	_, face, err := newFace(fontData, opts, header.Scale)
+	if err != nil {
+		return nil, err
+	}
+	return &FontAtlas{
+		face:    face,
+		glyphs:  glyphs,
+		image:   img,
+		ascent:  header.Ascent,
+		descent: header.Descent,
+		height:  header.Height,
+		scale:   header.Scale,
+		hash:    header.Hash,
+		opts:    *opts,
+	}, nil
+}
+
+// LoadFontAtlasFile loads a FontAtlas from the specified file using LoadFontAtlas()
+func LoadFontAtlasFile(w *Window, filename string, fontData []byte, opts *opentype.FaceOptions) (*FontAtlas, error) {
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadFontAtlas(w, bufio.NewReader(f), fontData, opts)
+}
+
+// fontAtlasCacheFile returns the name of the file in the specified cache directory for the FontAtlas
+// built from the specified font data, face options, rasterization scale and rune sets.
+func fontAtlasCacheFile(dir string, fontData []byte, opts *opentype.FaceOptions, scale float32, runeSets [][]rune) string {
+
+	h := sha256.New()
+	fontHash := sha256.Sum256(fontData)
+	h.Write(fontHash[:])
+	binary.Write(h, binary.LittleEndian, opts.Size)
+	binary.Write(h, binary.LittleEndian, opts.DPI)
+	binary.Write(h, binary.LittleEndian, int32(opts.Hinting))
+	binary.Write(h, binary.LittleEndian, scale)
+	for _, set := range runeSets {
+		binary.Write(h, binary.LittleEndian, int32(len(set)))
+		binary.Write(h, binary.LittleEndian, set)
+	}
+	key := h.Sum(nil)
+	return filepath.Join(dir, hex.EncodeToString(key[:16])+".gfa")
+}
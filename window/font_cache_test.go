@@ -0,0 +1,205 @@
+package window
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"image"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/leonsal/gux/gb"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+// testFontAtlas returns a FontAtlas with a small image and glyph table built from the specified font data,
+// which does not need a window
+func testFontAtlas(fontData []byte, opts *opentype.FaceOptions) *FontAtlas {
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 3))
+	for i := range img.Pix {
+		img.Pix[i] = byte(i * 7)
+	}
+	return &FontAtlas{
+		glyphs: map[rune]GlyphInfo{
+			'a': {
+				Advance: 7.5,
+				Bounds:  gb.Rect{Min: gb.Vec2{0, -9}, Max: gb.Vec2{7, 1}},
+				UV:      [4]gb.Vec2{{0, 0}, {0.25, 0}, {0.25, 0.5}, {0, 0.5}},
+			},
+			'😀': {
+				Advance: 12,
+				Bounds:  gb.Rect{Min: gb.Vec2{0, -10}, Max: gb.Vec2{12, 3}},
+				UV:      [4]gb.Vec2{{0.5, 0}, {1, 0}, {1, 1}, {0.5, 1}},
+				Colored: true,
+			},
+		},
+		image:   img,
+		ascent:  10,
+		descent: 3,
+		height:  14,
+		scale:   2,
+		hash:    sha256.Sum256(fontData),
+		opts:    *opts,
+	}
+}
+
+func TestFontAtlasSaveLoad(t *testing.T) {
+
+	opts := opentype.FaceOptions{Size: 14, DPI: 72, Hinting: font.HintingNone}
+	saved := testFontAtlas(goregular.TTF, &opts)
+	var buf bytes.Buffer
+	if err := saved.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		fontData []byte
+		opts     opentype.FaceOptions
+		ok       bool
+	}{
+		{"same font and options", goregular.TTF, opts, true},
+		{"different font", gobold.TTF, opts, false},
+		{"different size", goregular.TTF, opentype.FaceOptions{Size: 15, DPI: 72, Hinting: font.HintingNone}, false},
+		{"different DPI", goregular.TTF, opentype.FaceOptions{Size: 14, DPI: 96, Hinting: font.HintingNone}, false},
+		{"different hinting", goregular.TTF, opentype.FaceOptions{Size: 14, DPI: 72, Hinting: font.HintingFull}, false},
+	}
+	for _, c := range cases {
+		loaded, err := readFontAtlas(bytes.NewReader(buf.Bytes()), c.fontData, &c.opts)
+		if !c.ok {
+			if err == nil {
+				t.Errorf("%s: loaded without error", c.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(loaded.glyphs, saved.glyphs) {
+			t.Errorf("%s: glyphs %v, want %v", c.name, loaded.glyphs, saved.glyphs)
+		}
+		if !reflect.DeepEqual(loaded.image, saved.image) {
+			t.Errorf("%s: different image", c.name)
+		}
+		if loaded.ascent != saved.ascent || loaded.descent != saved.descent || loaded.height != saved.height ||
+			loaded.scale != saved.scale || loaded.hash != saved.hash || loaded.opts != saved.opts {
+			t.Errorf("%s: different metrics", c.name)
+		}
+		if loaded.face == nil {
+			t.Errorf("%s: font face not created", c.name)
+		}
+	}
+}
+
+func TestFontAtlasLoadInvalid(t *testing.T) {
+
+	opts := opentype.FaceOptions{Size: 14, DPI: 72, Hinting: font.HintingNone}
+	var buf bytes.Buffer
+	if err := testFontAtlas(goregular.TTF, &opts).Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	version := append([]byte(nil), data...)
+	version[len(fontAtlasMagic)]++
+
+	cases := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"bad magic", append([]byte("XXXXX"), data[len(fontAtlasMagic):]...)},
+		{"bad version", version},
+		{"truncated", data[:len(data)/2]},
+	}
+	for _, c := range cases {
+		if _, err := readFontAtlas(bytes.NewReader(c.data), goregular.TTF, &opts); err == nil {
+			t.Errorf("%s: loaded without error", c.name)
+		}
+	}
+
+	// The image must not have been released
+	a := testFontAtlas(goregular.TTF, &opts)
+	a.image = nil
+	if err := a.Save(&buf); err == nil {
+		t.Errorf("saved atlas without image")
+	}
+}
+
+func TestFontAtlasCacheFile(t *testing.T) {
+
+	opts := opentype.FaceOptions{Size: 14, DPI: 72, Hinting: font.HintingNone}
+	runes := [][]rune{{'a', 'b'}}
+	base := fontAtlasCacheFile("cache", goregular.TTF, &opts, 1, runes)
+	if again := fontAtlasCacheFile("cache", goregular.TTF, &opts, 1, runes); again != base {
+		t.Errorf("same parameters: got %q and %q", base, again)
+	}
+
+	larger := opts
+	larger.Size++
+	cases := []struct {
+		name     string
+		fontData []byte
+		opts     *opentype.FaceOptions
+		scale    float32
+		runeSets [][]rune
+	}{
+		{"font", gobold.TTF, &opts, 1, runes},
+		{"options", goregular.TTF, &larger, 1, runes},
+		{"scale", goregular.TTF, &opts, 2, runes},
+		{"runes", goregular.TTF, &opts, 1, [][]rune{{'a', 'c'}}},
+		{"rune sets", goregular.TTF, &opts, 1, [][]rune{{'a'}, {'b'}}},
+	}
+	for _, c := range cases {
+		if name := fontAtlasCacheFile("cache", c.fontData, c.opts, c.scale, c.runeSets); name == base {
+			t.Errorf("different %s: same file name %q", c.name, name)
+		}
+	}
+}
+
+func TestFontAtlasSaveFile(t *testing.T) {
+
+	opts := opentype.FaceOptions{Size: 14, DPI: 72, Hinting: font.HintingNone}
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "atlas.gfa")
+	a := testFontAtlas(goregular.TTF, &opts)
+
+	// Saving twice replaces the file and leaves no temporary files
+	for i := 0; i < 2; i++ {
+		if err := a.SaveFile(filename); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "atlas.gfa" {
+		t.Errorf("directory entries %v, want only atlas.gfa", entries)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readFontAtlas(bytes.NewReader(data), goregular.TTF, &opts); err != nil {
+		t.Error(err)
+	}
+
+	// A failed save keeps the previous file and removes the temporary file
+	a.image = nil
+	if err := a.SaveFile(filename); err == nil {
+		t.Errorf("saved atlas without image")
+	}
+	entries, _ = os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory entries %v after failed save, want only atlas.gfa", entries)
+	}
+	if after, _ := os.ReadFile(filename); !bytes.Equal(after, data) {
+		t.Errorf("failed save changed the saved file")
+	}
+}
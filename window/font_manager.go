@@ -2,6 +2,7 @@ package window
 
 import (
 	"fmt"
+	"os"
 
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/opentype"
@@ -39,6 +40,9 @@ type FontManager struct {
 	smaller    int                         // Number of font sizes smaller than the normal size
 	larger     int                         // Number of font sizes greater than the normal size
 	styles     map[FontStyleType]*fontInfo // Maps font style to font info
+	colors     ColorGlyphSource            // Optional source of color glyphs for all styles
+	scale      float32                     // Rasterization scale of the current font atlases
+	cacheDir   string                      // Optional directory to cache built font atlases
 }
 
 // NewFontManager creates and returns a new empty FontManager.
@@ -77,35 +81,134 @@ func (fm *FontManager) AddStyle(ff FontStyleType, fontData []byte) error {
 	return nil
 }
 
+// SetColorGlyphSource sets the source of color glyphs, such as a FontGlyphSource with an emoji font,
+// used by all the font styles.
+// The runes of the color glyphs must be included in the FontManager rune sets.
+// It must be called before BuildFonts().
+func (fm *FontManager) SetColorGlyphSource(src ColorGlyphSource) {
+
+	fm.colors = src
+}
+
+// SetCacheDir sets the directory used by BuildFonts() to cache the built font atlases.
+// When a cached atlas exists for the same font data, options, scale and rune sets,
+// it is loaded from the cache instead of being built.
+// Font atlases with color glyphs are not cached.
+// An empty directory name disables the cache, which is the default.
+func (fm *FontManager) SetCacheDir(dir string) {
+
+	fm.cacheDir = dir
+}
+
 // BuildFonts builds the font atlases for each family and each size in this FontManager.
+// The glyphs are rasterized using the current window framebuffer scale, so text is sharp
+// on HiDPI displays, while all the font metrics are kept in window coordinates.
 func (fm *FontManager) BuildFonts(w *Window) error {
 
-	// Scale the normal font size from the window.
-
+	fm.scale = w.Scale()
 	for _, fi := range fm.styles {
 
 		// If already built, continue with next family
 		if len(fi.faces) > 0 {
 			continue
 		}
+		faces, err := fm.buildFaces(w, fi, fm.scale)
+		if err != nil {
+			return err
+		}
+		fi.faces = faces
+	}
+	return nil
+}
 
-		// Creates font atlas for each relative size
-		for relSize := -fm.smaller; relSize <= fm.larger; relSize++ {
-			opts := opentype.FaceOptions{
-				Size:    fm.normalSize + float64(relSize),
-				DPI:     72,
-				Hinting: font.HintingNone,
+// RebuildFonts rebuilds all the font atlases of this FontManager using the current window framebuffer scale.
+// The existing FontAtlas objects are updated in place, so references to them remain valid.
+// The atlases of all the styles are built before any is replaced, so if an error occurs
+// the previous font atlases and scale are kept unchanged.
+// It is called automatically by the window when its framebuffer scale changes.
+func (fm *FontManager) RebuildFonts(w *Window) error {
+
+	scale := w.Scale()
+	built := make(map[*fontInfo][]*FontAtlas, len(fm.styles))
+	for _, fi := range fm.styles {
+		faces, err := fm.buildFaces(w, fi, scale)
+		if err != nil {
+			for _, faces := range built {
+				for _, fa := range faces {
+					fa.Destroy(w)
+				}
 			}
-			fa, err := NewFontAtlas(w, fi.fontData, &opts, fm.runeSets...)
-			if err != nil {
-				return err
+			return err
+		}
+		built[fi] = faces
+	}
+
+	// Replaces the previous atlases only after all were built successfully
+	for fi, faces := range built {
+		for i, fa := range faces {
+			if i >= len(fi.faces) {
+				fi.faces = append(fi.faces, fa)
+				continue
 			}
-			fi.faces = append(fi.faces, fa)
+			fi.faces[i].Destroy(w)
+			*fi.faces[i] = *fa
 		}
 	}
+	fm.scale = scale
 	return nil
 }
 
+// Scale returns the framebuffer scale used to rasterize the current font atlases
+func (fm *FontManager) Scale() float32 {
+
+	return fm.scale
+}
+
+// buildFaces builds and returns the font atlases for all the relative sizes of the specified font
+// rasterized with the specified framebuffer scale
+func (fm *FontManager) buildFaces(w *Window, fi *fontInfo, scale float32) ([]*FontAtlas, error) {
+
+	faces := []*FontAtlas{}
+	for relSize := -fm.smaller; relSize <= fm.larger; relSize++ {
+		opts := opentype.FaceOptions{
+			Size:    fm.normalSize + float64(relSize),
+			DPI:     72,
+			Hinting: font.HintingNone,
+		}
+		fa, err := fm.buildFace(w, fi, &opts, scale)
+		if err != nil {
+			for _, fa := range faces {
+				fa.Destroy(w)
+			}
+			return nil, err
+		}
+		faces = append(faces, fa)
+	}
+	return faces, nil
+}
+
+// buildFace returns the font atlas for the specified font and options, loading it from the
+// cache directory if possible. Errors writing to the cache are ignored.
+func (fm *FontManager) buildFace(w *Window, fi *fontInfo, opts *opentype.FaceOptions, scale float32) (*FontAtlas, error) {
+
+	if fm.cacheDir == "" || fm.colors != nil {
+		return newFontAtlas(w, fi.fontData, opts, fm.colors, scale, fm.runeSets...)
+	}
+	filename := fontAtlasCacheFile(fm.cacheDir, fi.fontData, opts, scale, fm.runeSets)
+	fa, err := LoadFontAtlasFile(w, filename, fi.fontData, opts)
+	if err == nil {
+		return fa, nil
+	}
+	fa, err = newFontAtlas(w, fi.fontData, opts, nil, scale, fm.runeSets...)
+	if err != nil {
+		return nil, err
+	}
+	if os.MkdirAll(fm.cacheDir, 0755) == nil {
+		fa.SaveFile(filename)
+	}
+	return fa, nil
+}
+
 // DestroyFonts destroys all font atlases created previously for this FontManager.
 // It normally should be called before the window is closed.
 func (fm *FontManager) DestroyFonts(w *Window) {
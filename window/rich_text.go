@@ -0,0 +1,251 @@
+package window
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/leonsal/gux/gb"
+)
+
+// TextRun is a sequence of text drawn with the same style
+type TextRun struct {
+	Text       string        // UTF8 encoded text of this run
+	Style      FontStyleType // Font style
+	RelSize    int           // Font relative size (0 for normal size)
+	Color      gb.RGBA       // Text color
+	Background gb.RGBA       // Background highlight color (transparent for no background)
+	Underline  bool          // Draws a line under the text
+	Strike     bool          // Draws a line through the text
+}
+
+// RichTextSpan is a part of a single TextRun inside a RichTextLine
+type RichTextSpan struct {
+	Run   int     // Index of the run in the layout runs
+	Start int     // Start byte offset in the run text
+	End   int     // End byte offset in the run text (exclusive)
+	X     float32 // Horizontal offset of the span origin relative to the line start
+	Width float32 // Total advance of the span glyphs
+}
+
+// RichTextLine contains the spans of a single line of a RichTextLayout
+type RichTextLine struct {
+	Top     float32        // Vertical offset of the line top relative to the layout origin
+	Ascent  float32        // Maximum ascent of all the spans in the line
+	Descent float32        // Maximum descent of all the spans in the line
+	Height  float32        // Total line height
+	Width   float32        // Line width not including trailing spaces
+	Spans   []RichTextSpan // List of spans in the line
+}
+
+// Baseline returns the vertical offset of the line baseline relative to the layout origin
+func (l *RichTextLine) Baseline() float32 {
+
+	return l.Top + l.Ascent
+}
+
+// RichTextLayout contains the result of laying out a paragraph of text runs with mixed styles
+type RichTextLayout struct {
+	Runs  []TextRun      // Runs used to build the layout
+	Lines []RichTextLine // Laid out lines
+	Size  gb.Vec2        // Total size of the laid out text
+	fonts []*FontAtlas   // Font atlas for each run
+}
+
+// richGlyph contains layout information for a single rune of a rich text
+type richGlyph struct {
+	run     int     // Index of the run
+	offset  int     // Byte offset in the run text
+	size    int     // Size of the rune in bytes
+	code    rune    // Rune code
+	kern    float32 // Kerning adjustment relative to the previous rune in the same run
+	advance float32 // Glyph advance
+}
+
+// NewRichTextLayout lays out the specified text runs using fonts from the specified FontManager
+// and returns the resulting layout.
+// If 'maxWidth' is greater than zero, lines are wrapped at spaces to fit this width when possible.
+// All the lines share the same baseline for runs of different sizes, with the line height
+// determined by the largest font in the line.
+func NewRichTextLayout(fm *FontManager, runs []TextRun, maxWidth float32) *RichTextLayout {
+
+	l := &RichTextLayout{Runs: runs}
+	l.fonts = make([]*FontAtlas, len(runs))
+	for i := 0; i < len(runs); i++ {
+		l.fonts[i] = fm.Font(runs[i].Style, runs[i].RelSize)
+	}
+
+	// Builds the list of glyphs for all the runs
+	glyphs := []richGlyph{}
+	for ri := 0; ri < len(runs); ri++ {
+		fa := l.fonts[ri]
+		prev := rune(-1)
+		text := runs[ri].Text
+		for offset := 0; offset < len(text); {
+			code, size := utf8.DecodeRuneInString(text[offset:])
+			g := richGlyph{run: ri, offset: offset, size: size, code: code}
+			if code != '\n' {
+				if prev >= 0 {
+					g.kern = fa.Kern(prev, code)
+				}
+				g.advance = fa.glyph(code).Advance
+				prev = code
+			} else {
+				prev = -1
+			}
+			glyphs = append(glyphs, g)
+			offset += size
+		}
+	}
+
+	// Breaks the glyphs into lines
+	start := 0
+	for start <= len(glyphs) {
+		end, next := l.breakLine(glyphs, start, maxWidth)
+		l.addLine(glyphs, start, end)
+		if next >= len(glyphs) {
+			// Adds empty line if the text ends with a new line
+			if end < len(glyphs) && glyphs[end].code == '\n' {
+				l.addLine(glyphs, len(glyphs), len(glyphs))
+			}
+			break
+		}
+		start = next
+	}
+	return l
+}
+
+// breakLine returns the end index of the line which starts at the specified glyph index
+// and the start index of the next line.
+func (l *RichTextLayout) breakLine(glyphs []richGlyph, start int, maxWidth float32) (int, int) {
+
+	var x float32
+	lastBreak := -1
+	for i := start; i < len(glyphs); i++ {
+		g := glyphs[i]
+		if g.code == '\n' {
+			return i, i + 1
+		}
+		if i > start {
+			x += g.kern
+		}
+		x += g.advance
+		if unicode.IsSpace(g.code) {
+			lastBreak = i
+			continue
+		}
+		if maxWidth > 0 && x > maxWidth && lastBreak >= 0 {
+			return lastBreak, lastBreak + 1
+		}
+	}
+	return len(glyphs), len(glyphs)
+}
+
+// addLine appends a new line with the glyphs from 'start' to 'end' (exclusive) to the layout
+func (l *RichTextLayout) addLine(glyphs []richGlyph, start, end int) {
+
+	line := RichTextLine{}
+	if len(l.Lines) > 0 {
+		last := &l.Lines[len(l.Lines)-1]
+		line.Top = last.Top + last.Height
+	}
+
+	// Builds spans grouping consecutive glyphs from the same run
+	var x float32
+	var width float32
+	for i := start; i < end; i++ {
+		g := glyphs[i]
+		if len(line.Spans) == 0 || line.Spans[len(line.Spans)-1].Run != g.run {
+			line.Spans = append(line.Spans, RichTextSpan{Run: g.run, Start: g.offset, End: g.offset, X: x})
+		} else {
+			x += g.kern
+		}
+		span := &line.Spans[len(line.Spans)-1]
+		x += g.advance
+		span.End = g.offset + g.size
+		span.Width = x - span.X
+		if !unicode.IsSpace(g.code) {
+			width = x
+		}
+	}
+	line.Width = width
+
+	// Calculates the line vertical metrics from the fonts of its runs.
+	// An empty line uses the font of the run which contains the line break.
+	fonts := []*FontAtlas{}
+	for _, span := range line.Spans {
+		fonts = append(fonts, l.fonts[span.Run])
+	}
+	if len(fonts) == 0 {
+		if start < len(glyphs) {
+			fonts = append(fonts, l.fonts[glyphs[start].run])
+		} else if len(glyphs) > 0 {
+			fonts = append(fonts, l.fonts[glyphs[len(glyphs)-1].run])
+		} else if len(l.fonts) > 0 {
+			fonts = append(fonts, l.fonts[0])
+		}
+	}
+	var gap float32
+	for _, fa := range fonts {
+		if fa.ascent > line.Ascent {
+			line.Ascent = fa.ascent
+		}
+		if fa.descent > line.Descent {
+			line.Descent = fa.descent
+		}
+		if g := fa.height - fa.ascent - fa.descent; g > gap {
+			gap = g
+		}
+	}
+	line.Height = line.Ascent + line.Descent + gap
+
+	l.Lines = append(l.Lines, line)
+	if line.Width > l.Size.X {
+		l.Size.X = line.Width
+	}
+	l.Size.Y = line.Top + line.Height
+}
+
+// AddRichText adds commands to draw the specified rich text layout with its top left corner at 'pos'.
+func (w *Window) AddRichText(dl *gb.DrawList, pos gb.Vec2, l *RichTextLayout) {
+
+	for li := 0; li < len(l.Lines); li++ {
+		line := &l.Lines[li]
+		top := pos.Y + line.Top
+		baseline := pos.Y + line.Baseline()
+
+		// Draws the spans backgrounds first, so they don't overlap the glyphs
+		for _, span := range line.Spans {
+			run := &l.Runs[span.Run]
+			if (run.Background & gb.RGBAMaskA) == 0 {
+				continue
+			}
+			min := gb.Vec2{pos.X + span.X, top}
+			max := gb.Vec2{pos.X + span.X + span.Width, top + line.Height}
+			w.AddRectFilled(dl, min, max, run.Background, 0, 0)
+		}
+
+		// Draws the spans glyphs and decorations
+		for _, span := range line.Spans {
+			run := &l.Runs[span.Run]
+			fa := l.fonts[span.Run]
+			origin := gb.Vec2{pos.X + span.X, baseline}
+			prev := rune(-1)
+			for _, code := range run.Text[span.Start:span.End] {
+				w.AddGlyph(dl, fa, &origin, run.Color, TextVAlignBase, prev, code)
+				prev = code
+			}
+			thickness := fa.ascent / 14
+			if thickness < 1 {
+				thickness = 1
+			}
+			if run.Underline {
+				y := baseline + fa.descent/3
+				w.AddRectFilled(dl, gb.Vec2{pos.X + span.X, y}, gb.Vec2{pos.X + span.X + span.Width, y + thickness}, run.Color, 0, 0)
+			}
+			if run.Strike {
+				y := baseline - fa.ascent/3
+				w.AddRectFilled(dl, gb.Vec2{pos.X + span.X, y}, gb.Vec2{pos.X + span.X + span.Width, y + thickness}, run.Color, 0, 0)
+			}
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package window
+
+import (
+	"unicode/utf8"
+
+	"github.com/leonsal/gux/gb"
+)
+
+// TextLayout contains the positions of the glyphs of a text string as drawn by AddText()
+// and allows mapping between byte indices of the text and positions relative to the text origin.
+// The text origin is the position passed to AddText() and its meaning depends on the vertical alignment.
+// All indices are byte offsets in the text and always refer to the start of a rune.
+type TextLayout struct {
+	fa    *FontAtlas // Font atlas used for the layout
+	text  string     // Laid out text
+	align TextVAlign // Vertical alignment
+	lines []textLine // Information for each line of the text
+}
+
+// textLine contains the caret positions for a single line of text
+type textLine struct {
+	top     float32   // Line top relative to the text origin
+	offsets []int     // Byte offset in the text for each caret position in the line
+	carets  []float32 // Horizontal offset of each caret position relative to the text origin
+}
+
+// NewTextLayout creates and returns the layout for the specified text drawn
+// with the specified font atlas and vertical alignment.
+func NewTextLayout(fa *FontAtlas, text string, align TextVAlign) *TextLayout {
+
+	l := &TextLayout{fa: fa, align: align}
+	l.SetText(text)
+	return l
+}
+
+// SetText sets the text of the layout and updates the glyph positions
+func (l *TextLayout) SetText(text string) {
+
+	l.text = text
+	l.lines = l.lines[:0]
+
+	// Calculates the top of the first line relative to the origin
+	var top float32
+	switch l.align {
+	case TextVAlignBase:
+		top = -l.fa.ascent
+	case TextVAlignBottom:
+		top = -l.fa.descent - l.fa.ascent
+	}
+
+	// The caret positions are calculated exactly as AddGlyph() advances its origin.
+	line := textLine{top: top}
+	var x float32
+	prev := rune(-1)
+	for offset := 0; offset < len(text); {
+		code, size := utf8.DecodeRuneInString(text[offset:])
+		if code == 0x0A {
+			line.offsets = append(line.offsets, offset)
+			line.carets = append(line.carets, x)
+			l.lines = append(l.lines, line)
+			line = textLine{top: line.top + l.fa.height}
+			x = 0
+			prev = -1
+			offset += size
+			continue
+		}
+		if prev >= 0 {
+			x += l.fa.Kern(prev, code)
+		}
+		line.offsets = append(line.offsets, offset)
+		line.carets = append(line.carets, x)
+		x += l.fa.glyph(code).Advance
+		prev = code
+		offset += size
+	}
+	line.offsets = append(line.offsets, len(text))
+	line.carets = append(line.carets, x)
+	l.lines = append(l.lines, line)
+}
+
+// Text returns the current text of the layout
+func (l *TextLayout) Text() string {
+
+	return l.text
+}
+
+// FontAtlas returns the font atlas used by the layout
+func (l *TextLayout) FontAtlas() *FontAtlas {
+
+	return l.fa
+}
+
+// LineCount returns the number of lines of the text
+func (l *TextLayout) LineCount() int {
+
+	return len(l.lines)
+}
+
+// Size returns the width of the longest line and the total height of the text
+func (l *TextLayout) Size() gb.Vec2 {
+
+	var width float32
+	for i := 0; i < len(l.lines); i++ {
+		carets := l.lines[i].carets
+		if last := carets[len(carets)-1]; last > width {
+			width = last
+		}
+	}
+	return gb.Vec2{width, float32(len(l.lines)) * l.fa.height}
+}
+
+// IndexAtPoint returns the byte index of the caret position nearest to the specified
+// point relative to the text origin. Points above or below the text are mapped to
+// the first or last line respectively.
+func (l *TextLayout) IndexAtPoint(x, y float32) int {
+
+	li := int((y - l.lines[0].top) / l.fa.height)
+	if li < 0 {
+		li = 0
+	} else if li >= len(l.lines) {
+		li = len(l.lines) - 1
+	}
+	line := &l.lines[li]
+	for i := 0; i < len(line.carets)-1; i++ {
+		if x < (line.carets[i]+line.carets[i+1])/2 {
+			return line.offsets[i]
+		}
+	}
+	return line.offsets[len(line.offsets)-1]
+}
+
+// CaretRect returns the rectangle with zero width of the caret at the specified byte index
+// relative to the text origin. The rectangle height spans from the line ascent to its descent.
+func (l *TextLayout) CaretRect(index int) gb.Rect {
+
+	li, ci := l.find(index)
+	line := &l.lines[li]
+	x := line.carets[ci]
+	return gb.Rect{
+		Min: gb.Vec2{x, line.top},
+		Max: gb.Vec2{x, line.top + l.fa.ascent + l.fa.descent},
+	}
+}
+
+// SelectionRects returns the rectangles, one for each line, which cover the text between
+// the specified byte indices relative to the text origin.
+// The indices can be specified in any order.
+func (l *TextLayout) SelectionRects(start, end int) []gb.Rect {
+
+	if start > end {
+		start, end = end, start
+	}
+	if start == end {
+		return nil
+	}
+	li0, ci0 := l.find(start)
+	li1, ci1 := l.find(end)
+	rects := make([]gb.Rect, 0, li1-li0+1)
+	for li := li0; li <= li1; li++ {
+		line := &l.lines[li]
+		minX := line.carets[0]
+		if li == li0 {
+			minX = line.carets[ci0]
+		}
+		maxX := line.carets[len(line.carets)-1]
+		if li == li1 {
+			maxX = line.carets[ci1]
+		}
+		rects = append(rects, gb.Rect{
+			Min: gb.Vec2{minX, line.top},
+			Max: gb.Vec2{maxX, line.top + l.fa.height},
+		})
+	}
+	return rects
+}
+
+// find returns the line index and caret index in this line for the specified byte index.
+// Indices inside a multibyte rune are moved to the start of the rune.
+func (l *TextLayout) find(index int) (int, int) {
+
+	if index < 0 {
+		return 0, 0
+	}
+	for li := 0; li < len(l.lines); li++ {
+		offsets := l.lines[li].offsets
+		if index > offsets[len(offsets)-1] {
+			continue
+		}
+		for ci := len(offsets) - 1; ci >= 0; ci-- {
+			if offsets[ci] <= index {
+				return li, ci
+			}
+		}
+		return li, 0
+	}
+	last := len(l.lines) - 1
+	return last, len(l.lines[last].offsets) - 1
+}
@@ -0,0 +1,165 @@
+package window
+
+import (
+	"reflect"
+	"testing"
+	"unicode"
+
+	"github.com/leonsal/gux/gb"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+// testTextAtlas returns a FontAtlas with fixed glyph advances and metrics, which does not need a window.
+// The Go fonts have no kerning, so the positions depend only on the advances.
+func testTextAtlas(t *testing.T) *FontAtlas {
+
+	opts := opentype.FaceOptions{Size: 14, DPI: 72, Hinting: font.HintingNone}
+	_, face, err := newFace(goregular.TTF, &opts, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &FontAtlas{
+		face: face,
+		glyphs: map[rune]GlyphInfo{
+			'a':                     {Advance: 10},
+			'b':                     {Advance: 20},
+			'é':                     {Advance: 15},
+			unicode.ReplacementChar: {Advance: 5},
+		},
+		ascent:  10,
+		descent: 4,
+		height:  16,
+		scale:   1,
+	}
+}
+
+func TestMeasureString(t *testing.T) {
+
+	fa := testTextAtlas(t)
+	cases := []struct {
+		text string
+		want float32
+	}{
+		{"", 0},
+		{"ab", 30},
+		{"aé", 25},
+		{"a?", 15},      // Missing runes advance by the replacement char
+		{"ab\na", 30},   // Longest line
+		{"a\nbb\n", 40}, // Longest line
+		{"\n\n", 0},
+	}
+	for _, c := range cases {
+		if got := fa.MeasureString(c.text); got != c.want {
+			t.Errorf("MeasureString(%q): got %v, want %v", c.text, got, c.want)
+		}
+	}
+}
+
+func TestAddTextPos(t *testing.T) {
+
+	fa := testTextAtlas(t)
+	cases := []struct {
+		text string
+		want gb.Vec2
+	}{
+		{"", gb.Vec2{100, 50}},
+		{"ab", gb.Vec2{130, 50}},
+		{"a?", gb.Vec2{115, 50}},    // Missing runes advance by the replacement char
+		{"ab\nb", gb.Vec2{120, 66}}, // New line restarts at the initial X
+		{"a\n\n", gb.Vec2{100, 82}},
+	}
+	var w Window
+	for _, c := range cases {
+		var dl gb.DrawList
+		pos := gb.Vec2{100, 50}
+		w.AddText(&dl, fa, &pos, gb.RGBAWhite, TextVAlignBase, c.text)
+		if pos != c.want {
+			t.Errorf("AddText(%q): pos %v, want %v", c.text, pos, c.want)
+		}
+		pos = gb.Vec2{100, 50}
+		w.AddTextBytes(&dl, fa, &pos, gb.RGBAWhite, TextVAlignBase, []byte(c.text))
+		if pos != c.want {
+			t.Errorf("AddTextBytes(%q): pos %v, want %v", c.text, pos, c.want)
+		}
+		if size := NewTextLayout(fa, c.text, TextVAlignBase).Size(); size.X != fa.MeasureString(c.text) {
+			t.Errorf("layout of %q: width %v, want %v", c.text, size.X, fa.MeasureString(c.text))
+		}
+	}
+}
+
+func TestTextLayoutIndexAtPoint(t *testing.T) {
+
+	// Lines "aé" (carets 0, 10, 25) and "b" (carets 0, 20) with top at 0 and 16
+	l := NewTextLayout(testTextAtlas(t), "aé\nb", TextVAlignTop)
+	cases := []struct {
+		x, y float32
+		want int
+	}{
+		{-5, 5, 0},
+		{4, 5, 0},
+		{6, 5, 1},
+		{17, 5, 1},
+		{18, 5, 3},
+		{100, 5, 3},
+		{9, 20, 4},
+		{11, 20, 5},
+		{4, -30, 0},  // Above the text
+		{50, 100, 5}, // Below the text
+	}
+	for _, c := range cases {
+		if got := l.IndexAtPoint(c.x, c.y); got != c.want {
+			t.Errorf("IndexAtPoint(%v, %v): got %d, want %d", c.x, c.y, got, c.want)
+		}
+	}
+}
+
+func TestTextLayoutCaretRect(t *testing.T) {
+
+	l := NewTextLayout(testTextAtlas(t), "aé\nb", TextVAlignBase)
+	cases := []struct {
+		index int
+		want  gb.Rect
+	}{
+		{-1, gb.Rect{Min: gb.Vec2{0, -10}, Max: gb.Vec2{0, 4}}},
+		{0, gb.Rect{Min: gb.Vec2{0, -10}, Max: gb.Vec2{0, 4}}},
+		{1, gb.Rect{Min: gb.Vec2{10, -10}, Max: gb.Vec2{10, 4}}},
+		{2, gb.Rect{Min: gb.Vec2{10, -10}, Max: gb.Vec2{10, 4}}}, // Inside the multibyte rune
+		{3, gb.Rect{Min: gb.Vec2{25, -10}, Max: gb.Vec2{25, 4}}},
+		{4, gb.Rect{Min: gb.Vec2{0, 6}, Max: gb.Vec2{0, 20}}},
+		{5, gb.Rect{Min: gb.Vec2{20, 6}, Max: gb.Vec2{20, 20}}},
+		{9, gb.Rect{Min: gb.Vec2{20, 6}, Max: gb.Vec2{20, 20}}},
+	}
+	for _, c := range cases {
+		if got := l.CaretRect(c.index); got != c.want {
+			t.Errorf("CaretRect(%d): got %v, want %v", c.index, got, c.want)
+		}
+	}
+}
+
+func TestTextLayoutSelectionRects(t *testing.T) {
+
+	l := NewTextLayout(testTextAtlas(t), "aé\nb", TextVAlignTop)
+	cases := []struct {
+		start, end int
+		want       []gb.Rect
+	}{
+		{1, 1, nil},
+		{0, 1, []gb.Rect{{Min: gb.Vec2{0, 0}, Max: gb.Vec2{10, 16}}}},
+		{3, 1, []gb.Rect{{Min: gb.Vec2{10, 0}, Max: gb.Vec2{25, 16}}}},
+		{1, 5, []gb.Rect{
+			{Min: gb.Vec2{10, 0}, Max: gb.Vec2{25, 16}},
+			{Min: gb.Vec2{0, 16}, Max: gb.Vec2{20, 32}},
+		}},
+		{3, 4, []gb.Rect{
+			{Min: gb.Vec2{25, 0}, Max: gb.Vec2{25, 16}},
+			{Min: gb.Vec2{0, 16}, Max: gb.Vec2{0, 32}},
+		}},
+	}
+	for _, c := range cases {
+		if got := l.SelectionRects(c.start, c.end); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("SelectionRects(%d, %d): got %v, want %v", c.start, c.end, got, c.want)
+		}
+	}
+}
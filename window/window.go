@@ -1,7 +1,10 @@
 package window
 
 import (
+	"time"
+
 	"github.com/leonsal/gux/gb"
+	"github.com/leonsal/gux/util"
 )
 
 const TexLinesWidthMax = 63
@@ -38,6 +41,7 @@ type Window struct {
 	gbw                  *gb.Window                    // Graphics backend native window reference
 	dl                   gb.DrawList                   // Draw list to render
 	fm                   *FontManager                  // Current FontManager
+	onDestroy            []func(*Window)               // Functions called when the window is destroyed
 	TexWhiteId           gb.TextureID                  // Texture with white opaque pixel
 	TexLinesId           gb.TextureID                  // Texture for lines
 	TexUvLines           [TexLinesWidthMax + 1]gb.Vec4 // UV coordinates for textured lines
@@ -46,8 +50,15 @@ type Window struct {
 	drawFlags            DrawListFlags                 // Flags, you may poke into these to adjust anti-aliasing settings per-primitive.
 	frameParams          gb.FrameParams
 	frameInfo            gb.FrameInfo
-	CurveTessellationTol float32 // IN STYLES ? Tessellation tolerance when using PathBezierCurveTo() without a specific number of segments. Decrease for highly tessellated curves (higher quality, more polygons), increase to reduce quality.
-	clipRect             gb.Rect // Current clip rectangle for Draw Commands
+	CurveTessellationTol float32   // IN STYLES ? Tessellation tolerance when using PathBezierCurveTo() without a specific number of segments. Decrease for highly tessellated curves (higher quality, more polygons), increase to reduce quality.
+	clipRect             gb.Rect   // Current clip rectangle for Draw Commands
+	clipStack            []gb.Rect // Clip rectangles saved by PushClipRect()
+	evTimeout            float32   // Event timeout set by the user
+	frameRequest         float32   // Maximum event timeout requested for the next frame (negative if none)
+	frameTime            time.Time // Start time of the current frame
+	frameDelta           float32   // Time in seconds since the start of the previous frame
+	fontScaleErr         float32   // Framebuffer scale for which the last fonts rebuild failed
+	fontErr              error     // Error of the last fonts rebuild (nil if it succeeded)
 }
 
 // New creates and returns a new Window
@@ -71,7 +82,10 @@ func New(title string, width, height int, cfg *gb.Config) (*Window, error) {
 	//w.frameParams.ClearColor = gb.Vec4{0.5, 0.5, 0.5, 1.0}
 	w.frameParams.ClearColor = gb.Vec4{1.0, 1.0, 1.0, 1.0}
 	w.frameInfo.WinSize = gb.Vec2{float32(width), float32(height)}
+	w.frameInfo.FbScale = w.gbw.FbScale()
 	w.CurveTessellationTol = 1.25
+	w.frameRequest = -1
+	w.frameTime = time.Now()
 	return w, nil
 }
 
@@ -81,6 +95,16 @@ func (w *Window) Size() gb.Vec2 {
 	return w.frameInfo.WinSize
 }
 
+// Scale returns the current ratio between the window framebuffer size and its size.
+// It is greater than 1 for HiDPI displays.
+func (w *Window) Scale() float32 {
+
+	if w.frameInfo.FbScale.X <= 0 {
+		return 1
+	}
+	return w.frameInfo.FbScale.X
+}
+
 func (w *Window) SetFontManager(fm *FontManager) {
 
 	w.fm = fm
@@ -106,14 +130,65 @@ func (w *Window) SetClipRect(r gb.Rect) {
 	w.clipRect = r
 }
 
+// ClipRect returns the current clip rectangle in window coordinates used for new draw commands
+func (w *Window) ClipRect() gb.Rect {
+
+	return w.clipRect
+}
+
+// PushClipRect saves the current clip rectangle and sets its intersection with the
+// specified rectangle in window coordinates as the new clip rectangle, so nested clip
+// rectangles never draw outside their parents.
+func (w *Window) PushClipRect(r gb.Rect) {
+
+	w.clipStack = append(w.clipStack, w.clipRect)
+	w.clipRect = w.clipRect.Intersect(r)
+}
+
+// PopClipRect restores the clip rectangle saved by the last PushClipRect()
+func (w *Window) PopClipRect() {
+
+	if len(w.clipStack) == 0 {
+		w.ClearClipRect()
+		return
+	}
+	w.clipRect = w.clipStack[len(w.clipStack)-1]
+	w.clipStack = w.clipStack[:len(w.clipStack)-1]
+}
+
 func (w *Window) SetClearColor(color gb.Vec4) {
 
 	w.frameParams.ClearColor = color
 }
 
+// SetEvTimeout sets the maximum time in seconds StartFrame() waits for events.
+// Zero does not wait, rendering frames continuously.
 func (w *Window) SetEvTimeout(timeout float32) {
 
-	w.frameParams.EvTimeout = timeout
+	w.evTimeout = timeout
+}
+
+// RequestFrame requests the next frame to start after at most the specified
+// timeout in seconds, even if there are no events and the event timeout is longer.
+// Views use it to drive animations and timers. The request is only valid for the next frame.
+func (w *Window) RequestFrame(timeout float32) {
+
+	timeout = util.Max(timeout, 0)
+	if w.frameRequest < 0 || timeout < w.frameRequest {
+		w.frameRequest = timeout
+	}
+}
+
+// FrameTime returns the start time of the current frame
+func (w *Window) FrameTime() time.Time {
+
+	return w.frameTime
+}
+
+// FrameDelta returns the time in seconds between the start of the previous frame and the current one
+func (w *Window) FrameDelta() float32 {
+
+	return w.frameDelta
 }
 
 // StartFrame sets the beginning of a new render frame and returns true if
@@ -122,11 +197,41 @@ func (w *Window) StartFrame() bool {
 
 	w.dl.Clear()
 	w.bufVec2 = w.bufVec2[:0]
+
+	// Uses the event timeout requested for this frame if shorter than the user timeout
+	w.frameParams.EvTimeout = w.evTimeout
+	if w.frameRequest >= 0 && w.evTimeout > 0 && w.frameRequest < w.evTimeout {
+		w.frameParams.EvTimeout = w.frameRequest
+	}
+	w.frameRequest = -1
 	w.frameInfo = w.gbw.StartFrame(&w.frameParams)
+
+	now := time.Now()
+	w.frameDelta = float32(now.Sub(w.frameTime).Seconds())
+	w.frameTime = now
 	w.ClearClipRect()
+	w.clipStack = w.clipStack[:0]
+
+	// Rebuilds the fonts if the window moved to a monitor with a different content scale.
+	// On error the previous fonts are kept, the error is reported by FontError() and
+	// the rebuild is only retried after the scale changes again.
+	if scale := w.Scale(); w.fm != nil && w.fm.Scale() != scale && w.fontScaleErr != scale {
+		w.fontErr = w.fm.RebuildFonts(w)
+		w.fontScaleErr = 0
+		if w.fontErr != nil {
+			w.fontScaleErr = scale
+		}
+	}
 	return w.frameInfo.WinClose
 }
 
+// FontError returns the error of the last rebuild of the fonts for a new content scale of the window
+// or nil if it succeeded. After a failed rebuild the window keeps using the fonts of the previous scale.
+func (w *Window) FontError() error {
+
+	return w.fontErr
+}
+
 // RenderFrame sends this Windows' DrawList to the Graphics Backend for rendering
 func (w *Window) RenderFrame() {
 
@@ -141,6 +246,10 @@ func (w *Window) AddList(src *gb.DrawList) {
 
 func (w *Window) Destroy() {
 
+	for _, cb := range w.onDestroy {
+		cb(w)
+	}
+	w.onDestroy = nil
 	if w.fm != nil {
 		w.fm.DestroyFonts(w)
 	}
@@ -149,11 +258,30 @@ func (w *Window) Destroy() {
 	w.gbw.Destroy()
 }
 
+// OnDestroy adds a function called when the window is destroyed, before its resources are released.
+// It allows packages which keep state for each window to release it.
+func (w *Window) OnDestroy(cb func(w *Window)) {
+
+	w.onDestroy = append(w.onDestroy, cb)
+}
+
 func (w *Window) SetCursor(cursor gb.Cursor) {
 
 	w.gbw.SetCursor(cursor)
 }
 
+// Clipboard returns the current clipboard text or an empty string if not available
+func (w *Window) Clipboard() string {
+
+	return w.gbw.Clipboard()
+}
+
+// SetClipboard sets the clipboard text
+func (w *Window) SetClipboard(text string) {
+
+	w.gbw.SetClipboard(text)
+}
+
 func (w *Window) FrameInfo() *gb.FrameInfo {
 
 	return &w.frameInfo